@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// checker holds the processors built from a configuration, one per signal.
+type checker struct {
+	// contexts describes the OTTL context used by each group of statements or conditions.
+	contexts []string
+
+	traces       processor.Traces
+	tracesSink   *consumertest.TracesSink
+	metrics      processor.Metrics
+	metricsSink  *consumertest.MetricsSink
+	logs         processor.Logs
+	logsSink     *consumertest.LogsSink
+	profiles     xprocessor.Profiles
+	profilesSink *consumertest.ProfilesSink
+}
+
+// newChecker loads and validates the configuration of the processor identified by id,
+// and builds it for every signal using the same factory as the collector does, so the
+// statements are parsed with the exact functions and contexts available at runtime.
+func newChecker(ctx context.Context, path string, id component.ID) (_ *checker, err error) {
+	fs := factories()
+	factory, ok := fs[id.Type()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported processor type %q, expected one of %q", id.Type(), supportedTypes(fs))
+	}
+	cfg, err := loadConfig(path, id, factory)
+	if err != nil {
+		return nil, err
+	}
+	if err = xconfmap.Validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	c := &checker{
+		tracesSink:  new(consumertest.TracesSink),
+		metricsSink: new(consumertest.MetricsSink),
+		logsSink:    new(consumertest.LogsSink),
+	}
+	groups := statementsGroups(cfg)
+	defer func() {
+		if err != nil {
+			_ = c.shutdown(ctx)
+		}
+	}()
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs, xpipeline.SignalProfiles} {
+		set := processortest.NewNopSettings(factory.Type())
+		set.ID = id
+		// The processor logs the contexts it infers at debug level.
+		core, logs := observer.New(zap.DebugLevel)
+		set.Logger = zap.New(core)
+
+		var p component.Component
+		switch signal {
+		case pipeline.SignalTraces:
+			c.traces, err = factory.CreateTraces(ctx, set, cfg, c.tracesSink)
+			p = c.traces
+		case pipeline.SignalMetrics:
+			c.metrics, err = factory.CreateMetrics(ctx, set, cfg, c.metricsSink)
+			p = c.metrics
+		case pipeline.SignalLogs:
+			c.logs, err = factory.CreateLogs(ctx, set, cfg, c.logsSink)
+			p = c.logs
+		case xpipeline.SignalProfiles:
+			profilesFactory, ok := factory.(xprocessor.Factory)
+			if !ok {
+				continue
+			}
+			c.profilesSink = new(consumertest.ProfilesSink)
+			c.profiles, err = profilesFactory.CreateProfiles(ctx, set, cfg, c.profilesSink)
+			p = c.profiles
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to create %s processor: %w", signal, err)
+		}
+		if err = p.Start(ctx, componenttest.NewNopHost()); err != nil {
+			return nil, fmt.Errorf("unable to start %s processor: %w", signal, err)
+		}
+		if err = setInferredContexts(groups[signal], logs); err != nil {
+			return nil, err
+		}
+		c.contexts = append(c.contexts, describeContexts(id, groups[signal])...)
+	}
+	return c, nil
+}
+
+func describeContexts(id component.ID, groups []statementsGroup) []string {
+	descriptions := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.inferred {
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s: context %q (inferred)", id, g.name, g.context))
+			continue
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s: context %q", id, g.name, g.context))
+	}
+	return descriptions
+}
+
+// process applies the processor to each payload of in, leaving in untouched.
+// Payloads entirely dropped by the processor are returned empty.
+func (c *checker) process(ctx context.Context, in *payloads) (*payloads, error) {
+	out := &payloads{yaml: in.yaml}
+	for i, p := range in.items {
+		result := payload{signal: p.signal}
+		var err error
+		switch p.signal {
+		case pipeline.SignalTraces:
+			td := ptrace.NewTraces()
+			p.traces.CopyTo(td)
+			err = c.traces.ConsumeTraces(ctx, td)
+			result.traces = ptrace.NewTraces()
+			if all := c.tracesSink.AllTraces(); len(all) > 0 {
+				result.traces = all[0]
+			}
+			c.tracesSink.Reset()
+		case pipeline.SignalMetrics:
+			md := pmetric.NewMetrics()
+			p.metrics.CopyTo(md)
+			err = c.metrics.ConsumeMetrics(ctx, md)
+			result.metrics = pmetric.NewMetrics()
+			if all := c.metricsSink.AllMetrics(); len(all) > 0 {
+				result.metrics = all[0]
+			}
+			c.metricsSink.Reset()
+		case pipeline.SignalLogs:
+			ld := plog.NewLogs()
+			p.logs.CopyTo(ld)
+			err = c.logs.ConsumeLogs(ctx, ld)
+			result.logs = plog.NewLogs()
+			if all := c.logsSink.AllLogs(); len(all) > 0 {
+				result.logs = all[0]
+			}
+			c.logsSink.Reset()
+		case xpipeline.SignalProfiles:
+			if c.profiles == nil {
+				return nil, fmt.Errorf("payload %d: profiles are not supported by the processor", i+1)
+			}
+			pd := pprofile.NewProfiles()
+			p.profiles.CopyTo(pd)
+			err = c.profiles.ConsumeProfiles(ctx, pd)
+			result.profiles = pprofile.NewProfiles()
+			if all := c.profilesSink.AllProfiles(); len(all) > 0 {
+				result.profiles = all[0]
+			}
+			c.profilesSink.Reset()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("payload %d: %w", i+1, err)
+		}
+		out.items = append(out.items, result)
+	}
+	return out, nil
+}
+
+func (c *checker) shutdown(ctx context.Context) error {
+	var errs error
+	for _, p := range []component.Component{c.traces, c.metrics, c.logs, c.profiles} {
+		if p != nil {
+			errs = errors.Join(errs, p.Shutdown(ctx))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap/zaptest/observer"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+)
+
+// factories returns the processor factories supported by ottlcheck, keyed by type.
+func factories() map[component.Type]processor.Factory {
+	fs := map[component.Type]processor.Factory{}
+	for _, f := range []processor.Factory{
+		filterprocessor.NewFactory(),
+		transformprocessor.NewFactory(),
+	} {
+		fs[f.Type()] = f
+	}
+	return fs
+}
+
+func supportedTypes(fs map[component.Type]processor.Factory) []string {
+	types := make([]string, 0, len(fs))
+	for t := range fs {
+		types = append(types, t.String())
+	}
+	sort.Strings(types)
+	return types
+}
+
+// loadConfig reads the configuration of the processor identified by id from path.
+// The file can either contain the processor configuration itself or a collector
+// configuration, in which case the processor is looked up in its processors section.
+func loadConfig(path string, id component.ID, factory processor.Factory) (component.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err = yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	conf := confmap.NewFromStringMap(raw)
+	if processors, ok := raw["processors"]; ok {
+		section, _ := processors.(map[string]any)
+		if _, ok = section[id.String()]; !ok {
+			return nil, fmt.Errorf("processor %q is not defined in %s", id, path)
+		}
+		if conf, err = conf.Sub("processors::" + id.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := factory.CreateDefaultConfig()
+	if err = conf.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// statementsGroup is a list of statements or conditions parsed together by a processor.
+type statementsGroup struct {
+	// name is the configuration path of the group, e.g. log_statements[0].
+	name string
+	// context is the OTTL context the processor parses the group with. It is empty until
+	// the processor is created when the context is inferred from the statements.
+	context string
+	// inferred is whether the processor infers the context from the statements.
+	inferred bool
+}
+
+// statementsGroups returns the OTTL statements and conditions groups of cfg per signal,
+// in the order the processor parses them.
+func statementsGroups(cfg component.Config) map[pipeline.Signal][]statementsGroup {
+	groups := map[pipeline.Signal][]statementsGroup{}
+	switch c := cfg.(type) {
+	case *transformprocessor.Config:
+		appendGroup := func(signal pipeline.Signal, key string, i int, context string) {
+			name := fmt.Sprintf("%s[%d]", key, i)
+			groups[signal] = append(groups[signal], statementsGroup{name: name, context: context, inferred: context == ""})
+		}
+		for i, cs := range c.TraceStatements {
+			appendGroup(pipeline.SignalTraces, "trace_statements", i, string(cs.Context))
+		}
+		for i, cs := range c.MetricStatements {
+			appendGroup(pipeline.SignalMetrics, "metric_statements", i, string(cs.Context))
+		}
+		for i, cs := range c.LogStatements {
+			appendGroup(pipeline.SignalLogs, "log_statements", i, string(cs.Context))
+		}
+		for i, cs := range c.ProfileStatements {
+			appendGroup(xpipeline.SignalProfiles, "profile_statements", i, string(cs.Context))
+		}
+	case *filterprocessor.Config:
+		appendConditions := func(signal pipeline.Signal, name, context string, conditions []string) {
+			if len(conditions) > 0 {
+				groups[signal] = append(groups[signal], statementsGroup{name: name, context: context})
+			}
+		}
+		appendConditions(pipeline.SignalTraces, "traces.span", "span", c.Traces.SpanConditions)
+		appendConditions(pipeline.SignalTraces, "traces.spanevent", "spanevent", c.Traces.SpanEventConditions)
+		appendConditions(pipeline.SignalTraces, "traces.spanlink", "spanlink", c.Traces.SpanLinkConditions)
+		appendConditions(pipeline.SignalMetrics, "metrics.metric", "metric", c.Metrics.MetricConditions)
+		appendConditions(pipeline.SignalMetrics, "metrics.datapoint", "datapoint", c.Metrics.DataPointConditions)
+		appendConditions(pipeline.SignalLogs, "logs.log_record", "log", c.Logs.LogConditions)
+		appendConditions(xpipeline.SignalProfiles, "profiles.profile", "profile", c.Profiles.ProfileConditions)
+	}
+	return groups
+}
+
+// inferredContextMessage is the prefix of the debug message logged by the OTTL parser
+// collections with the context inferred from a group of statements.
+const inferredContextMessage = "Inferred context: "
+
+// setInferredContexts sets the context of the groups whose context is inferred from the
+// contexts the processor logged while parsing them, in the same order.
+func setInferredContexts(groups []statementsGroup, logs *observer.ObservedLogs) error {
+	var inferred []string
+	for _, entry := range logs.FilterMessageSnippet(inferredContextMessage).All() {
+		context, err := strconv.Unquote(strings.TrimPrefix(entry.Message, inferredContextMessage))
+		if err != nil {
+			return fmt.Errorf("unable to read the inferred context from %q: %w", entry.Message, err)
+		}
+		inferred = append(inferred, context)
+	}
+	for i := range groups {
+		if !groups[i].inferred {
+			continue
+		}
+		if len(inferred) == 0 {
+			return fmt.Errorf("%s: the processor did not report the inferred context", groups[i].name)
+		}
+		groups[i].context, inferred = inferred[0], inferred[1:]
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// ottlcheck validates the OTTL statements and conditions of a transform or
+// filter processor configuration and, optionally, applies them to an OTLP
+// payload so the result can be inspected without running a collector.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	exitOK = iota
+	exitInvalid
+	exitUsage
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ottlcheck", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "", "path to a processor or collector configuration file")
+	processorID := flags.String("processor", "", "ID of the processor to check, for example transform or filter/drop")
+	inputPath := flags.String("input", "", `path to an OTLP JSON or YAML payload to process, or "-" to read JSON from stdin`)
+	diff := flags.Bool("diff", false, "print a diff between the input and the processed payload instead of the processed payload")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: ottlcheck -config <file> -processor <id> [-input <file>] [-diff]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *configPath == "" || *processorID == "" || flags.NArg() > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *diff && *inputPath == "" {
+		fmt.Fprintln(stderr, "-diff requires -input")
+		return exitUsage
+	}
+
+	var id component.ID
+	if err := id.UnmarshalText([]byte(*processorID)); err != nil {
+		fmt.Fprintf(stderr, "invalid processor ID %q: %v\n", *processorID, err)
+		return exitUsage
+	}
+
+	ctx := context.Background()
+	c, err := newChecker(ctx, *configPath, id)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", id, err)
+		return exitInvalid
+	}
+	defer func() {
+		_ = c.shutdown(ctx)
+	}()
+	for _, r := range c.contexts {
+		fmt.Fprintln(stderr, r)
+	}
+	fmt.Fprintf(stderr, "%s: configuration is valid\n", id)
+
+	if *inputPath == "" {
+		return exitOK
+	}
+	in, err := readInput(*inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to read input: %v\n", err)
+		return exitInvalid
+	}
+	out, err := c.process(ctx, in)
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to process input: %v\n", err)
+		return exitInvalid
+	}
+	outText, err := out.render()
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to render output: %v\n", err)
+		return exitInvalid
+	}
+	if !*diff {
+		_, _ = io.WriteString(stdout, outText)
+		return exitOK
+	}
+
+	inText, err := in.render()
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to render input: %v\n", err)
+		return exitInvalid
+	}
+	d, err := unifiedDiff(inText, outText)
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to compute diff: %v\n", err)
+		return exitInvalid
+	}
+	if d == "" {
+		fmt.Fprintln(stderr, "the payload was not modified")
+	}
+	_, _ = io.WriteString(stdout, d)
+	return exitOK
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedCode   int
+		expectedStdout string
+		stdoutContains []string
+		expectedStderr []string
+	}{
+		{
+			name:         "valid transform configuration",
+			args:         []string{"-config", filepath.Join("testdata", "transform.yaml"), "-processor", "transform"},
+			expectedCode: exitOK,
+			expectedStderr: []string{
+				`transform: trace_statements[0]: context "span" (inferred)`,
+				`transform: metric_statements[0]: context "metric" (inferred)`,
+				`transform: log_statements[0]: context "log" (inferred)`,
+				`transform: log_statements[1]: context "resource"`,
+				`transform: log_statements[2]: context "log" (inferred)`,
+				`transform: profile_statements[0]: context "profile" (inferred)`,
+				`transform: configuration is valid`,
+			},
+		},
+		{
+			name:           "transform JSON payloads",
+			args:           []string{"-config", filepath.Join("testdata", "transform.yaml"), "-processor", "transform", "-input", filepath.Join("testdata", "logs.json")},
+			expectedCode:   exitOK,
+			expectedStdout: filepath.Join("testdata", "expected", "transform_logs.json"),
+		},
+		{
+			name:           "transform JSON payloads diff",
+			args:           []string{"-config", filepath.Join("testdata", "transform.yaml"), "-processor", "transform", "-input", filepath.Join("testdata", "logs.json"), "-diff"},
+			expectedCode:   exitOK,
+			expectedStdout: filepath.Join("testdata", "expected", "transform_logs.diff"),
+		},
+		{
+			name:           "filter YAML payload from a collector configuration",
+			args:           []string{"-config", filepath.Join("testdata", "collector.yaml"), "-processor", "filter/drop", "-input", filepath.Join("testdata", "traces.yaml")},
+			expectedCode:   exitOK,
+			expectedStdout: filepath.Join("testdata", "expected", "filter_traces.yaml"),
+			expectedStderr: []string{
+				`filter/drop: traces.span: context "span"`,
+				`filter/drop: logs.log_record: context "log"`,
+			},
+		},
+		{
+			name:           "payload from stdin",
+			args:           []string{"-config", filepath.Join("testdata", "collector.yaml"), "-processor", "transform", "-input", "-", "-diff"},
+			stdin:          `{"resourceLogs":[{"resource":{},"scopeLogs":[{"scope":{},"logRecords":[{"body":{"stringValue":"hello"}}]}]}]}`,
+			expectedCode:   exitOK,
+			expectedStderr: []string{`transform: log_statements[0]: context "log" (inferred)`},
+		},
+		{
+			name:           "profiles payload",
+			args:           []string{"-config", filepath.Join("testdata", "transform.yaml"), "-processor", "transform", "-input", "-", "-diff"},
+			stdin:          `{"resourceProfiles":[{"resource":{},"scopeProfiles":[{"scope":{},"profiles":[{"profileId":"0102030405060708090a0b0c0d0e0f10"}]}]}]}`,
+			expectedCode:   exitOK,
+			stdoutContains: []string{`"originalPayloadFormat": "pprof"`},
+		},
+		{
+			name:           "unmodified payload",
+			args:           []string{"-config", filepath.Join("testdata", "collector.yaml"), "-processor", "filter/drop", "-input", "-", "-diff"},
+			stdin:          `{"resourceSpans":[{"resource":{},"scopeSpans":[{"scope":{},"spans":[{"name":"get /users","kind":2}]}]}]}`,
+			expectedCode:   exitOK,
+			expectedStderr: []string{"the payload was not modified"},
+		},
+		{
+			name:           "invalid statement",
+			args:           []string{"-config", filepath.Join("testdata", "invalid.yaml"), "-processor", "transform"},
+			expectedCode:   exitInvalid,
+			expectedStderr: []string{"transform: invalid configuration: statement has invalid syntax"},
+		},
+		{
+			name:           "undefined processor",
+			args:           []string{"-config", filepath.Join("testdata", "collector.yaml"), "-processor", "transform/missing"},
+			expectedCode:   exitInvalid,
+			expectedStderr: []string{`processor "transform/missing" is not defined in`},
+		},
+		{
+			name:           "unsupported processor",
+			args:           []string{"-config", filepath.Join("testdata", "collector.yaml"), "-processor", "batch"},
+			expectedCode:   exitInvalid,
+			expectedStderr: []string{`unsupported processor type "batch", expected one of ["filter" "transform"]`},
+		},
+		{
+			name:           "payload without signal",
+			args:           []string{"-config", filepath.Join("testdata", "collector.yaml"), "-processor", "transform", "-input", "-"},
+			stdin:          `{"foo":[]}`,
+			expectedCode:   exitInvalid,
+			expectedStderr: []string{"unable to read input: payload 1: unable to determine the signal"},
+		},
+		{
+			name:           "missing config",
+			args:           []string{"-processor", "transform"},
+			expectedCode:   exitUsage,
+			expectedStderr: []string{"Usage: ottlcheck"},
+		},
+		{
+			name:           "diff without input",
+			args:           []string{"-config", filepath.Join("testdata", "transform.yaml"), "-processor", "transform", "-diff"},
+			expectedCode:   exitUsage,
+			expectedStderr: []string{"-diff requires -input"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			assert.Equal(t, tt.expectedCode, code, stderr.String())
+			for _, s := range tt.expectedStderr {
+				assert.Contains(t, stderr.String(), s)
+			}
+			for _, s := range tt.stdoutContains {
+				assert.Contains(t, stdout.String(), s)
+			}
+			if tt.expectedStdout != "" {
+				expected, err := os.ReadFile(tt.expectedStdout)
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), stdout.String())
+			}
+		})
+	}
+}
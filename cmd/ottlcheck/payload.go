@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"gopkg.in/yaml.v3"
+)
+
+// payload is a single OTLP payload of one signal.
+type payload struct {
+	signal   pipeline.Signal
+	traces   ptrace.Traces
+	metrics  pmetric.Metrics
+	logs     plog.Logs
+	profiles pprofile.Profiles
+}
+
+func (p payload) marshalJSON() ([]byte, error) {
+	switch p.signal {
+	case pipeline.SignalTraces:
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(p.traces)
+	case pipeline.SignalMetrics:
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(p.metrics)
+	case xpipeline.SignalProfiles:
+		return (&pprofile.JSONMarshaler{}).MarshalProfiles(p.profiles)
+	default:
+		return (&plog.JSONMarshaler{}).MarshalLogs(p.logs)
+	}
+}
+
+// payloads is the content of an input file: either a single payload in the YAML format
+// used by golden files, or one or more JSON payloads as read by the otlpjsonfile receiver.
+type payloads struct {
+	yaml  bool
+	items []payload
+}
+
+// readInput reads the payloads from path, or from stdin if path is "-".
+func readInput(path string, stdin io.Reader) (*payloads, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := &payloads{}
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		var m map[string]any
+		if err = yaml.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(m); err != nil {
+			return nil, err
+		}
+		p.yaml = true
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("payload %d: %w", len(p.items)+1, err)
+		}
+		item, err := unmarshalPayload(raw)
+		if err != nil {
+			return nil, fmt.Errorf("payload %d: %w", len(p.items)+1, err)
+		}
+		p.items = append(p.items, item)
+	}
+	if len(p.items) == 0 {
+		return nil, errors.New("no payload found")
+	}
+	return p, nil
+}
+
+func unmarshalPayload(raw []byte) (payload, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return payload{}, err
+	}
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if _, ok := fields[k]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	var err error
+	p := payload{}
+	switch {
+	case has("resourceSpans", "resource_spans"):
+		p.signal = pipeline.SignalTraces
+		p.traces, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(raw)
+	case has("resourceMetrics", "resource_metrics"):
+		p.signal = pipeline.SignalMetrics
+		p.metrics, err = (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(raw)
+	case has("resourceLogs", "resource_logs"):
+		p.signal = pipeline.SignalLogs
+		p.logs, err = (&plog.JSONUnmarshaler{}).UnmarshalLogs(raw)
+	case has("resourceProfiles", "resource_profiles"):
+		p.signal = xpipeline.SignalProfiles
+		p.profiles, err = (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(raw)
+	default:
+		return payload{}, errors.New("unable to determine the signal, expected one of resourceSpans, resourceMetrics, resourceLogs or resourceProfiles")
+	}
+	return p, err
+}
+
+// render returns the payloads in the format they were read in, indented so they can be diffed.
+func (p *payloads) render() (string, error) {
+	var b bytes.Buffer
+	for _, item := range p.items {
+		data, err := item.marshalJSON()
+		if err != nil {
+			return "", err
+		}
+		if !p.yaml {
+			if err = json.Indent(&b, data, "", "  "); err != nil {
+				return "", err
+			}
+			b.WriteByte('\n')
+			continue
+		}
+		var m map[string]any
+		if err = json.Unmarshal(data, &m); err != nil {
+			return "", err
+		}
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err = enc.Encode(m); err != nil {
+			return "", err
+		}
+		if err = enc.Close(); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+func unifiedDiff(input, output string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(input),
+		B:        difflib.SplitLines(output),
+		FromFile: "input",
+		ToFile:   "output",
+		Context:  3,
+	})
+}
@@ -7,9 +7,17 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 var (
@@ -39,6 +47,11 @@ type Config struct {
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+	// UserFunctions are OTTL functions defined in the configuration, which can be used in the
+	// statements and conditions of the routing table. Their invocations are expanded before the
+	// statements and conditions are parsed.
+	// Optional.
+	UserFunctions []ottl.UserFunction `mapstructure:"ottl_functions"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -76,6 +89,91 @@ func (c *Config) Validate() error {
 			return errors.New("invalid context: " + item.Context)
 		}
 	}
+
+	userFunctions, err := c.newUserFunctions()
+	if err != nil {
+		return err
+	}
+	if userFunctions != nil {
+		if _, err = c.routingTable(userFunctions); err != nil {
+			return err
+		}
+		if err = c.validateUserFunctions(userFunctions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newUserFunctions returns the UserFunctions expanding the configured user functions, or nil if
+// there are none.
+func (c *Config) newUserFunctions() (*ottl.UserFunctions, error) {
+	if len(c.UserFunctions) == 0 {
+		return nil, nil
+	}
+	return ottl.NewUserFunctions(c.UserFunctions)
+}
+
+// routingTable returns the routing table with the invocations of the user functions expanded.
+func (c *Config) routingTable(userFunctions *ottl.UserFunctions) ([]RoutingTableItem, error) {
+	if userFunctions == nil {
+		return c.Table, nil
+	}
+
+	table := make([]RoutingTableItem, len(c.Table))
+	for i, item := range c.Table {
+		table[i] = item
+		switch {
+		case item.Context == "request":
+			// request conditions are not OTTL conditions
+		case item.Condition != "":
+			conditions, err := userFunctions.ExpandConditions([]string{item.Condition})
+			if err != nil {
+				return nil, err
+			}
+			table[i].Condition = conditions[0]
+		case item.Statement != "":
+			statements, err := userFunctions.ExpandStatements([]string{item.Statement})
+			if err != nil {
+				return nil, err
+			}
+			if len(statements) != 1 {
+				return nil, fmt.Errorf("invalid route: statement %q expands to %d statements instead of one", item.Statement, len(statements))
+			}
+			table[i].Statement = statements[0]
+		}
+	}
+	return table, nil
+}
+
+// validateUserFunctions returns an error if a user function has the name of a function, or one of
+// its parameters the name of a path, of one of the contexts of the routing table.
+func (c *Config) validateUserFunctions(userFunctions *ottl.UserFunctions) error {
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	validated := make(map[string]bool)
+	for _, item := range c.Table {
+		if validated[item.Context] {
+			continue
+		}
+		validated[item.Context] = true
+
+		var err error
+		switch item.Context {
+		case "", "resource":
+			err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlresource.NewParser, common.Functions[ottlresource.TransformContext](), set)
+		case "span":
+			err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlspan.NewParser, common.Functions[ottlspan.TransformContext](), set)
+		case "metric":
+			err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlmetric.NewParser, common.Functions[ottlmetric.TransformContext](), set)
+		case "datapoint":
+			err = ottl.ValidateUserFunctionsForParser(userFunctions, ottldatapoint.NewParser, common.Functions[ottldatapoint.TransformContext](), set)
+		case "log":
+			err = ottl.ValidateUserFunctionsForParser(userFunctions, ottllog.NewParser, common.Functions[ottllog.TransformContext](), set)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
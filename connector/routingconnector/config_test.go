@@ -361,6 +361,56 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "user function",
+			config: &Config{
+				UserFunctions: []ottl.UserFunction{
+					{Name: "IsTenant", Params: []string{"tenant"}, Expression: `attributes["tenant"] == tenant`},
+				},
+				Table: []RoutingTableItem{
+					{
+						Condition: `IsTenant("acme")`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user function with path parameter",
+			config: &Config{
+				UserFunctions: []ottl.UserFunction{
+					{Name: "IsTenant", Params: []string{"attributes"}, Expression: `attributes["tenant"] == "acme"`},
+				},
+				Table: []RoutingTableItem{
+					{
+						Condition: `IsTenant(attributes)`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `invalid user function "IsTenant": parameter "attributes" has the name of a path, which it would hide in the body of the function`,
+		},
+		{
+			name: "user editor expanding to several statements",
+			config: &Config{
+				UserFunctions: []ottl.UserFunction{
+					{Name: "route_twice", Statements: []string{"route()", "route()"}},
+				},
+				Table: []RoutingTableItem{
+					{
+						Statement: `route_twice()`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `invalid route: statement "route_twice()" expands to 2 statements instead of one`,
+		},
 		{
 			name: "log context with condition",
 			config: &Config{
@@ -414,3 +464,28 @@ func testConfig(opts ...testConfigOption) *Config {
 	}
 	return cfg
 }
+
+func TestRoutingTableUserFunctions(t *testing.T) {
+	cfg := testConfig(
+		withRoute("request", `request["X-Tenant"] == "acme"`, pipeline.NewIDWithName(pipeline.SignalTraces, "0")),
+		withRoute("span", `IsTenant("acme")`, pipeline.NewIDWithName(pipeline.SignalTraces, "1")),
+	)
+	cfg.Table = append(cfg.Table, RoutingTableItem{
+		Statement: `route() where IsTenant("globex")`,
+		Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "2")},
+	})
+	cfg.UserFunctions = []ottl.UserFunction{
+		{Name: "IsTenant", Params: []string{"tenant"}, Expression: `attributes["tenant"] == tenant`},
+	}
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	userFunctions, err := cfg.newUserFunctions()
+	require.NoError(t, err)
+	table, err := cfg.routingTable(userFunctions)
+	require.NoError(t, err)
+	require.Len(t, table, 3)
+	assert.Equal(t, `request["X-Tenant"] == "acme"`, table[0].Condition)
+	assert.Equal(t, `(attributes["tenant"] == "acme")`, table[1].Condition)
+	assert.Equal(t, `route() where (attributes["tenant"] == "globex")`, table[2].Statement)
+	assert.Equal(t, `IsTenant("acme")`, cfg.Table[1].Condition)
+}
@@ -40,8 +40,17 @@ func newMetricsConnector(
 		return nil, errUnexpectedConsumer
 	}
 
+	userFunctions, err := cfg.newUserFunctions()
+	if err != nil {
+		return nil, err
+	}
+	table, err := cfg.routingTable(userFunctions)
+	if err != nil {
+		return nil, err
+	}
+
 	r, err := newRouter(
-		cfg.Table,
+		table,
 		cfg.DefaultPipelines,
 		mr.Consumer,
 		set.TelemetrySettings)
@@ -39,8 +39,17 @@ func newTracesConnector(
 		return nil, errUnexpectedConsumer
 	}
 
+	userFunctions, err := cfg.newUserFunctions()
+	if err != nil {
+		return nil, err
+	}
+	table, err := cfg.routingTable(userFunctions)
+	if err != nil {
+		return nil, err
+	}
+
 	r, err := newRouter(
-		cfg.Table,
+		table,
 		cfg.DefaultPipelines,
 		tr.Consumer,
 		set.TelemetrySettings)
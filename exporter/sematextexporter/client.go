@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+)
+
+// sematextHTTPClient holds the HTTP client of the logs and traces writers,
+// created when the exporter starts, and sends their requests to Sematext.
+type sematextHTTPClient struct {
+	httpClient *http.Client
+
+	httpClientSettings confighttp.ClientConfig
+	telemetrySettings  component.TelemetrySettings
+	telemetryBuilder   *metadata.TelemetryBuilder
+	logger             sematextLogger
+	// signal names the writer in its logs and the responses in its telemetry.
+	signal string
+}
+
+func newSematextHTTPClient(logger sematextLogger, config *Config, telemetrySettings component.TelemetrySettings, signal string) (sematextHTTPClient, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(telemetrySettings)
+	if err != nil {
+		return sematextHTTPClient{}, err
+	}
+	return sematextHTTPClient{
+		httpClientSettings: config.ClientConfig,
+		telemetrySettings:  telemetrySettings,
+		telemetryBuilder:   telemetryBuilder,
+		logger:             logger,
+		signal:             signal,
+	}, nil
+}
+
+// Start implements component.StartFunc
+func (c *sematextHTTPClient) Start(ctx context.Context, host component.Host) error {
+	httpClient, err := c.httpClientSettings.ToClient(ctx, host, c.telemetrySettings)
+	if err != nil {
+		return err
+	}
+	c.httpClient = httpClient
+	return nil
+}
+
+func (c *sematextHTTPClient) Shutdown(_ context.Context) error {
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
+	c.telemetryBuilder.Shutdown()
+	c.logger.Debug("HTTP client connections closed successfully for Sematext writer", "signal", c.signal)
+	return nil
+}
+
+// send sends req to Sematext and returns the body of the response. The
+// response is counted under the signal of the client, and a non-2xx status is
+// turned into an error by checkResponse.
+func (c *sematextHTTPClient) send(ctx context.Context, req *http.Request, what string) ([]byte, error) {
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = res.Body.Close(); err != nil {
+		return nil, err
+	}
+
+	recordResponse(ctx, c.telemetryBuilder, c.signal, res.StatusCode, time.Since(start))
+	if err = checkResponse(res, body, what); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
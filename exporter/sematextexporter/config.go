@@ -4,13 +4,22 @@
 package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/influxdata/influxdb-observability/common"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 const (
@@ -18,6 +27,12 @@ const (
 	usRegion          = "us"
 	euMetricsEndpoint = "https://spm-receiver.eu.sematext.com"
 	usMetricsEndpoint = "https://spm-receiver.sematext.com"
+	euLogsEndpoint    = "https://logsene-receiver.eu.sematext.com"
+	usLogsEndpoint    = "https://logsene-receiver.sematext.com"
+	euTracesEndpoint  = "https://otlp-receiver.eu.sematext.com"
+	usTracesEndpoint  = "https://otlp-receiver.sematext.com"
+	euEventsEndpoint  = "https://event-receiver.eu.sematext.com"
+	usEventsEndpoint  = "https://event-receiver.sematext.com"
 )
 
 type Config struct {
@@ -31,6 +46,10 @@ type Config struct {
 	Region string `mapstructure:"region"`
 	// MetricsConfig defines the configuration specific to metrics
 	MetricsConfig `mapstructure:"metrics"`
+	// LogsConfig defines the configuration specific to logs
+	LogsConfig `mapstructure:"logs"`
+	// TracesConfig defines the configuration specific to traces
+	TracesConfig `mapstructure:"traces"`
 }
 type MetricsConfig struct {
 	// App token is the token of Sematext Monitoring App to which you want to send the metrics.
@@ -38,12 +57,133 @@ type MetricsConfig struct {
 	// MetricsEndpoint specifies the endpoint for receiving metrics in Sematext
 	MetricsEndpoint string `mapstructure:"-"`
 	// MetricsSchema indicates the metrics schema to emit to line protocol.
+	// Options: telegraf-prometheus-v1, telegraf-prometheus-v2, otel-v1
 	// Default: telegraf-prometheus-v2
-	MetricsSchema string `mapstructure:"-"`
+	MetricsSchema string `mapstructure:"metrics_schema"`
 	// PayloadMaxLines is the maximum number of line protocol lines to POST in a single request.
 	PayloadMaxLines int `mapstructure:"payload_max_lines"`
 	// PayloadMaxBytes is the maximum number of line protocol bytes to POST in a single request.
 	PayloadMaxBytes int `mapstructure:"payload_max_bytes"`
+	// Tags configures which metric attributes are sent to Sematext as tags.
+	Tags TagsConfig `mapstructure:"tags"`
+	// HostMetadata configures the host, container, pod and cloud tags.
+	HostMetadata HostMetadataConfig `mapstructure:"host_metadata"`
+	// Histograms configures how histograms are sent to Sematext.
+	Histograms HistogramsConfig `mapstructure:"histograms"`
+	// Routing sends metrics of matching resources to other Monitoring Apps than
+	// the one of AppToken.
+	Routing RoutingConfig `mapstructure:"routing"`
+}
+
+// HostMetadataConfig defines how resource attributes are mapped to the tags of
+// the Sematext infrastructure views.
+type HostMetadataConfig struct {
+	// Enabled sends container, Kubernetes and cloud attributes as Sematext tags
+	// and takes the os.host tag from HostnameSources. When disabled, os.host is
+	// always the hostname of the collector.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// HostnameSources lists, by priority, the attributes whose value is sent as
+	// the os.host tag. The hostname of the collector is only used when none of
+	// them is present.
+	// Default: [host.name, k8s.node.name, host.id]
+	HostnameSources []string `mapstructure:"hostname_sources"`
+}
+
+// HistogramsConfig defines the conversion of histograms. Exponential histograms
+// are always sent as percentiles.
+type HistogramsConfig struct {
+	// Mode is either "buckets", to send the bucket fields of the metrics schema,
+	// or "percentiles", to send percentiles computed from the buckets together
+	// with the count, sum, min and max of the histogram.
+	// Default: buckets
+	Mode string `mapstructure:"mode"`
+	// Percentiles lists the percentiles, greater than 0 and at most 100, that
+	// are computed from the buckets.
+	// Default: [50, 90, 95, 99]
+	Percentiles []float64 `mapstructure:"percentiles"`
+}
+
+// RoutingConfig maps resources to the tokens of Sematext Monitoring Apps.
+// Resources that match no entry of the table are sent with the default AppToken.
+type RoutingConfig struct {
+	// FromAttribute is the resource attribute compared with the value of the
+	// routing table entries.
+	FromAttribute string `mapstructure:"from_attribute"`
+	// Table lists the routes, the first matching entry wins.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+type RoutingTableItem struct {
+	// Value is matched against the FromAttribute resource attribute.
+	Value string `mapstructure:"value"`
+	// Condition is an OTTL condition in the resource context. It is an
+	// alternative to Value.
+	Condition string `mapstructure:"condition"`
+	// AppToken is the token of the Sematext Monitoring App matching resources are sent to.
+	AppToken string `mapstructure:"app_token"`
+}
+
+// TagsConfig defines which metric attributes are kept as tags, in addition to the
+// default ones, and how they are named.
+type TagsConfig struct {
+	TagRules `mapstructure:",squash"`
+	// MetricOverrides holds additional tag rules, keyed by metric name, that only
+	// apply to points of that metric.
+	MetricOverrides map[string]TagRules `mapstructure:"metric_overrides"`
+}
+
+type TagRules struct {
+	// Allowed lists attribute keys that are kept as tags.
+	Allowed []string `mapstructure:"allowed"`
+	// AllowedPatterns lists regular expressions; attributes whose key matches
+	// any of them are kept as tags.
+	AllowedPatterns []string `mapstructure:"allowed_patterns"`
+	// Rename maps attribute keys to the tag names sent to Sematext.
+	// Renamed attributes are kept even if they are not otherwise allowed.
+	Rename map[string]string `mapstructure:"rename"`
+}
+
+type LogsConfig struct {
+	// App token is the token of Sematext Logs App to which you want to send the logs.
+	AppToken string `mapstructure:"app_token"`
+	// LogsEndpoint specifies the endpoint for receiving logs in Sematext
+	LogsEndpoint string `mapstructure:"-"`
+	// Events sends log records matching any of its conditions to the Sematext
+	// Events API. Such records are still indexed as logs if app_token is set.
+	Events EventsConfig `mapstructure:"events"`
+}
+
+// EventsConfig selects the log records that are sent as Sematext events and
+// the attributes their fields are read from.
+type EventsConfig struct {
+	// Conditions are OTTL log conditions; a record matching any of them is
+	// sent as an event.
+	Conditions []string `mapstructure:"conditions"`
+	// App token is the token of the Sematext App the events are attached to.
+	AppToken string `mapstructure:"app_token"`
+	// EventsEndpoint specifies the endpoint for receiving events in Sematext
+	EventsEndpoint string `mapstructure:"-"`
+	// TitleAttribute is the attribute the event title is read from.
+	TitleAttribute string `mapstructure:"title_attribute"`
+	// TypeAttribute is the attribute the event type is read from.
+	TypeAttribute string `mapstructure:"type_attribute"`
+	// PriorityAttribute is the attribute the event priority is read from.
+	PriorityAttribute string `mapstructure:"priority_attribute"`
+	// TagsAttribute is the attribute the event tags are read from, either a
+	// list or a comma separated string.
+	TagsAttribute string `mapstructure:"tags_attribute"`
+}
+
+type TracesConfig struct {
+	// App token is the token of Sematext Tracing App to which you want to send the traces.
+	AppToken string `mapstructure:"app_token"`
+	// TracesEndpoint specifies the OTLP/HTTP endpoint for receiving traces in Sematext
+	TracesEndpoint string `mapstructure:"-"`
+	// TokenHeader is the HTTP header carrying AppToken.
+	TokenHeader string `mapstructure:"token_header"`
+	// Path is the path of the traces requests, relative to TracesEndpoint.
+	Path string `mapstructure:"path"`
 }
 
 // Validate checks for invalid or missing entries in the configuration.
@@ -54,18 +194,122 @@ func (cfg *Config) Validate() error {
 	if !isValidUUID(cfg.MetricsConfig.AppToken) && cfg.MetricsConfig.AppToken != "" {
 		return fmt.Errorf("invalid metrics app_token: %s. app_token is not a valid UUID", cfg.MetricsConfig.AppToken)
 	}
+	if !isValidUUID(cfg.LogsConfig.AppToken) && cfg.LogsConfig.AppToken != "" {
+		return fmt.Errorf("invalid logs app_token: %s. app_token is not a valid UUID", cfg.LogsConfig.AppToken)
+	}
+	if !isValidUUID(cfg.TracesConfig.AppToken) && cfg.TracesConfig.AppToken != "" {
+		return fmt.Errorf("invalid traces app_token: %s. app_token is not a valid UUID", cfg.TracesConfig.AppToken)
+	}
+	if cfg.TracesConfig.AppToken != "" && cfg.TracesConfig.TokenHeader == "" {
+		return errors.New("invalid traces token_header: it must not be empty")
+	}
+
+	if _, ok := common.MetricsSchemata[cfg.MetricsSchema]; !ok && cfg.MetricsSchema != "" {
+		return fmt.Errorf("invalid metrics_schema: %s. please use one of %s", cfg.MetricsSchema, strings.Join(slices.Sorted(maps.Keys(common.MetricsSchemata)), ", "))
+	}
+	for _, source := range cfg.MetricsConfig.HostMetadata.HostnameSources {
+		if source == "" {
+			return errors.New("invalid metrics host_metadata: hostname_sources contains an empty attribute")
+		}
+	}
+	if err := cfg.MetricsConfig.Histograms.validate(); err != nil {
+		return fmt.Errorf("invalid metrics histograms: %w", err)
+	}
+
+	if err := cfg.MetricsConfig.Tags.TagRules.validate(); err != nil {
+		return fmt.Errorf("invalid metrics tags: %w", err)
+	}
+	for name, rules := range cfg.MetricsConfig.Tags.MetricOverrides {
+		if err := rules.validate(); err != nil {
+			return fmt.Errorf("invalid metrics tags override for %q: %w", name, err)
+		}
+	}
+
+	if err := cfg.MetricsConfig.Routing.validate(); err != nil {
+		return fmt.Errorf("invalid metrics routing: %w", err)
+	}
+	if err := cfg.LogsConfig.Events.validate(); err != nil {
+		return fmt.Errorf("invalid logs events: %w", err)
+	}
 
 	if strings.ToLower(cfg.Region) == euRegion {
 		cfg.MetricsEndpoint = euMetricsEndpoint
+		cfg.LogsEndpoint = euLogsEndpoint
+		cfg.TracesEndpoint = euTracesEndpoint
+		cfg.Events.EventsEndpoint = euEventsEndpoint
 	}
 	if strings.ToLower(cfg.Region) == usRegion {
 		cfg.MetricsEndpoint = usMetricsEndpoint
+		cfg.LogsEndpoint = usLogsEndpoint
+		cfg.TracesEndpoint = usTracesEndpoint
+		cfg.Events.EventsEndpoint = usEventsEndpoint
 	}
 
 	return nil
 }
 
+func (r TagRules) validate() error {
+	for _, p := range r.AllowedPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid allowed_patterns entry %q: %w", p, err)
+		}
+	}
+	for from, to := range r.Rename {
+		if to == "" {
+			return fmt.Errorf("rename of %q has an empty tag name", from)
+		}
+		if to == "token" {
+			return fmt.Errorf("rename of %q to the reserved tag %q", from, to)
+		}
+	}
+	return nil
+}
+
+func (h HistogramsConfig) validate() error {
+	if h.Mode != "" && h.Mode != histogramsModeBuckets && h.Mode != histogramsModePercentiles {
+		return fmt.Errorf("mode %q is not one of %q or %q", h.Mode, histogramsModeBuckets, histogramsModePercentiles)
+	}
+	for _, p := range h.Percentiles {
+		if p <= 0 || p > 100 {
+			return fmt.Errorf("percentile %v is not in the range (0, 100]", p)
+		}
+	}
+	return nil
+}
+
+func (r RoutingConfig) validate() error {
+	for i, item := range r.Table {
+		if !isValidUUID(item.AppToken) {
+			return fmt.Errorf("table entry %d: app_token %q is not a valid UUID", i, item.AppToken)
+		}
+		if (item.Value == "") == (item.Condition == "") {
+			return fmt.Errorf("table entry %d: exactly one of value or condition must be set", i)
+		}
+		if item.Value != "" && r.FromAttribute == "" {
+			return fmt.Errorf("table entry %d: value requires from_attribute to be set", i)
+		}
+		if item.Condition != "" {
+			_, err := filterottl.NewBoolExprForResource([]string{item.Condition}, filterottl.StandardResourceFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+			if err != nil {
+				return fmt.Errorf("table entry %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
 func isValidUUID(uuid string) bool {
 	const uuidPattern = `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
 	return regexp.MustCompile(uuidPattern).MatchString(strings.ToLower(uuid))
 }
+
+func (e EventsConfig) validate() error {
+	if len(e.Conditions) == 0 {
+		return nil
+	}
+	if !isValidUUID(e.AppToken) {
+		return fmt.Errorf("app_token %q is not a valid UUID", e.AppToken)
+	}
+	_, err := filterottl.NewBoolExprForLog(e.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+	return err
+}
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -24,6 +26,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
 )
 
+var (
+	logsAppToken   = uuid.NewString()
+	tracesAppToken = uuid.NewString()
+)
+
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
@@ -31,6 +38,10 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	contentStr := strings.ReplaceAll(string(content), "<METRICS_APP_TOKEN>", metricsAppToken)
+	contentStr = strings.ReplaceAll(contentStr, "<LOGS_APP_TOKEN>", logsAppToken)
+	contentStr = strings.ReplaceAll(contentStr, "<TRACES_APP_TOKEN>", tracesAppToken)
+	contentStr = strings.ReplaceAll(contentStr, "<TEAM_A_APP_TOKEN>", teamAAppToken)
+	contentStr = strings.ReplaceAll(contentStr, "<PROD_APP_TOKEN>", prodAppToken)
 
 	tmpConfigPath := filepath.Join("testdata", "config_tmp.yaml")
 	err = os.WriteFile(tmpConfigPath, []byte(contentStr), 0o600)
@@ -38,6 +49,12 @@ func TestLoadConfig(t *testing.T) {
 	defer os.Remove(tmpConfigPath)
 	cm, err := confmaptest.LoadConf(tmpConfigPath)
 	require.NoError(t, err)
+	overrideClientConfig := confighttp.NewDefaultClientConfig()
+	overrideClientConfig.Timeout = 500 * time.Millisecond
+	overrideClientConfig.Headers = map[string]configopaque.String{"User-Agent": "OpenTelemetry -> Sematext"}
+	overrideClientConfig.Compression = configcompression.TypeZstd
+	storageID := component.MustNewIDWithName("file_storage", "sematext")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -49,21 +66,63 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "override-config"),
 			expected: &Config{
-				ClientConfig: confighttp.ClientConfig{
-					Timeout: 500 * time.Millisecond,
-					Headers: map[string]configopaque.String{"User-Agent": "OpenTelemetry -> Sematext"},
-				},
+				ClientConfig: overrideClientConfig,
 				QueueSettings: exporterhelper.QueueConfig{
 					Enabled:      true,
 					NumConsumers: 3,
 					QueueSize:    10,
+					StorageID:    &storageID,
 				},
 				MetricsConfig: MetricsConfig{
 					MetricsEndpoint: usMetricsEndpoint,
 					AppToken:        metricsAppToken,
-					MetricsSchema:   "telegraf-prometheus-v2",
+					MetricsSchema:   "telegraf-prometheus-v1",
 					PayloadMaxLines: 72,
 					PayloadMaxBytes: 27,
+					HostMetadata: HostMetadataConfig{
+						Enabled:         true,
+						HostnameSources: []string{"k8s.node.name", "host.name"},
+					},
+					Histograms: HistogramsConfig{
+						Mode:        histogramsModePercentiles,
+						Percentiles: []float64{50, 99.9},
+					},
+					Tags: TagsConfig{
+						TagRules: TagRules{
+							Allowed:         []string{"cloud.region"},
+							AllowedPatterns: []string{`^k8s\.namespace\.`},
+							Rename:          map[string]string{"k8s.pod.name": "pod"},
+						},
+						MetricOverrides: map[string]TagRules{
+							"http.server.duration": {Allowed: []string{"http.route"}},
+						},
+					},
+					Routing: RoutingConfig{
+						FromAttribute: "team",
+						Table: []RoutingTableItem{
+							{Value: "a", AppToken: teamAAppToken},
+							{Condition: `attributes["deployment.environment"] == "prod"`, AppToken: prodAppToken},
+						},
+					},
+				},
+				LogsConfig: LogsConfig{
+					LogsEndpoint: usLogsEndpoint,
+					AppToken:     logsAppToken,
+					Events: EventsConfig{
+						Conditions:        []string{`attributes["event.type"] != nil`},
+						AppToken:          metricsAppToken,
+						EventsEndpoint:    usEventsEndpoint,
+						TitleAttribute:    "k8s.event.reason",
+						TypeAttribute:     defaultEventTypeAttribute,
+						PriorityAttribute: defaultEventPriorityAttribute,
+						TagsAttribute:     defaultEventTagsAttribute,
+					},
+				},
+				TracesConfig: TracesConfig{
+					TracesEndpoint: usTracesEndpoint,
+					AppToken:       tracesAppToken,
+					TokenHeader:    defaultTracesTokenHeader,
+					Path:           defaultTracesPath,
 				},
 
 				BackOffConfig: configretry.BackOffConfig{
@@ -130,6 +189,253 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Valid configuration with logs",
+			config: &Config{
+				Region: euRegion,
+				LogsConfig: LogsConfig{
+					AppToken: logsAppToken,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid logs AppToken",
+			config: &Config{
+				Region: usRegion,
+				LogsConfig: LogsConfig{
+					AppToken: "short-token",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid metrics tags",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					AppToken: metricsAppToken,
+					Tags: TagsConfig{
+						TagRules: TagRules{
+							Allowed:         []string{"cloud.region"},
+							AllowedPatterns: []string{`^k8s\.`},
+							Rename:          map[string]string{"k8s.pod.name": "pod"},
+						},
+						MetricOverrides: map[string]TagRules{
+							"http.server.duration": {Allowed: []string{"http.route"}},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid metrics tags pattern",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Tags: TagsConfig{
+						TagRules: TagRules{AllowedPatterns: []string{"k8s.("}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid metrics tags override rename",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Tags: TagsConfig{
+						MetricOverrides: map[string]TagRules{
+							"http.server.duration": {Rename: map[string]string{"user.id": "token"}},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid metrics schema",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					MetricsSchema: "otel-v1",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid metrics schema",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					MetricsSchema: "prometheus",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid host metadata hostname source",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					HostMetadata: HostMetadataConfig{Enabled: true, HostnameSources: []string{"host.name", ""}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid histograms mode",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Histograms: HistogramsConfig{Mode: "summary"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid histograms percentile",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Histograms: HistogramsConfig{Mode: histogramsModePercentiles, Percentiles: []float64{50, 120}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid metrics routing",
+			config: &Config{
+				Region:        usRegion,
+				MetricsConfig: newTestRoutingConfig(),
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid metrics routing AppToken",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Routing: RoutingConfig{
+						FromAttribute: "team",
+						Table:         []RoutingTableItem{{Value: "a", AppToken: "short-token"}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Metrics routing with value and condition",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Routing: RoutingConfig{
+						FromAttribute: "team",
+						Table: []RoutingTableItem{{
+							Value:     "a",
+							Condition: `attributes["team"] == "a"`,
+							AppToken:  teamAAppToken,
+						}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Metrics routing value without from_attribute",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Routing: RoutingConfig{
+						Table: []RoutingTableItem{{Value: "a", AppToken: teamAAppToken}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid metrics routing condition",
+			config: &Config{
+				Region: usRegion,
+				MetricsConfig: MetricsConfig{
+					Routing: RoutingConfig{
+						Table: []RoutingTableItem{{Condition: `attributes["team"] ==`, AppToken: teamAAppToken}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid logs events",
+			config: &Config{
+				Region: euRegion,
+				LogsConfig: LogsConfig{
+					Events: EventsConfig{
+						Conditions: []string{`attributes["event.type"] != nil`},
+						AppToken:   metricsAppToken,
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Logs events without AppToken",
+			config: &Config{
+				Region: euRegion,
+				LogsConfig: LogsConfig{
+					Events: EventsConfig{
+						Conditions: []string{`attributes["event.type"] != nil`},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid logs events condition",
+			config: &Config{
+				Region: euRegion,
+				LogsConfig: LogsConfig{
+					Events: EventsConfig{
+						Conditions: []string{`attributes["event.type"] !=`},
+						AppToken:   metricsAppToken,
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid configuration with traces",
+			config: &Config{
+				Region: euRegion,
+				TracesConfig: TracesConfig{
+					AppToken:    tracesAppToken,
+					TokenHeader: defaultTracesTokenHeader,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Empty traces token_header",
+			config: &Config{
+				Region: euRegion,
+				TracesConfig: TracesConfig{
+					AppToken: tracesAppToken,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid traces AppToken",
+			config: &Config{
+				Region: usRegion,
+				TracesConfig: TracesConfig{
+					AppToken: "short-token",
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "Invalid metrics AppToken",
 			config: &Config{
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+const (
+	defaultEventTitleAttribute    = "event.title"
+	defaultEventTypeAttribute     = "event.type"
+	defaultEventPriorityAttribute = "event.priority"
+	defaultEventTagsAttribute     = "event.tags"
+	// defaultEventType is the type of events without a type attribute.
+	defaultEventType = "otel"
+)
+
+// sematextEvent is the document accepted by the Sematext Events API.
+type sematextEvent struct {
+	Timestamp string   `json:"timestamp"`
+	Type      string   `json:"type"`
+	Message   string   `json:"message"`
+	Title     string   `json:"title,omitempty"`
+	Priority  string   `json:"priority,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Creator   string   `json:"creator,omitempty"`
+}
+
+// eventsSender sends the log records matching the configured conditions to
+// the Sematext Events API, one request per event.
+type eventsSender struct {
+	condition *ottl.ConditionSequence[ottllog.TransformContext]
+	writeURL  string
+	config    EventsConfig
+}
+
+// newEventsSender returns nil when cfg has no conditions, in which case no
+// log record is sent as an event.
+func newEventsSender(cfg EventsConfig, telemetrySettings component.TelemetrySettings) (*eventsSender, error) {
+	if len(cfg.Conditions) == 0 {
+		return nil, nil
+	}
+	condition, err := filterottl.NewBoolExprForLog(cfg.Conditions, filterottl.StandardLogFuncs(), ottl.IgnoreError, telemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	writeURL, err := url.JoinPath(cfg.EventsEndpoint, cfg.AppToken, "event")
+	if err != nil {
+		return nil, err
+	}
+	return &eventsSender{
+		condition: condition,
+		writeURL:  writeURL,
+		config:    cfg,
+	}, nil
+}
+
+// collect returns the events of all log records of ld matching any of the
+// conditions.
+func (s *eventsSender) collect(ctx context.Context, ld plog.Logs) []sematextEvent {
+	var events []sematextEvent
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				// Evaluation errors are logged by the condition sequence and
+				// the record is treated as not matching.
+				matched, _ := s.condition.Eval(ctx, ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl))
+				if matched {
+					events = append(events, s.newEvent(rl.Resource(), lr))
+				}
+			}
+		}
+	}
+	return events
+}
+
+// newEvent maps a log record to a Sematext event. The message is the body of
+// the record, while the title, type, priority and tags are read from the
+// configured attributes of the record or, if missing there, of its resource.
+func (s *eventsSender) newEvent(resource pcommon.Resource, lr plog.LogRecord) sematextEvent {
+	ts := lr.Timestamp()
+	if ts == 0 {
+		ts = lr.ObservedTimestamp()
+	}
+	if ts == 0 {
+		ts = pcommon.NewTimestampFromTime(time.Now())
+	}
+	event := sematextEvent{
+		Timestamp: ts.AsTime().UTC().Format(time.RFC3339Nano),
+		Type:      defaultEventType,
+		Message:   lr.Body().AsString(),
+	}
+	lookup := func(key string) (pcommon.Value, bool) {
+		if key == "" {
+			return pcommon.Value{}, false
+		}
+		if v, ok := lr.Attributes().Get(key); ok {
+			return v, true
+		}
+		return resource.Attributes().Get(key)
+	}
+
+	if v, ok := lookup(s.config.TitleAttribute); ok {
+		event.Title = v.AsString()
+	}
+	if v, ok := lookup(s.config.TypeAttribute); ok && v.AsString() != "" {
+		event.Type = v.AsString()
+	}
+	if v, ok := lookup(s.config.PriorityAttribute); ok {
+		event.Priority = v.AsString()
+	}
+	if v, ok := lookup(s.config.TagsAttribute); ok {
+		event.Tags = eventTags(v)
+	}
+	if v, ok := resource.Attributes().Get("service.name"); ok {
+		event.Creator = v.AsString()
+	}
+	return event
+}
+
+// eventTags returns the elements of a slice value, or the comma separated
+// parts of any other value.
+func eventTags(v pcommon.Value) []string {
+	var tags []string
+	if v.Type() == pcommon.ValueTypeSlice {
+		for i := 0; i < v.Slice().Len(); i++ {
+			if tag := v.Slice().At(i).AsString(); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	}
+	for _, tag := range strings.Split(v.AsString(), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// sendEvents posts the events matching the conditions to the Events API. All
+// events are sent even if some of them fail. Retryable failures are returned
+// so that the logs are retried before they are indexed; permanent failures
+// are logged and do not prevent the logs from being indexed. Events accepted
+// or rejected by a previous attempt of the same request are not sent again;
+// they are identified by their content, so that a record matching the
+// conditions of only some attempts doesn't change which events are skipped.
+func (w *sematextLogsWriter) sendEvents(ctx context.Context, ld plog.Logs) error {
+	attempt, tracked := writeAttemptFromContext(ctx)
+	tracked = tracked && w.delivered != nil
+	var retryableErr error
+	for _, event := range w.events.collect(ctx, ld) {
+		key := eventHash(event)
+		if tracked && attempt.skip(key) {
+			w.logger.Debug("skipping event delivered by a previous attempt", "title", event.Title)
+			continue
+		}
+
+		err := w.sendEvent(ctx, event)
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			w.logger.Warn("Sematext rejected an event", "title", event.Title, "error", err)
+		default:
+			retryableErr = err
+			continue
+		}
+		if tracked {
+			attempt.deliver(key)
+		}
+	}
+	return retryableErr
+}
+
+// eventHash identifies an event across the attempts of a request. The
+// timestamp is left out, as it is set when the event is collected for the
+// records without one.
+func eventHash(event sematextEvent) uint64 {
+	event.Timestamp = ""
+	payload, _ := json.Marshal(event)
+	return payloadHash(payload)
+}
+
+func (w *sematextLogsWriter) sendEvent(ctx context.Context, event sematextEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.events.writeURL, bytes.NewReader(payload))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = w.eventsClient.send(ctx, req, "event write")
+	return err
+}
@@ -0,0 +1,348 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadatatest"
+)
+
+var eventsAppToken = uuid.NewString()
+
+func newTestEventsConfig(endpoint string) EventsConfig {
+	return EventsConfig{
+		Conditions:        []string{`attributes["event.type"] != nil`},
+		AppToken:          eventsAppToken,
+		EventsEndpoint:    endpoint,
+		TitleAttribute:    defaultEventTitleAttribute,
+		TypeAttribute:     defaultEventTypeAttribute,
+		PriorityAttribute: defaultEventPriorityAttribute,
+		TagsAttribute:     defaultEventTagsAttribute,
+	}
+}
+
+func newTestEventLogs() plog.Logs {
+	ld := newTestLogs()
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1628605794, 0)))
+	lr.Body().SetStr("checkout v2.3.1 deployed to prod")
+	lr.Attributes().PutStr("event.type", "deployment")
+	lr.Attributes().PutStr("event.title", "Deployment of checkout")
+	lr.Attributes().PutStr("event.priority", "high")
+	lr.Attributes().PutEmptySlice("event.tags").FromRaw([]any{"ci", "", "prod"})
+	return ld
+}
+
+func TestEventsSenderCollect(t *testing.T) {
+	sender, err := newEventsSender(newTestEventsConfig(usEventsEndpoint), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Equal(t, "https://event-receiver.sematext.com/"+eventsAppToken+"/event", sender.writeURL)
+
+	events := sender.collect(context.Background(), newTestEventLogs())
+	assert.Equal(t, []sematextEvent{{
+		Timestamp: "2021-08-10T14:29:54Z",
+		Type:      "deployment",
+		Message:   "checkout v2.3.1 deployed to prod",
+		Title:     "Deployment of checkout",
+		Priority:  "high",
+		Tags:      []string{"ci", "prod"},
+		Creator:   "checkout",
+	}}, events)
+}
+
+func TestEventsSenderResourceAttributes(t *testing.T) {
+	cfg := newTestEventsConfig(usEventsEndpoint)
+	cfg.Conditions = []string{`attributes["k8s.event.reason"] != nil`}
+	cfg.TitleAttribute = "k8s.event.reason"
+	cfg.TagsAttribute = "k8s.namespace.name"
+	sender, err := newEventsSender(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("k8s.namespace.name", "shop, payments")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(1628605794, 0)))
+	lr.Body().SetStr("Back-off restarting failed container")
+	lr.Attributes().PutStr("k8s.event.reason", "BackOff")
+
+	assert.Equal(t, []sematextEvent{{
+		Timestamp: "2021-08-10T14:29:54Z",
+		Type:      defaultEventType,
+		Message:   "Back-off restarting failed container",
+		Title:     "BackOff",
+		Tags:      []string{"shop", "payments"},
+	}}, sender.collect(context.Background(), ld))
+}
+
+func TestNewEventsSenderWithoutConditions(t *testing.T) {
+	sender, err := newEventsSender(EventsConfig{}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Nil(t, sender)
+}
+
+func TestEventHash(t *testing.T) {
+	event := sematextEvent{Timestamp: "2021-08-10T14:29:54Z", Type: "deployment", Message: "checkout deployed", Title: "Deployment"}
+	later := event
+	later.Timestamp = "2021-08-10T14:30:54Z"
+	assert.Equal(t, eventHash(event), eventHash(later), "the timestamp may be set when the event is collected")
+
+	other := event
+	other.Title = "Rollback"
+	assert.NotEqual(t, eventHash(event), eventHash(other))
+}
+
+func TestSematextLogsWriterPushEvents(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		logsToken    string
+		eventsStatus int
+		expectErr    bool
+		expectPaths  []string
+	}{
+		{
+			name:         "events and logs",
+			logsToken:    logsAppToken,
+			eventsStatus: http.StatusCreated,
+			expectPaths:  []string{"/" + eventsAppToken + "/event", "/_bulk"},
+		},
+		{
+			name:         "events only",
+			eventsStatus: http.StatusCreated,
+			expectPaths:  []string{"/" + eventsAppToken + "/event"},
+		},
+		{
+			name:         "rejected events are not retried",
+			logsToken:    logsAppToken,
+			eventsStatus: http.StatusBadRequest,
+			expectPaths:  []string{"/" + eventsAppToken + "/event", "/_bulk"},
+		},
+		{
+			name:         "logs are sent when events fail",
+			logsToken:    logsAppToken,
+			eventsStatus: http.StatusInternalServerError,
+			expectErr:    true,
+			expectPaths:  []string{"/" + eventsAppToken + "/event", "/_bulk"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var paths []string
+			var event map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				mu.Lock()
+				defer mu.Unlock()
+				paths = append(paths, r.URL.Path)
+				if r.URL.Path == "/_bulk" {
+					_, _ = w.Write([]byte(`{"errors":false}`))
+					return
+				}
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.Unmarshal(body, &event))
+				w.WriteHeader(testCase.eventsStatus)
+			}))
+			t.Cleanup(server.Close)
+
+			writer, err := newSematextLogsWriter(
+				newZapSematextLogger(zap.NewNop()),
+				&Config{
+					LogsConfig: LogsConfig{
+						LogsEndpoint: server.URL,
+						AppToken:     testCase.logsToken,
+						Events:       newTestEventsConfig(server.URL),
+					},
+				},
+				componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+			err = writer.pushLogs(context.Background(), newTestEventLogs())
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectPaths, paths)
+			assert.Equal(t, "Deployment of checkout", event["title"])
+		})
+	}
+}
+
+func TestSematextLogsWriterRetryDoesNotResendLogs(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	eventRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/_bulk" {
+			paths = append(paths, "bulk")
+			_, _ = w.Write([]byte(`{"errors":false}`))
+			return
+		}
+		paths = append(paths, "event")
+		eventRequests++
+		if eventRequests < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	writer, err := newSematextLogsWriter(
+		newZapSematextLogger(zap.NewNop()),
+		&Config{
+			BackOffConfig: configretry.NewDefaultBackOffConfig(),
+			LogsConfig: LogsConfig{
+				LogsEndpoint: server.URL,
+				AppToken:     logsAppToken,
+				Events:       newTestEventsConfig(server.URL),
+			},
+		},
+		componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	ld := newTestEventLogs()
+	err = writer.pushLogs(context.Background(), ld)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.NoError(t, writer.pushLogs(context.Background(), ld))
+	assert.Equal(t, []string{"event", "bulk", "event"}, paths,
+		"logs accepted by a previous attempt are not sent again")
+}
+
+func TestSematextLogsWriterEventsTelemetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_bulk" {
+			_, _ = w.Write([]byte(`{"errors":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	writer, err := newSematextLogsWriter(
+		newZapSematextLogger(zap.NewNop()),
+		&Config{
+			LogsConfig: LogsConfig{
+				LogsEndpoint: server.URL,
+				AppToken:     logsAppToken,
+				Events:       newTestEventsConfig(server.URL),
+			},
+		},
+		tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	require.NoError(t, writer.pushLogs(context.Background(), newTestEventLogs()))
+
+	metadatatest.AssertEqualExporterSematextHTTPResponses(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("signal", "events"), attribute.String("status_class", "2xx")),
+		},
+		{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("signal", "logs"), attribute.String("status_class", "2xx")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestSematextLogsWriterRetryDoesNotResendEvents(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	bulkRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/_bulk" {
+			paths = append(paths, "bulk")
+			bulkRequests++
+			if bulkRequests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"errors":false}`))
+			return
+		}
+		var event map[string]any
+		assert.NoError(t, json.Unmarshal(body, &event))
+		paths = append(paths, event["title"].(string))
+		if event["title"] == "Rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	core, logs := observer.New(zap.WarnLevel)
+	writer, err := newSematextLogsWriter(
+		newZapSematextLogger(zap.New(core)),
+		&Config{
+			BackOffConfig: configretry.NewDefaultBackOffConfig(),
+			LogsConfig: LogsConfig{
+				LogsEndpoint: server.URL,
+				AppToken:     logsAppToken,
+				Events:       newTestEventsConfig(server.URL),
+			},
+		},
+		componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	ld := newTestEventLogs()
+	rejected := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+	rejected.Attributes().PutStr("event.type", "deployment")
+	rejected.Attributes().PutStr("event.title", "Rejected")
+
+	for i := 0; i < 2; i++ {
+		err = writer.pushLogs(context.Background(), ld)
+		require.Error(t, err)
+		assert.False(t, consumererror.IsPermanent(err))
+	}
+	require.NoError(t, writer.pushLogs(context.Background(), ld))
+	assert.Equal(t, []string{"Deployment of checkout", "Rejected", "bulk", "bulk", "bulk"}, paths,
+		"events handled by a previous attempt are not sent again")
+
+	require.Equal(t, 1, logs.Len(), "rejected events are logged once")
+	entry := logs.All()[0]
+	assert.Equal(t, "Sematext rejected an event", entry.Message)
+	assert.Equal(t, "Rejected", entry.ContextMap()["title"])
+	assert.Contains(t, entry.ContextMap(), "error")
+}
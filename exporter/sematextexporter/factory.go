@@ -8,47 +8,69 @@ package sematextexporter // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/influxdata/influxdb-observability/common"
-	"github.com/influxdata/influxdb-observability/otel2influx"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
-	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
 )
 
 var metricsAppToken = uuid.NewString()
 
-// NewFactory creates a factory for the Sematext metrics exporter.
+// NewFactory creates a factory for the Sematext exporter.
 func NewFactory() exporter.Factory {
 	return exporter.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
 		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
 		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
 	)
 }
 
 func createDefaultConfig() component.Config {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Timeout = 5 * time.Second
+	clientConfig.Headers = map[string]configopaque.String{
+		"User-Agent": "OpenTelemetry -> Sematext",
+	}
+
 	cfg := &Config{
-		ClientConfig: confighttp.ClientConfig{
-			Timeout: 5 * time.Second,
-			Headers: map[string]configopaque.String{
-				"User-Agent": "OpenTelemetry -> Sematext",
-			},
-		},
+		ClientConfig:  clientConfig,
 		QueueSettings: exporterhelper.NewDefaultQueueConfig(),
 		MetricsConfig: MetricsConfig{
 			MetricsSchema:   common.MetricsSchemaTelegrafPrometheusV2.String(),
 			PayloadMaxLines: 1_000,
 			PayloadMaxBytes: 300_000,
+			HostMetadata: HostMetadataConfig{
+				Enabled:         true,
+				HostnameSources: slices.Clone(defaultHostnameSources),
+			},
+			Histograms: HistogramsConfig{
+				Mode:        histogramsModeBuckets,
+				Percentiles: slices.Clone(defaultPercentiles),
+			},
+		},
+		LogsConfig: LogsConfig{
+			Events: EventsConfig{
+				TitleAttribute:    defaultEventTitleAttribute,
+				TypeAttribute:     defaultEventTypeAttribute,
+				PriorityAttribute: defaultEventPriorityAttribute,
+				TagsAttribute:     defaultEventTagsAttribute,
+			},
+		},
+		TracesConfig: TracesConfig{
+			TokenHeader: defaultTracesTokenHeader,
+			Path:        defaultTracesPath,
 		},
 		BackOffConfig: configretry.NewDefaultBackOffConfig(),
 	}
@@ -70,25 +92,23 @@ func createMetricsExporter(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Sematext HTTP writer: %w", err)
 	}
-	schema, found := common.MetricsSchemata[cfg.MetricsSchema]
-	if !found {
-		return nil, fmt.Errorf("schema '%s' not recognized", cfg.MetricsSchema)
+	schemaName := cfg.MetricsSchema
+	if schemaName == "" {
+		schemaName = common.MetricsSchemaTelegrafPrometheusV2.String()
 	}
-
-	expConfig := otel2influx.DefaultOtelMetricsToLineProtocolConfig()
-	expConfig.Logger = logger
-	expConfig.Writer = writer
-	expConfig.Schema = schema
-	exp, err := otel2influx.NewOtelMetricsToLineProtocol(expConfig)
-	if err != nil {
-		return nil, err
+	schema, found := common.MetricsSchemata[schemaName]
+	if !found {
+		return nil, fmt.Errorf("schema '%s' not recognized", schemaName)
 	}
+	converter := newMetricsConverter(writer, schema, cfg.MetricsConfig.Histograms, writer.telemetryBuilder, logger)
 
 	return exporterhelper.NewMetrics(
 		ctx,
 		set,
 		cfg,
-		exp.WriteMetrics,
+		func(ctx context.Context, md pmetric.Metrics) error {
+			return writer.writeMetrics(ctx, md, converter.WriteMetrics)
+		},
 		exporterhelper.WithQueue(cfg.QueueSettings),
 		exporterhelper.WithRetry(cfg.BackOffConfig),
 		exporterhelper.WithStart(writer.Start),
@@ -103,12 +123,51 @@ func createLogsExporter(
 ) (exporter.Logs, error) {
 	cfg := config.(*Config)
 
+	// Initialize the logger for Sematext
+	logger := newZapSematextLogger(set.Logger)
+
+	// Create a writer for sending logs to Sematext
+	writer, err := newSematextLogsWriter(logger, cfg, set.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Sematext logs writer: %w", err)
+	}
+
 	return exporterhelper.NewLogs(
 		ctx,
 		set,
 		cfg,
-		func(_ context.Context, _ plog.Logs) error {
-			return nil
-		},
+		writer.pushLogs,
+		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+		exporterhelper.WithStart(writer.Start),
+		exporterhelper.WithShutdown(writer.Shutdown),
+	)
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	config component.Config,
+) (exporter.Traces, error) {
+	cfg := config.(*Config)
+
+	// Initialize the logger for Sematext
+	logger := newZapSematextLogger(set.Logger)
+
+	// Create a writer for sending traces to Sematext
+	writer, err := newSematextTracesWriter(logger, cfg, set.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Sematext traces writer: %w", err)
+	}
+
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		cfg,
+		writer.pushTraces,
+		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+		exporterhelper.WithStart(writer.Start),
+		exporterhelper.WithShutdown(writer.Shutdown),
 	)
 }
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestCreateMetricsExporterWithPersistentQueue(t *testing.T) {
+	var mu sync.Mutex
+	var requestBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		requestBodies = append(requestBodies, string(body))
+		mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	storageID := storagetest.NewStorageID("sematext")
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsEndpoint = server.URL
+	cfg.MetricsConfig.AppToken = metricsAppToken
+	cfg.QueueSettings.StorageID = &storageID
+
+	exp, err := NewFactory().CreateMetrics(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("sematext", t.TempDir())
+	require.NoError(t, exp.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(context.Background())) })
+
+	md := pmetric.NewMetrics()
+	dp := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+	dp.SetIntValue(1)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("queue.size")
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requestBodies) == 1 && strings.Contains(requestBodies[0], "queue.size=1i")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCreateMetricsExporterWithMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsEndpoint = usMetricsEndpoint
+	cfg.QueueSettings.StorageID = &storageID
+
+	exp, err := NewFactory().CreateMetrics(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	assert.Error(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestCreateMetricsExporterWithEmptyMetricsSchema(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsEndpoint = usMetricsEndpoint
+	cfg.MetricsSchema = ""
+	require.NoError(t, cfg.Validate())
+
+	exp, err := NewFactory().CreateMetrics(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err, "an empty metrics_schema falls back to the default")
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
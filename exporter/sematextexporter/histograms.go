@@ -0,0 +1,378 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/influxdata/influxdb-observability/otel2influx"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+)
+
+const (
+	// histogramsModeBuckets sends histograms with the bucket fields of the metrics schema.
+	histogramsModeBuckets = "buckets"
+	// histogramsModePercentiles sends histograms as percentiles computed from their buckets.
+	histogramsModePercentiles = "percentiles"
+
+	percentileFieldPrefix = "p"
+)
+
+var defaultPercentiles = []float64{50, 90, 95, 99}
+
+// metricsConverter converts metrics to line protocol with the configured
+// metrics schema. Histograms in the percentiles mode and exponential histograms,
+// which the schemas do not support, are encoded by the exporter itself. All
+// points of a call are sent in the same batch.
+type metricsConverter struct {
+	writer           otel2influx.InfluxWriter
+	schema           common.MetricsSchema
+	percentiles      []float64
+	native           bool
+	telemetryBuilder *metadata.TelemetryBuilder
+	logger           common.Logger
+}
+
+func newMetricsConverter(writer otel2influx.InfluxWriter, schema common.MetricsSchema, cfg HistogramsConfig, telemetryBuilder *metadata.TelemetryBuilder, logger common.Logger) *metricsConverter {
+	percentiles := cfg.Percentiles
+	if len(percentiles) == 0 {
+		percentiles = defaultPercentiles
+	}
+	return &metricsConverter{
+		writer:           writer,
+		schema:           schema,
+		percentiles:      percentiles,
+		native:           cfg.Mode == histogramsModePercentiles,
+		telemetryBuilder: telemetryBuilder,
+		logger:           logger,
+	}
+}
+
+// WriteMetrics converts md and sends it.
+func (c *metricsConverter) WriteMetrics(ctx context.Context, md pmetric.Metrics) error {
+	batch := c.writer.NewBatch()
+
+	exp, err := otel2influx.NewOtelMetricsToLineProtocol(&otel2influx.OtelMetricsToLineProtocolConfig{
+		Logger: c.logger,
+		Writer: sharedBatchWriter{batch},
+		Schema: c.schema,
+	})
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	if !c.hasNativeHistograms(md) {
+		if err = exp.WriteMetrics(ctx, md); err != nil {
+			return err
+		}
+		return batch.WriteBatch(ctx)
+	}
+
+	rest := pmetric.NewMetrics()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		restRM := rest.ResourceMetrics().AppendEmpty()
+		rm.Resource().CopyTo(restRM.Resource())
+		restRM.SetSchemaUrl(rm.SchemaUrl())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			restSM := restRM.ScopeMetrics().AppendEmpty()
+			sm.Scope().CopyTo(restSM.Scope())
+			restSM.SetSchemaUrl(sm.SchemaUrl())
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				if !c.isNativeHistogram(m) {
+					m.CopyTo(restSM.Metrics().AppendEmpty())
+					continue
+				}
+				if err = c.enqueueHistogram(ctx, rm.Resource(), sm.Scope(), m, batch); err != nil {
+					return fmt.Errorf("failed to convert OTLP histogram to line protocol: %w", err)
+				}
+			}
+		}
+	}
+	if err = exp.WriteMetrics(ctx, rest); err != nil {
+		return err
+	}
+	return batch.WriteBatch(ctx)
+}
+
+func (c *metricsConverter) isNativeHistogram(m pmetric.Metric) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeExponentialHistogram:
+		return true
+	case pmetric.MetricTypeHistogram:
+		return c.native
+	default:
+		return false
+	}
+}
+
+func (c *metricsConverter) hasNativeHistograms(md pmetric.Metrics) bool {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if c.isNativeHistogram(ms.At(k)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// enqueueHistogram emits one point per data point of m, with the count, sum,
+// min, max and percentile fields. With the telegraf-prometheus-v2 schema the
+// fields are prefixed with the metric name like the other metrics of that
+// schema, otherwise the metric name is the measurement.
+func (c *metricsConverter) enqueueHistogram(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, batch otel2influx.InfluxWriterBatch) error {
+	tags := otel2influx.ResourceToTags(resource, make(map[string]string))
+	tags = otel2influx.InstrumentationScopeToTags(scope, tags)
+
+	measurement, fieldPrefix := m.Name(), ""
+	if c.schema == common.MetricsSchemaTelegrafPrometheusV2 {
+		measurement, fieldPrefix = common.MeasurementPrometheus, m.Name()+"_"
+	}
+
+	var points []histogramPoint
+	switch m.Type() {
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			points = append(points, newExplicitHistogramPoint(dps.At(i)))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			points = append(points, newExponentialHistogramPoint(dps.At(i)))
+		}
+	}
+
+	for _, point := range points {
+		if point.timestamp == 0 {
+			// Drop only this point, like the points that cannot be encoded.
+			c.telemetryBuilder.ExporterSematextPointsDropped.Add(ctx, 1)
+			c.logger.Debug("dropping histogram data point without timestamp", "metric", m.Name())
+			continue
+		}
+		pointTags := maps.Clone(tags)
+		point.attributes.Range(func(k string, v pcommon.Value) bool {
+			if k != "" {
+				pointTags[k] = v.AsString()
+			}
+			return true
+		})
+
+		fields := make(map[string]any, len(c.percentiles)+5)
+		if point.startTimestamp != 0 {
+			fields[common.AttributeStartTimeUnixNano] = int64(point.startTimestamp)
+		}
+		fields[fieldPrefix+"count"] = float64(point.count)
+		if point.hasSum {
+			fields[fieldPrefix+"sum"] = point.sum
+		}
+		if point.hasMin {
+			fields[fieldPrefix+"min"] = point.min
+		}
+		if point.hasMax {
+			fields[fieldPrefix+"max"] = point.max
+		}
+		for _, p := range c.percentiles {
+			if v, ok := point.percentile(p); ok {
+				fields[fieldPrefix+percentileFieldName(p)] = v
+			}
+		}
+
+		if err := batch.EnqueuePoint(ctx, measurement, pointTags, fields, point.timestamp.AsTime(), common.InfluxMetricValueTypeHistogram); err != nil {
+			return fmt.Errorf("failed to write point for histogram: %w", err)
+		}
+	}
+	return nil
+}
+
+// percentileFieldName returns the field name of a percentile, e.g. p99 or p99.9.
+func percentileFieldName(p float64) string {
+	return percentileFieldPrefix + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// trimPercentileSuffix removes the _p<percentile> suffix of a field name.
+func trimPercentileSuffix(name string) (string, bool) {
+	i := strings.LastIndex(name, "_"+percentileFieldPrefix)
+	if i <= 0 {
+		return "", false
+	}
+	if _, err := strconv.ParseFloat(name[i+2:], 64); err != nil {
+		return "", false
+	}
+	return name[:i], true
+}
+
+// histogramBucket is a bucket of a histogram. Values are assumed to be evenly
+// distributed within a bucket.
+type histogramBucket struct {
+	lower, upper float64
+	count        uint64
+}
+
+// histogramPoint is the common representation of explicit and exponential
+// histogram data points.
+type histogramPoint struct {
+	timestamp      pcommon.Timestamp
+	startTimestamp pcommon.Timestamp
+	attributes     pcommon.Map
+	count          uint64
+	sum, min, max  float64
+	hasSum         bool
+	hasMin         bool
+	hasMax         bool
+	// buckets are ordered by their bounds.
+	buckets []histogramBucket
+}
+
+func newExplicitHistogramPoint(dp pmetric.HistogramDataPoint) histogramPoint {
+	point := histogramPoint{
+		timestamp:      dp.Timestamp(),
+		startTimestamp: dp.StartTimestamp(),
+		attributes:     dp.Attributes(),
+		count:          dp.Count(),
+		sum:            dp.Sum(),
+		min:            dp.Min(),
+		max:            dp.Max(),
+		hasSum:         dp.HasSum(),
+		hasMin:         dp.HasMin(),
+		hasMax:         dp.HasMax(),
+	}
+
+	bounds, counts := dp.ExplicitBounds(), dp.BucketCounts()
+	for i := 0; i < counts.Len(); i++ {
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if i > 0 && i-1 < bounds.Len() {
+			lower = bounds.At(i - 1)
+		}
+		if i < bounds.Len() {
+			upper = bounds.At(i)
+		}
+		point.buckets = append(point.buckets, point.finiteBucket(lower, upper, counts.At(i)))
+	}
+	return point
+}
+
+func newExponentialHistogramPoint(dp pmetric.ExponentialHistogramDataPoint) histogramPoint {
+	point := histogramPoint{
+		timestamp:      dp.Timestamp(),
+		startTimestamp: dp.StartTimestamp(),
+		attributes:     dp.Attributes(),
+		count:          dp.Count(),
+		sum:            dp.Sum(),
+		min:            dp.Min(),
+		max:            dp.Max(),
+		hasSum:         dp.HasSum(),
+		hasMin:         dp.HasMin(),
+		hasMax:         dp.HasMax(),
+	}
+
+	// The bucket with index i covers (base^i, base^(i+1)], mirrored for
+	// negative values.
+	base := math.Pow(2, math.Pow(2, -float64(dp.Scale())))
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		index := float64(negative.Offset()) + float64(i)
+		lower, upper := -math.Pow(base, index+1), -math.Pow(base, index)
+		point.buckets = append(point.buckets, point.finiteBucket(lower, upper, negative.BucketCounts().At(i)))
+	}
+	if dp.ZeroCount() > 0 {
+		threshold := dp.ZeroThreshold()
+		point.buckets = append(point.buckets, histogramBucket{lower: -threshold, upper: threshold, count: dp.ZeroCount()})
+	}
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		index := float64(positive.Offset()) + float64(i)
+		lower, upper := math.Pow(base, index), math.Pow(base, index+1)
+		point.buckets = append(point.buckets, point.finiteBucket(lower, upper, positive.BucketCounts().At(i)))
+	}
+	return point
+}
+
+// finiteBucket narrows the bounds of a bucket to the min and max of the data
+// point. Infinite bounds without a known min or max collapse to the other bound.
+func (p histogramPoint) finiteBucket(lower, upper float64, count uint64) histogramBucket {
+	if p.hasMin && p.min > lower {
+		lower = math.Min(p.min, upper)
+	}
+	if p.hasMax && p.max < upper {
+		upper = math.Max(p.max, lower)
+	}
+	if math.IsInf(lower, -1) {
+		lower = upper
+	}
+	if math.IsInf(upper, 1) {
+		upper = lower
+	}
+	return histogramBucket{lower: lower, upper: upper, count: count}
+}
+
+// percentile estimates the value below which p percent of the observations
+// fall, by linear interpolation within the bucket holding that rank.
+func (p histogramPoint) percentile(percentile float64) (float64, bool) {
+	var total uint64
+	for _, b := range p.buckets {
+		total += b.count
+	}
+	if total == 0 {
+		return 0, false
+	}
+
+	rank := percentile / 100 * float64(total)
+	var cumulative float64
+	for _, b := range p.buckets {
+		if b.count == 0 {
+			continue
+		}
+		next := cumulative + float64(b.count)
+		if next >= rank {
+			if math.IsInf(b.lower, 0) || math.IsInf(b.upper, 0) {
+				return 0, false
+			}
+			return b.lower + (b.upper-b.lower)*(rank-cumulative)/float64(b.count), true
+		}
+		cumulative = next
+	}
+	last := p.buckets[len(p.buckets)-1]
+	return last.upper, !math.IsInf(last.upper, 0)
+}
+
+// sharedBatchWriter hands out the same batch to otel2influx and leaves sending
+// it to the metricsConverter.
+type sharedBatchWriter struct {
+	batch otel2influx.InfluxWriterBatch
+}
+
+func (w sharedBatchWriter) NewBatch() otel2influx.InfluxWriterBatch {
+	return w
+}
+
+func (w sharedBatchWriter) EnqueuePoint(ctx context.Context, measurement string, tags map[string]string, fields map[string]any, ts time.Time, vType common.InfluxMetricValueType) error {
+	return w.batch.EnqueuePoint(ctx, measurement, tags, fields, ts, vType)
+}
+
+func (sharedBatchWriter) WriteBatch(context.Context) error {
+	return nil
+}
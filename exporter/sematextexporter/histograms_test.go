@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/influxdata/influxdb-observability/otel2influx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadatatest"
+)
+
+type recordedPoint struct {
+	measurement string
+	tags        map[string]string
+	fields      map[string]any
+}
+
+// recordingWriter records the points of all batches it hands out.
+type recordingWriter struct {
+	points  []recordedPoint
+	batches int
+	writes  int
+}
+
+func (w *recordingWriter) NewBatch() otel2influx.InfluxWriterBatch {
+	w.batches++
+	return w
+}
+
+func (w *recordingWriter) EnqueuePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]any, _ time.Time, _ common.InfluxMetricValueType) error {
+	w.points = append(w.points, recordedPoint{measurement: measurement, tags: tags, fields: fields})
+	return nil
+}
+
+func (w *recordingWriter) WriteBatch(context.Context) error {
+	w.writes++
+	return nil
+}
+
+var testTimestamp = pcommon.NewTimestampFromTime(time.Unix(1700000000, 0))
+
+func newTestHistogramMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("queue.size")
+	gdp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	gdp.SetTimestamp(testTimestamp)
+	gdp.SetIntValue(3)
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("http.duration")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(testTimestamp)
+	hdp.Attributes().PutStr("http.request.method", "GET")
+	hdp.ExplicitBounds().FromRaw([]float64{10, 20})
+	hdp.BucketCounts().FromRaw([]uint64{10, 10, 0})
+	hdp.SetCount(20)
+	hdp.SetSum(250)
+	hdp.SetMin(2)
+	hdp.SetMax(20)
+
+	exponential := ms.AppendEmpty()
+	exponential.SetName("rpc.duration")
+	edp := exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(testTimestamp)
+	edp.SetScale(0)
+	edp.Positive().BucketCounts().FromRaw([]uint64{4, 4})
+	edp.SetCount(8)
+	edp.SetSum(20)
+	return md
+}
+
+func TestMetricsConverterPercentiles(t *testing.T) {
+	writer := &recordingWriter{}
+	converter := newMetricsConverter(writer, common.MetricsSchemaTelegrafPrometheusV2, HistogramsConfig{
+		Mode:        histogramsModePercentiles,
+		Percentiles: []float64{50, 75},
+	}, newTestTelemetryBuilder(t), common.NoopLogger{})
+
+	require.NoError(t, converter.WriteMetrics(context.Background(), newTestHistogramMetrics()))
+	assert.Equal(t, 1, writer.batches, "all points are sent in one batch")
+	assert.Equal(t, 1, writer.writes)
+
+	require.Len(t, writer.points, 3)
+	assert.Equal(t, recordedPoint{
+		measurement: common.MeasurementPrometheus,
+		tags:        map[string]string{"service.name": "checkout", "http.request.method": "GET"},
+		fields: map[string]any{
+			"http.duration_count": float64(20),
+			"http.duration_sum":   float64(250),
+			"http.duration_min":   float64(2),
+			"http.duration_max":   float64(20),
+			"http.duration_p50":   float64(10),
+			"http.duration_p75":   float64(15),
+		},
+	}, writer.points[0])
+	assert.Equal(t, recordedPoint{
+		measurement: common.MeasurementPrometheus,
+		tags:        map[string]string{"service.name": "checkout"},
+		fields: map[string]any{
+			"rpc.duration_count": float64(8),
+			"rpc.duration_sum":   float64(20),
+			"rpc.duration_p50":   float64(2),
+			"rpc.duration_p75":   float64(3),
+		},
+	}, writer.points[1])
+	assert.Equal(t, map[string]any{"queue.size": int64(3)}, writer.points[2].fields)
+}
+
+func TestMetricsConverterBuckets(t *testing.T) {
+	writer := &recordingWriter{}
+	converter := newMetricsConverter(writer, common.MetricsSchemaTelegrafPrometheusV1, HistogramsConfig{Mode: histogramsModeBuckets}, newTestTelemetryBuilder(t), common.NoopLogger{})
+
+	require.NoError(t, converter.WriteMetrics(context.Background(), newTestHistogramMetrics()))
+	assert.Equal(t, 1, writer.writes)
+
+	var measurements []string
+	for _, point := range writer.points {
+		measurements = append(measurements, point.measurement)
+	}
+	assert.Equal(t, []string{"rpc.duration", "queue.size", "http.duration"}, measurements)
+	exponentialFields := writer.points[0].fields
+	assert.Len(t, exponentialFields, 6, "exponential histograms are always sent as percentiles")
+	assert.Equal(t, float64(8), exponentialFields["count"])
+	assert.Equal(t, float64(2), exponentialFields["p50"])
+	assert.InDelta(t, 3.6, exponentialFields["p90"], 1e-9)
+	assert.InDelta(t, 3.96, exponentialFields["p99"], 1e-9)
+	assert.Contains(t, writer.points[2].fields, "10", "histograms keep the bucket fields of the schema")
+}
+
+func TestMetricsConverterHistogramWithoutTimestamp(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	writer := &recordingWriter{}
+	converter := newMetricsConverter(writer, common.MetricsSchemaTelegrafPrometheusV2, HistogramsConfig{Mode: histogramsModePercentiles}, telemetryBuilder, common.NoopLogger{})
+
+	md := newTestHistogramMetrics()
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints()
+	dps.At(0).CopyTo(dps.AppendEmpty())
+	dps.At(0).SetTimestamp(0)
+
+	require.NoError(t, converter.WriteMetrics(context.Background(), md), "invalid points don't fail the request")
+	require.Len(t, writer.points, 3)
+	assert.Contains(t, writer.points[0].fields, "http.duration_count", "the valid point of the histogram is sent")
+	metadatatest.AssertEqualExporterSematextPointsDropped(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestHistogramPointPercentile(t *testing.T) {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.ExplicitBounds().FromRaw([]float64{10, 20})
+	dp.BucketCounts().FromRaw([]uint64{10, 10, 0})
+	point := newExplicitHistogramPoint(dp)
+
+	for percentile, expected := range map[float64]float64{25: 10, 50: 10, 75: 15, 100: 20} {
+		v, ok := point.percentile(percentile)
+		require.True(t, ok)
+		assert.InDelta(t, expected, v, 1e-9, "p%v", percentile)
+	}
+
+	dp.SetMin(2)
+	v, ok := newExplicitHistogramPoint(dp).percentile(25)
+	require.True(t, ok)
+	assert.InDelta(t, 6, v, 1e-9, "the min narrows the first bucket")
+
+	_, ok = newExplicitHistogramPoint(pmetric.NewHistogramDataPoint()).percentile(50)
+	assert.False(t, ok, "empty histograms have no percentiles")
+
+	unbounded := pmetric.NewHistogramDataPoint()
+	unbounded.BucketCounts().FromRaw([]uint64{5})
+	_, ok = newExplicitHistogramPoint(unbounded).percentile(50)
+	assert.False(t, ok, "a single bucket without min and max has no finite bounds")
+}
+
+func TestExponentialHistogramPointPercentile(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.Negative().BucketCounts().FromRaw([]uint64{2})
+	dp.Positive().BucketCounts().FromRaw([]uint64{4, 4})
+	point := newExponentialHistogramPoint(dp)
+
+	for percentile, expected := range map[float64]float64{10: -1.5, 60: 2, 80: 3, 100: 4} {
+		v, ok := point.percentile(percentile)
+		require.True(t, ok)
+		assert.InDelta(t, expected, v, 1e-9, "p%v", percentile)
+	}
+
+	dp.SetZeroCount(10)
+	dp.SetZeroThreshold(0)
+	v, ok := newExponentialHistogramPoint(dp).percentile(50)
+	require.True(t, ok)
+	assert.InDelta(t, 0, v, 1e-9)
+}
+
+func TestTrimPercentileSuffix(t *testing.T) {
+	name, ok := trimPercentileSuffix("http.duration_p99.9")
+	assert.True(t, ok)
+	assert.Equal(t, "http.duration", name)
+
+	_, ok = trimPercentileSuffix("http.duration_path")
+	assert.False(t, ok)
+	_, ok = trimPercentileSuffix("p50")
+	assert.False(t, ok)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"strings"
+)
+
+const (
+	hostTag        = "os.host"
+	cloudTagPrefix = "cloud."
+)
+
+// defaultHostnameSources are the attributes the os.host tag is taken from,
+// by priority. Pods and containers are reported with the host they run on.
+var defaultHostnameSources = []string{
+	"host.name",
+	"k8s.node.name",
+	"host.id",
+}
+
+// infraTags maps resource attributes to the tag names of the container and
+// Kubernetes views of Sematext. Attributes starting with cloudTagPrefix are
+// sent under their own name.
+var infraTags = map[string]string{
+	"container.id":         "container.id",
+	"container.name":       "container.name",
+	"container.image.name": "container.image.name",
+	"k8s.pod.name":         "kubernetes.pod.name",
+	"k8s.namespace.name":   "kubernetes.namespace",
+	"k8s.node.name":        "kubernetes.node.name",
+}
+
+// hostMetadata maps resource attributes to the Sematext host, container, pod
+// and cloud tags. A nil hostMetadata sends the collector hostname as os.host
+// and no infrastructure tags.
+type hostMetadata struct {
+	sources []string
+}
+
+func newHostMetadata(cfg HostMetadataConfig) *hostMetadata {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &hostMetadata{sources: cfg.HostnameSources}
+}
+
+// hostname returns the value of the first hostname source present in attrs,
+// or fallback if there is none.
+func (h *hostMetadata) hostname(attrs map[string]string, fallback string) string {
+	if h == nil {
+		return fallback
+	}
+	for _, source := range h.sources {
+		if v := attrs[source]; v != "" {
+			return v
+		}
+	}
+	return fallback
+}
+
+// tagName returns the Sematext tag name of an infrastructure attribute, and
+// false if key is not one.
+func (h *hostMetadata) tagName(key string) (string, bool) {
+	if h == nil {
+		return "", false
+	}
+	if name, ok := infraTags[key]; ok {
+		return name, true
+	}
+	if strings.HasPrefix(key, cloudTagPrefix) {
+		return key, true
+	}
+	return "", false
+}
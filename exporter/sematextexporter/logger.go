@@ -8,18 +8,38 @@ import (
 	"go.uber.org/zap"
 )
 
+// sematextLogger is the common.Logger of the exporter, which also logs the
+// data it drops at warn level.
+type sematextLogger interface {
+	common.Logger
+	Warn(msg string, kv ...any)
+}
+
 type zapSematextLogger struct {
 	*zap.SugaredLogger
 }
 
-func newZapSematextLogger(logger *zap.Logger) common.Logger {
-	return &common.ErrorLogger{
-		Logger: &zapSematextLogger{
-			logger.Sugar(),
+type errorSematextLogger struct {
+	*common.ErrorLogger
+	zap *zapSematextLogger
+}
+
+func newZapSematextLogger(logger *zap.Logger) sematextLogger {
+	l := &zapSematextLogger{
+		logger.Sugar(),
+	}
+	return &errorSematextLogger{
+		ErrorLogger: &common.ErrorLogger{
+			Logger: l,
 		},
+		zap: l,
 	}
 }
 
 func (l zapSematextLogger) Debug(msg string, kv ...any) {
 	l.SugaredLogger.Debugw(msg, kv...)
 }
+
+func (l *errorSematextLogger) Warn(msg string, kv ...any) {
+	l.zap.SugaredLogger.Warnw(msg, kv...)
+}
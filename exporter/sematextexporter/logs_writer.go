@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// sematextLogsWriter converts logs to Sematext Logs documents and sends them
+// to the Elasticsearch-compatible bulk API of the Sematext logs receiver.
+type sematextLogsWriter struct {
+	sematextHTTPClient
+	// eventsClient sends the requests of the Events API, whose responses are
+	// counted under their own signal. It is nil when no event is sent.
+	eventsClient *sematextHTTPClient
+
+	writeURL  string
+	hostname  string
+	token     string
+	events    *eventsSender
+	delivered *payloadCache
+}
+
+func newSematextLogsWriter(logger sematextLogger, config *Config, telemetrySettings component.TelemetrySettings) (*sematextLogsWriter, error) {
+	writeURL, err := composeLogsWriteURL(config)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("could not detect hostname: %w", err)
+	}
+
+	events, err := newEventsSender(config.LogsConfig.Events, telemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newSematextHTTPClient(logger, config, telemetrySettings, "logs")
+	if err != nil {
+		return nil, err
+	}
+	var eventsClient *sematextHTTPClient
+	if events != nil {
+		c, err := newSematextHTTPClient(logger, config, telemetrySettings, "events")
+		if err != nil {
+			return nil, err
+		}
+		eventsClient = &c
+	}
+	var delivered *payloadCache
+	if config.BackOffConfig.Enabled {
+		delivered = newPayloadCache(config.BackOffConfig.MaxElapsedTime)
+	}
+
+	return &sematextLogsWriter{
+		sematextHTTPClient: client,
+		eventsClient:       eventsClient,
+		writeURL:           writeURL,
+		hostname:           hostname,
+		token:              config.LogsConfig.AppToken,
+		events:             events,
+		delivered:          delivered,
+	}, nil
+}
+
+// Start implements component.StartFunc
+func (w *sematextLogsWriter) Start(ctx context.Context, host component.Host) error {
+	if err := w.sematextHTTPClient.Start(ctx, host); err != nil {
+		return err
+	}
+	if w.eventsClient != nil {
+		return w.eventsClient.Start(ctx, host)
+	}
+	return nil
+}
+
+func (w *sematextLogsWriter) Shutdown(ctx context.Context) error {
+	if w.eventsClient != nil {
+		if err := w.eventsClient.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return w.sematextHTTPClient.Shutdown(ctx)
+}
+
+func composeLogsWriteURL(config *Config) (string, error) {
+	writeURL, err := url.Parse(config.LogsEndpoint)
+	if err != nil {
+		return "", err
+	}
+	if writeURL.Path == "" || writeURL.Path == "/" {
+		writeURL, err = writeURL.Parse("_bulk")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return writeURL.String(), nil
+}
+
+// pushLogs sends the log records of ld matching the events conditions to the
+// Events API, then all log records to Sematext in a single bulk request. The
+// bulk request is skipped when no Logs App token is configured. When the
+// request fails with a retryable error, the events and the bulk request
+// already accepted are skipped by the retries of the same request.
+func (w *sematextLogsWriter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	if ld.LogRecordCount() == 0 {
+		return nil
+	}
+	attempt := newWriteAttempt(w.delivered.get(ld))
+	err := w.writeLogs(contextWithWriteAttempt(ctx, attempt), ld)
+	if err != nil && !consumererror.IsPermanent(err) {
+		w.delivered.set(ld, attempt.delivered)
+	} else {
+		w.delivered.remove(ld)
+	}
+	return err
+}
+
+// writeLogs sends the bulk request even if some events failed, so that the
+// log records are not held back by the Events API.
+func (w *sematextLogsWriter) writeLogs(ctx context.Context, ld plog.Logs) error {
+	var eventsErr error
+	if w.events != nil {
+		eventsErr = w.sendEvents(ctx, ld)
+	}
+	if w.token == "" {
+		return eventsErr
+	}
+	err := w.sendLogs(ctx, ld)
+	if eventsErr != nil && consumererror.IsPermanent(err) {
+		// Only the retryable error of the events is returned, so the rejected
+		// log records are not retried with them.
+		w.logger.Warn("Sematext rejected the logs", "error", err)
+		err = nil
+	}
+	return errors.Join(eventsErr, err)
+}
+
+// sendLogs sends all log records of ld in a single bulk request, unless a
+// previous attempt of the same request already did.
+func (w *sematextLogsWriter) sendLogs(ctx context.Context, ld plog.Logs) error {
+	payload, err := w.encodeLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	attempt, tracked := writeAttemptFromContext(ctx)
+	tracked = tracked && w.delivered != nil
+	hash := payloadHash(payload)
+	if tracked && attempt.skip(hash) {
+		w.logger.Debug("skipping logs delivered by a previous attempt")
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.writeURL, bytes.NewReader(payload))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	body, err := w.send(ctx, req, "logs bulk write")
+	if err == nil {
+		err = checkBulkResponse(body)
+	}
+	if tracked && (err == nil || consumererror.IsPermanent(err)) {
+		attempt.deliver(hash)
+	}
+	return err
+}
+
+// encodeLogs renders ld as an Elasticsearch bulk request body: an index action
+// line followed by the document for every log record.
+func (w *sematextLogsWriter) encodeLogs(ld plog.Logs) ([]byte, error) {
+	action, err := json.Marshal(map[string]any{
+		"index": map[string]string{"_index": w.token},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				doc, err := json.Marshal(w.newLogDocument(rl.Resource(), sl.Scope(), lrs.At(k)))
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode log record: %w", err)
+				}
+				buf.Write(action)
+				buf.WriteByte('\n')
+				buf.Write(doc)
+				buf.WriteByte('\n')
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// newLogDocument flattens a log record together with its resource and scope
+// into a single Sematext Logs document. Log record attributes take precedence
+// over resource attributes with the same key.
+func (w *sematextLogsWriter) newLogDocument(resource pcommon.Resource, scope pcommon.InstrumentationScope, lr plog.LogRecord) map[string]any {
+	doc := make(map[string]any, resource.Attributes().Len()+lr.Attributes().Len()+6)
+	resource.Attributes().Range(func(k string, v pcommon.Value) bool {
+		doc[k] = v.AsRaw()
+		return true
+	})
+	lr.Attributes().Range(func(k string, v pcommon.Value) bool {
+		doc[k] = v.AsRaw()
+		return true
+	})
+
+	ts := lr.Timestamp()
+	if ts == 0 {
+		ts = lr.ObservedTimestamp()
+	}
+	if ts == 0 {
+		ts = pcommon.NewTimestampFromTime(time.Now())
+	}
+	doc["@timestamp"] = ts.AsTime().UTC().Format(time.RFC3339Nano)
+	doc["message"] = lr.Body().AsString()
+
+	if severity := lr.SeverityText(); severity != "" {
+		doc["severity"] = strings.ToLower(severity)
+	} else if lr.SeverityNumber() != plog.SeverityNumberUnspecified {
+		doc["severity"] = strings.ToLower(lr.SeverityNumber().String())
+	}
+	if _, ok := doc["host"]; !ok {
+		if hostName, ok := resource.Attributes().Get("host.name"); ok && hostName.Str() != "" {
+			doc["host"] = hostName.Str()
+		} else {
+			doc["host"] = w.hostname
+		}
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		doc["trace.id"] = traceID.String()
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		doc["span.id"] = spanID.String()
+	}
+	if name := scope.Name(); name != "" {
+		doc["otel.scope.name"] = name
+	}
+	if version := scope.Version(); version != "" {
+		doc["otel.scope.version"] = version
+	}
+	return doc
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// checkBulkResponse reports documents rejected by the bulk API. Such documents
+// were malformed or refused by the receiver and are not retried.
+func checkBulkResponse(body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+	var res bulkResponse
+	if err := json.Unmarshal(body, &res); err != nil || !res.Errors {
+		return nil
+	}
+
+	failed := 0
+	reason := ""
+	for _, item := range res.Items {
+		for _, result := range item {
+			if result.Status < 300 {
+				continue
+			}
+			failed++
+			if reason == "" {
+				reason = fmt.Sprintf("%s: %s", result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return consumererror.NewPermanent(fmt.Errorf("%d of %d log documents were rejected: %s", failed, len(res.Items), reason))
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func newTestLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("host.name", "web-1")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("test-scope")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1628605794, 318000000)))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.Body().SetStr("payment declined")
+	lr.Attributes().PutStr("service.name", "payments")
+	lr.Attributes().PutInt("http.response.status_code", 402)
+	lr.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	return ld
+}
+
+func decodeBulkBody(t *testing.T, body []byte) (actions, docs []map[string]any) {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for i := 0; scanner.Scan(); i++ {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		if i%2 == 0 {
+			actions = append(actions, line)
+		} else {
+			docs = append(docs, line)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return actions, docs
+}
+
+func TestSematextLogsWriterEncodeLogs(t *testing.T) {
+	w := &sematextLogsWriter{
+		token:    "test-token",
+		hostname: "test-host",
+	}
+
+	body, err := w.encodeLogs(newTestLogs())
+	require.NoError(t, err)
+
+	actions, docs := decodeBulkBody(t, body)
+	require.Len(t, actions, 1)
+	require.Len(t, docs, 1)
+	assert.Equal(t, map[string]any{"index": map[string]any{"_index": "test-token"}}, actions[0])
+	assert.Equal(t, map[string]any{
+		"@timestamp":                "2021-08-10T14:29:54.318Z",
+		"message":                   "payment declined",
+		"severity":                  "warn",
+		"host":                      "web-1",
+		"host.name":                 "web-1",
+		"service.name":              "payments",
+		"http.response.status_code": float64(402),
+		"trace.id":                  "0102030405060708090a0b0c0d0e0f10",
+		"otel.scope.name":           "test-scope",
+	}, docs[0])
+}
+
+func TestSematextLogsWriterFallsBackToCollectorHostname(t *testing.T) {
+	w := &sematextLogsWriter{
+		token:    "test-token",
+		hostname: "test-host",
+	}
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+	body, err := w.encodeLogs(ld)
+	require.NoError(t, err)
+
+	_, docs := decodeBulkBody(t, body)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "test-host", docs[0]["host"])
+	assert.Equal(t, "hello", docs[0]["message"])
+	assert.NotEmpty(t, docs[0]["@timestamp"])
+}
+
+func TestSematextLogsWriterPushLogs(t *testing.T) {
+	var recordedRequest *http.Request
+	var recordedRequestBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recordedRequest = r
+		var err error
+		recordedRequestBody, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		_, _ = w.Write([]byte(`{"errors":false,"items":[{"index":{"status":201}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	writer, err := newSematextLogsWriter(
+		newZapSematextLogger(zap.NewNop()),
+		&Config{
+			LogsConfig: LogsConfig{
+				LogsEndpoint: server.URL,
+				AppToken:     logsAppToken,
+			},
+		},
+		componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	require.NoError(t, writer.pushLogs(context.Background(), newTestLogs()))
+
+	require.NotNil(t, recordedRequest)
+	assert.Equal(t, "/_bulk", recordedRequest.URL.Path)
+	assert.Equal(t, "application/x-ndjson", recordedRequest.Header.Get("Content-Type"))
+	actions, docs := decodeBulkBody(t, recordedRequestBody)
+	require.Len(t, docs, 1)
+	assert.Equal(t, map[string]any{"index": map[string]any{"_index": logsAppToken}}, actions[0])
+}
+
+func TestSematextLogsWriterPushLogsErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		name            string
+		status          int
+		body            string
+		expectErr       bool
+		expectPermanent bool
+	}{
+		{
+			name:   "accepted",
+			status: http.StatusOK,
+			body:   `{"errors":false,"items":[{"index":{"status":201}}]}`,
+		},
+		{
+			name:            "rejected documents",
+			status:          http.StatusOK,
+			body:            `{"errors":true,"items":[{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`,
+			expectErr:       true,
+			expectPermanent: true,
+		},
+		{
+			name:      "server error",
+			status:    http.StatusInternalServerError,
+			expectErr: true,
+		},
+		{
+			name:            "bad request",
+			status:          http.StatusBadRequest,
+			expectErr:       true,
+			expectPermanent: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			t.Cleanup(server.Close)
+
+			writer := &sematextLogsWriter{
+				sematextHTTPClient: sematextHTTPClient{
+					httpClient:       server.Client(),
+					telemetryBuilder: newTestTelemetryBuilder(t),
+					logger:           newZapSematextLogger(zap.NewNop()),
+				},
+				writeURL: server.URL,
+				token:    "test-token",
+				hostname: "test-host",
+			}
+
+			err := writer.pushLogs(context.Background(), newTestLogs())
+			if !testCase.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestComposeLogsWriteURL(t *testing.T) {
+	writeURL, err := composeLogsWriteURL(&Config{LogsConfig: LogsConfig{LogsEndpoint: usLogsEndpoint}})
+	require.NoError(t, err)
+	assert.Equal(t, usLogsEndpoint+"/_bulk", writeURL)
+}
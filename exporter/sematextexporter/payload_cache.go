@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// defaultPayloadCacheTTL bounds how long delivered payloads are remembered when
+// retries have no maximum elapsed time.
+const defaultPayloadCacheTTL = 5 * time.Minute
+
+// payloadCache remembers the payloads that were delivered by an export attempt
+// which failed later on. The exporterhelper retries the same request in that
+// case, and the cache lets the writer skip the payloads that Sematext already
+// accepted instead of sending them twice.
+//
+// Payloads are identified by the hash of their content, and identical
+// payloads of a request are counted. The entries are keyed by the request,
+// which is the pdata value given to the push function: retries pass the same
+// value, while any other request, even with identical content, has its own
+// key and is always sent in full.
+type payloadCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[any]payloadCacheEntry
+}
+
+type payloadCacheEntry struct {
+	delivered map[uint64]int
+	expires   time.Time
+}
+
+func newPayloadCache(ttl time.Duration) *payloadCache {
+	if ttl <= 0 {
+		ttl = defaultPayloadCacheTTL
+	}
+	return &payloadCache{
+		ttl:     ttl,
+		entries: make(map[any]payloadCacheEntry),
+	}
+}
+
+func payloadHash(payload []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(payload)
+	return h.Sum64()
+}
+
+// get returns how many times each payload of the request was delivered by its
+// failed attempts.
+func (c *payloadCache) get(request any) map[uint64]int {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[request]
+	if !ok || time.Now().After(entry.expires) {
+		return nil
+	}
+	return entry.delivered
+}
+
+// set remembers the payloads delivered by a failed attempt of the request
+// until its retries are over. The payloads include the ones that the attempt
+// skipped because an earlier attempt delivered them.
+func (c *payloadCache) set(request any, delivered map[uint64]int) {
+	if c == nil {
+		return
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	if len(delivered) == 0 {
+		delete(c.entries, request)
+		return
+	}
+	c.entries[request] = payloadCacheEntry{delivered: delivered, expires: now.Add(c.ttl)}
+}
+
+// remove forgets the request once it succeeded or failed permanently.
+func (c *payloadCache) remove(request any) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, request)
+}
+
+type writeAttemptKey struct{}
+
+// writeAttempt tracks the payloads of a single call of the exporter push
+// function.
+type writeAttempt struct {
+	// previous counts the payloads delivered by the failed attempts of the
+	// same request.
+	previous map[uint64]int
+	// delivered counts the payloads delivered by this attempt, or skipped
+	// because a previous attempt delivered them.
+	delivered map[uint64]int
+}
+
+func newWriteAttempt(previous map[uint64]int) *writeAttempt {
+	return &writeAttempt{
+		previous:  previous,
+		delivered: make(map[uint64]int),
+	}
+}
+
+// skip reports whether the payload was delivered by a previous attempt and
+// accounts it as delivered by this one. A payload that appears several times
+// in the request is skipped as many times as it was delivered.
+func (a *writeAttempt) skip(hash uint64) bool {
+	if a.delivered[hash] >= a.previous[hash] {
+		return false
+	}
+	a.delivered[hash]++
+	return true
+}
+
+func (a *writeAttempt) deliver(hash uint64) {
+	a.delivered[hash]++
+}
+
+func contextWithWriteAttempt(ctx context.Context, attempt *writeAttempt) context.Context {
+	return context.WithValue(ctx, writeAttemptKey{}, attempt)
+}
+
+func writeAttemptFromContext(ctx context.Context) (*writeAttempt, bool) {
+	attempt, ok := ctx.Value(writeAttemptKey{}).(*writeAttempt)
+	return attempt, ok
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+)
+
+const headerRetryAfter = "Retry-After"
+
+// checkResponse turns a non-2xx response of a Sematext receiver into an error,
+// classified like the otlphttp exporter does: 429 and 503 are throttled
+// according to the Retry-After header, other 5xx are retried and every other
+// status is permanent.
+func checkResponse(res *http.Response, body []byte, what string) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	err := fmt.Errorf("%s returned %q %q", what, res.Status, string(body))
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable:
+		// A zero delay makes the retry sender fall back to its own backoff.
+		return exporterhelper.NewThrottleRetry(err, parseRetryAfter(res.Header.Get(headerRetryAfter), time.Now()))
+	case res.StatusCode >= 500:
+		return err
+	default:
+		return consumererror.NewPermanent(err)
+	}
+}
+
+// parseRetryAfter returns the delay requested by a Retry-After header value,
+// which is either a number of seconds or an HTTP date. It returns 0 if the
+// value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// recordResponse counts a response of a Sematext receiver by signal and
+// status class, and records how long the request took.
+func recordResponse(ctx context.Context, telemetryBuilder *metadata.TelemetryBuilder, signal string, statusCode int, elapsed time.Duration) {
+	telemetryBuilder.ExporterSematextHTTPResponses.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("signal", signal),
+		attribute.String("status_class", fmt.Sprintf("%dxx", statusCode/100)),
+	))
+	telemetryBuilder.ExporterSematextHTTPRequestDuration.Record(ctx, elapsed.Milliseconds(), metric.WithAttributes(
+		attribute.String("signal", signal),
+	))
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadatatest"
+)
+
+func TestCheckResponse(t *testing.T) {
+	for _, testCase := range []struct {
+		name            string
+		status          int
+		retryAfter      string
+		expectErr       bool
+		expectPermanent bool
+		expectThrottle  string
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "no content", status: http.StatusNoContent},
+		{name: "accepted", status: http.StatusAccepted},
+		{
+			name:           "too many requests",
+			status:         http.StatusTooManyRequests,
+			retryAfter:     "30",
+			expectErr:      true,
+			expectThrottle: "30s",
+		},
+		{
+			name:           "service unavailable without retry-after",
+			status:         http.StatusServiceUnavailable,
+			expectErr:      true,
+			expectThrottle: "0s",
+		},
+		{name: "internal server error", status: http.StatusInternalServerError, expectErr: true},
+		{name: "bad gateway", status: http.StatusBadGateway, expectErr: true},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, expectErr: true},
+		{name: "bad request", status: http.StatusBadRequest, expectErr: true, expectPermanent: true},
+		{name: "unauthorized", status: http.StatusUnauthorized, expectErr: true, expectPermanent: true},
+		{name: "not found", status: http.StatusNotFound, expectErr: true, expectPermanent: true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			res := &http.Response{
+				StatusCode: testCase.status,
+				Status:     http.StatusText(testCase.status),
+				Header:     http.Header{},
+			}
+			if testCase.retryAfter != "" {
+				res.Header.Set(headerRetryAfter, testCase.retryAfter)
+			}
+
+			err := checkResponse(res, []byte("body"), "test write")
+			if !testCase.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectPermanent, consumererror.IsPermanent(err))
+			if testCase.expectThrottle != "" {
+				assert.Contains(t, err.Error(), "Throttle ("+testCase.expectThrottle+")")
+			} else {
+				assert.NotContains(t, err.Error(), "Throttle")
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 3, 13, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-5", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
+
+func TestRecordResponse(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	recordResponse(context.Background(), telemetryBuilder, "metrics", http.StatusNoContent, 20*time.Millisecond)
+	recordResponse(context.Background(), telemetryBuilder, "metrics", http.StatusNoContent, 20*time.Millisecond)
+	recordResponse(context.Background(), telemetryBuilder, "logs", http.StatusTooManyRequests, 300*time.Millisecond)
+
+	metadatatest.AssertEqualExporterSematextHTTPResponses(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value:      2,
+			Attributes: attribute.NewSet(attribute.String("signal", "metrics"), attribute.String("status_class", "2xx")),
+		},
+		{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("signal", "logs"), attribute.String("status_class", "4xx")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+
+	bounds := []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000}
+	metadatatest.AssertEqualExporterSematextHTTPRequestDuration(t, tel, []metricdata.HistogramDataPoint[int64]{
+		{
+			Attributes:   attribute.NewSet(attribute.String("signal", "metrics")),
+			Count:        2,
+			Sum:          40,
+			Bounds:       bounds,
+			BucketCounts: []uint64{0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			Min:          metricdata.NewExtrema[int64](20),
+			Max:          metricdata.NewExtrema[int64](20),
+		},
+		{
+			Attributes:   attribute.NewSet(attribute.String("signal", "logs")),
+			Count:        1,
+			Sum:          300,
+			Bounds:       bounds,
+			BucketCounts: []uint64{0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+			Min:          metricdata.NewExtrema[int64](300),
+			Max:          metricdata.NewExtrema[int64](300),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestPayloadCache(t *testing.T) {
+	var nilCache *payloadCache
+	nilCache.set(1, map[uint64]int{1: 1})
+	assert.Nil(t, nilCache.get(1))
+	nilCache.remove(1)
+
+	cache := newPayloadCache(time.Minute)
+	cache.set("request", map[uint64]int{1: 1, 2: 2})
+	assert.Equal(t, map[uint64]int{1: 1, 2: 2}, cache.get("request"))
+	assert.Equal(t, map[uint64]int{1: 1, 2: 2}, cache.get("request"), "entries are kept until the request is over")
+	assert.Nil(t, cache.get("other request"))
+	cache.remove("request")
+	assert.Nil(t, cache.get("request"))
+
+	expired := newPayloadCache(time.Nanosecond)
+	expired.set("request", map[uint64]int{1: 1})
+	time.Sleep(time.Millisecond)
+	assert.Nil(t, expired.get("request"))
+}
+
+func TestWriteAttempt(t *testing.T) {
+	attempt := newWriteAttempt(map[uint64]int{1: 1, 2: 2})
+	assert.True(t, attempt.skip(1))
+	assert.False(t, attempt.skip(1), "a payload is skipped as many times as it was delivered")
+	attempt.deliver(1)
+	assert.True(t, attempt.skip(2))
+	assert.True(t, attempt.skip(2))
+	assert.False(t, attempt.skip(3))
+	attempt.deliver(3)
+	assert.Equal(t, map[uint64]int{1: 2, 2: 2, 3: 1}, attempt.delivered, "skipped payloads are accounted as delivered")
+}
+
+func TestWriteAttemptContext(t *testing.T) {
+	_, ok := writeAttemptFromContext(context.Background())
+	assert.False(t, ok)
+
+	attempt := newWriteAttempt(nil)
+	got, ok := writeAttemptFromContext(contextWithWriteAttempt(context.Background(), attempt))
+	require.True(t, ok)
+	assert.Same(t, attempt, got)
+}
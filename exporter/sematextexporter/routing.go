@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+)
+
+// metricsRouter selects the Sematext Monitoring App token of every resource.
+type metricsRouter struct {
+	attribute    string
+	routes       []metricsRoute
+	defaultToken string
+}
+
+type metricsRoute struct {
+	value     string
+	condition *ottl.ConditionSequence[ottlresource.TransformContext]
+	token     string
+}
+
+// newMetricsRouter returns nil when cfg has no routing table, in which case
+// all metrics are sent with the default token.
+func newMetricsRouter(cfg MetricsConfig, telemetrySettings component.TelemetrySettings) (*metricsRouter, error) {
+	if len(cfg.Routing.Table) == 0 {
+		return nil, nil
+	}
+	router := &metricsRouter{
+		attribute:    cfg.Routing.FromAttribute,
+		routes:       make([]metricsRoute, 0, len(cfg.Routing.Table)),
+		defaultToken: cfg.AppToken,
+	}
+	for _, item := range cfg.Routing.Table {
+		route := metricsRoute{value: item.Value, token: item.AppToken}
+		if item.Condition != "" {
+			condition, err := filterottl.NewBoolExprForResource([]string{item.Condition}, filterottl.StandardResourceFuncs(), ottl.IgnoreError, telemetrySettings)
+			if err != nil {
+				return nil, err
+			}
+			route.condition = condition
+		}
+		router.routes = append(router.routes, route)
+	}
+	return router, nil
+}
+
+// tokenFor returns the token of the first route matching the resource of rm,
+// or the default token.
+func (r *metricsRouter) tokenFor(ctx context.Context, rm pmetric.ResourceMetrics) string {
+	var attr pcommon.Value
+	hasAttr := false
+	if r.attribute != "" {
+		attr, hasAttr = rm.Resource().Attributes().Get(r.attribute)
+	}
+	for _, route := range r.routes {
+		if route.condition == nil {
+			if hasAttr && attr.AsString() == route.value {
+				return route.token
+			}
+			continue
+		}
+		// Evaluation errors are logged by the condition sequence and the
+		// route is treated as not matching.
+		matched, _ := route.condition.Eval(ctx, ottlresource.NewTransformContext(rm.Resource(), rm))
+		if matched {
+			return route.token
+		}
+	}
+	return r.defaultToken
+}
+
+// split groups the resources of md by token, keeping the order in which the
+// tokens first appear. md is returned unchanged when all of its resources
+// share a token.
+func (r *metricsRouter) split(ctx context.Context, md pmetric.Metrics) ([]string, map[string]pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	tokens := make([]string, rms.Len())
+	var order []string
+	for i := 0; i < rms.Len(); i++ {
+		tokens[i] = r.tokenFor(ctx, rms.At(i))
+		if !slices.Contains(order, tokens[i]) {
+			order = append(order, tokens[i])
+		}
+	}
+	if len(order) == 1 {
+		return order, map[string]pmetric.Metrics{order[0]: md}
+	}
+
+	groups := make(map[string]pmetric.Metrics, len(order))
+	for _, token := range order {
+		groups[token] = pmetric.NewMetrics()
+	}
+	for i := 0; i < rms.Len(); i++ {
+		rms.At(i).CopyTo(groups[tokens[i]].ResourceMetrics().AppendEmpty())
+	}
+	return order, groups
+}
+
+type appTokenKey struct{}
+
+// contextWithAppToken sets the token that batches created with ctx send
+// their points with.
+func contextWithAppToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, appTokenKey{}, token)
+}
+
+func appTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(appTokenKey{}).(string)
+	return token, ok
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/influxdata/influxdb-observability/otel2influx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadatatest"
+)
+
+var (
+	teamAAppToken = uuid.NewString()
+	teamBAppToken = uuid.NewString()
+	prodAppToken  = uuid.NewString()
+)
+
+func newTestRoutingConfig() MetricsConfig {
+	return MetricsConfig{
+		AppToken: metricsAppToken,
+		Routing: RoutingConfig{
+			FromAttribute: "team",
+			Table: []RoutingTableItem{
+				{Value: "a", AppToken: teamAAppToken},
+				{Value: "b", AppToken: teamBAppToken},
+				{Condition: `attributes["deployment.environment"] == "prod"`, AppToken: prodAppToken},
+			},
+		},
+	}
+}
+
+func appendTestResourceMetrics(md pmetric.Metrics, attrs map[string]string) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	for k, v := range attrs {
+		rm.Resource().Attributes().PutStr(k, v)
+	}
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+}
+
+func TestMetricsRouterTokenFor(t *testing.T) {
+	router, err := newMetricsRouter(newTestRoutingConfig(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		name     string
+		attrs    map[string]string
+		expected string
+	}{
+		{name: "attribute value", attrs: map[string]string{"team": "b"}, expected: teamBAppToken},
+		{name: "condition", attrs: map[string]string{"deployment.environment": "prod"}, expected: prodAppToken},
+		{name: "first match wins", attrs: map[string]string{"team": "a", "deployment.environment": "prod"}, expected: teamAAppToken},
+		{name: "unknown value", attrs: map[string]string{"team": "c"}, expected: metricsAppToken},
+		{name: "no attributes", expected: metricsAppToken},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			md := pmetric.NewMetrics()
+			appendTestResourceMetrics(md, testCase.attrs)
+			assert.Equal(t, testCase.expected, router.tokenFor(context.Background(), md.ResourceMetrics().At(0)))
+		})
+	}
+}
+
+func TestNewMetricsRouterWithoutTable(t *testing.T) {
+	router, err := newMetricsRouter(MetricsConfig{AppToken: metricsAppToken}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Nil(t, router)
+}
+
+func TestMetricsRouterSplit(t *testing.T) {
+	router, err := newMetricsRouter(newTestRoutingConfig(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	appendTestResourceMetrics(md, map[string]string{"team": "b"})
+	appendTestResourceMetrics(md, map[string]string{"team": "c"})
+	appendTestResourceMetrics(md, map[string]string{"team": "b"})
+
+	tokens, groups := router.split(context.Background(), md)
+	assert.Equal(t, []string{teamBAppToken, metricsAppToken}, tokens)
+	assert.Equal(t, 2, groups[teamBAppToken].ResourceMetrics().Len())
+	assert.Equal(t, 1, groups[metricsAppToken].ResourceMetrics().Len())
+
+	single := pmetric.NewMetrics()
+	appendTestResourceMetrics(single, map[string]string{"team": "a"})
+	tokens, groups = router.split(context.Background(), single)
+	assert.Equal(t, []string{teamAAppToken}, tokens)
+	assert.Equal(t, single, groups[teamAAppToken], "metrics of a single app are not copied")
+}
+
+func TestSematextHTTPWriterRouting(t *testing.T) {
+	var mu sync.Mutex
+	var requestBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		requestBodies = append(requestBodies, string(body))
+		mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsEndpoint = server.URL
+	cfg.MetricsConfig.AppToken = metricsAppToken
+	cfg.MetricsConfig.Routing = newTestRoutingConfig().Routing
+	writer, err := newSematextHTTPWriter(newZapSematextLogger(zap.NewNop()), cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	expConfig := otel2influx.DefaultOtelMetricsToLineProtocolConfig()
+	expConfig.Writer = writer
+	expConfig.Schema = common.MetricsSchemaTelegrafPrometheusV2
+	exp, err := otel2influx.NewOtelMetricsToLineProtocol(expConfig)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	appendTestResourceMetrics(md, map[string]string{"team": "a"})
+	appendTestResourceMetrics(md, map[string]string{"deployment.environment": "prod"})
+	appendTestResourceMetrics(md, map[string]string{"team": "a"})
+	appendTestResourceMetrics(md, nil)
+	require.NoError(t, writer.writeMetrics(context.Background(), md, exp.WriteMetrics))
+
+	require.Len(t, requestBodies, 3, "one batch is sent per app")
+	var tokens []string
+	for _, body := range requestBodies {
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		token := lineToken(t, lines[0])
+		for _, line := range lines[1:] {
+			assert.Equal(t, token, lineToken(t, line), "a batch only holds points of one app")
+		}
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	expected := []string{teamAAppToken, prodAppToken, metricsAppToken}
+	sort.Strings(expected)
+	assert.Equal(t, expected, tokens)
+}
+
+func lineToken(t *testing.T, line string) string {
+	_, after, found := strings.Cut(line, "token=")
+	require.True(t, found, line)
+	token, _, _ := strings.Cut(after, " ")
+	token, _, _ = strings.Cut(token, ",")
+	return token
+}
+
+func TestSematextHTTPWriterRoutingRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if strings.Contains(string(body), "token="+teamAAppToken) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	core, logs := observer.New(zap.WarnLevel)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsEndpoint = server.URL
+	cfg.MetricsConfig.AppToken = metricsAppToken
+	cfg.MetricsConfig.Routing = newTestRoutingConfig().Routing
+	writer, err := newSematextHTTPWriter(newZapSematextLogger(zap.New(core)), cfg, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	expConfig := otel2influx.DefaultOtelMetricsToLineProtocolConfig()
+	expConfig.Writer = writer
+	expConfig.Schema = common.MetricsSchemaTelegrafPrometheusV2
+	exp, err := otel2influx.NewOtelMetricsToLineProtocol(expConfig)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	appendTestResourceMetrics(md, map[string]string{"team": "a"})
+	appendTestResourceMetrics(md, map[string]string{"team": "a"})
+	appendTestResourceMetrics(md, nil)
+	err = writer.writeMetrics(context.Background(), md, exp.WriteMetrics)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err), "the metrics of the other apps are retried")
+
+	require.Equal(t, 1, logs.FilterMessage("Sematext rejected the metrics of a Monitoring App").Len())
+	metadatatest.AssertEqualExporterSematextPointsDropped(t, tel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/influxdata/influxdb-observability/common"
+)
+
+// defaultAllowedTags are the attributes that are always sent to Sematext as tags.
+var defaultAllowedTags = []string{
+	"service.name",
+	"service.instance.id",
+	"process.pid",
+	"os.type",
+	"os.host",
+	"http.response.status_code",
+	"network.protocol.version",
+	"jvm.memory.type",
+	"http.request.method",
+	"jvm.gc.name",
+	"token",
+}
+
+// metricNameSuffixes are appended to metric names by otel2influx when a metric
+// is split into several fields or measurements.
+var metricNameSuffixes = []string{
+	common.MetricHistogramCountSuffix,
+	common.MetricHistogramSumSuffix,
+	common.MetricHistogramBucketSuffix,
+	common.MetricHistogramMinSuffix,
+	common.MetricHistogramMaxSuffix,
+	common.MetricExemplarSuffix,
+}
+
+// tagFilter decides which attributes are kept as tags and under which name.
+type tagFilter struct {
+	allowed  map[string]struct{}
+	patterns []*regexp.Regexp
+	rename   map[string]string
+}
+
+// newTagFilter merges the default allowed tags with the given rules. Rules
+// later in the list take precedence when they rename the same attribute.
+func newTagFilter(rules ...TagRules) (*tagFilter, error) {
+	f := &tagFilter{
+		allowed: make(map[string]struct{}, len(defaultAllowedTags)),
+		rename:  make(map[string]string),
+	}
+	for _, k := range defaultAllowedTags {
+		f.allowed[k] = struct{}{}
+	}
+	for _, r := range rules {
+		for _, k := range r.Allowed {
+			f.allowed[k] = struct{}{}
+		}
+		for _, p := range r.AllowedPatterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed tag pattern %q: %w", p, err)
+			}
+			f.patterns = append(f.patterns, re)
+		}
+		maps.Copy(f.rename, r.Rename)
+	}
+	return f, nil
+}
+
+// resolve returns the tag name to use for the attribute key, and false if the
+// attribute must be dropped. Renamed attributes are always kept.
+func (f *tagFilter) resolve(key string) (string, bool) {
+	if name, ok := f.rename[key]; ok {
+		return name, true
+	}
+	if _, ok := f.allowed[key]; ok {
+		return key, true
+	}
+	for _, re := range f.patterns {
+		if re.MatchString(key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// tagMapper selects the tag filter for a point, using the per-metric
+// overrides when the point belongs to one of the configured metrics.
+type tagMapper struct {
+	base      *tagFilter
+	overrides map[string]*tagFilter
+}
+
+func newTagMapper(cfg TagsConfig) (*tagMapper, error) {
+	base, err := newTagFilter(cfg.TagRules)
+	if err != nil {
+		return nil, err
+	}
+	m := &tagMapper{
+		base:      base,
+		overrides: make(map[string]*tagFilter, len(cfg.MetricOverrides)),
+	}
+	for name, rules := range cfg.MetricOverrides {
+		if m.overrides[name], err = newTagFilter(cfg.TagRules, rules); err != nil {
+			return nil, fmt.Errorf("metric %q: %w", name, err)
+		}
+	}
+	return m, nil
+}
+
+// filterFor returns the tag filter for a point. Depending on the metrics schema
+// the metric name is either the measurement or the name of the point's fields.
+func (m *tagMapper) filterFor(measurement string, fields map[string]any) *tagFilter {
+	if len(m.overrides) == 0 {
+		return m.base
+	}
+	if f, ok := m.lookupOverride(measurement); ok {
+		return f
+	}
+	for k := range fields {
+		if f, ok := m.lookupOverride(k); ok {
+			return f
+		}
+	}
+	return m.base
+}
+
+func (m *tagMapper) lookupOverride(name string) (*tagFilter, bool) {
+	if f, ok := m.overrides[name]; ok {
+		return f, true
+	}
+	for _, suffix := range metricNameSuffixes {
+		if trimmed, found := strings.CutSuffix(name, suffix); found {
+			if f, ok := m.overrides[trimmed]; ok {
+				return f, true
+			}
+		}
+	}
+	if trimmed, found := trimPercentileSuffix(name); found {
+		if f, ok := m.overrides[trimmed]; ok {
+			return f, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter"
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+const (
+	// defaultTracesTokenHeader is the default header carrying the Sematext
+	// Tracing App token of an OTLP request.
+	defaultTracesTokenHeader = "X-Sematext-Token"
+	// defaultTracesPath is the default path of OTLP/HTTP traces requests.
+	defaultTracesPath = "v1/traces"
+)
+
+// sematextTracesWriter sends spans unchanged to the OTLP/HTTP endpoint of
+// Sematext Tracing.
+type sematextTracesWriter struct {
+	sematextHTTPClient
+
+	writeURL    string
+	token       string
+	tokenHeader string
+}
+
+func newSematextTracesWriter(logger sematextLogger, config *Config, telemetrySettings component.TelemetrySettings) (*sematextTracesWriter, error) {
+	writeURL, err := composeTracesWriteURL(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newSematextHTTPClient(logger, config, telemetrySettings, "traces")
+	if err != nil {
+		return nil, err
+	}
+
+	return &sematextTracesWriter{
+		sematextHTTPClient: client,
+		writeURL:           writeURL,
+		token:              config.TracesConfig.AppToken,
+		tokenHeader:        config.TracesConfig.TokenHeader,
+	}, nil
+}
+
+func composeTracesWriteURL(config *Config) (string, error) {
+	writeURL, err := url.Parse(config.TracesEndpoint)
+	if err != nil {
+		return "", err
+	}
+	if writeURL.Path == "" || writeURL.Path == "/" {
+		writeURL, err = writeURL.Parse(config.TracesConfig.Path)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return writeURL.String(), nil
+}
+
+// pushTraces sends td to Sematext as an OTLP/HTTP protobuf export request.
+func (w *sematextTracesWriter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	if td.SpanCount() == 0 {
+		return nil
+	}
+	payload, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.writeURL, bytes.NewReader(payload))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set(w.tokenHeader, w.token)
+
+	_, err = w.send(ctx, req, "traces write")
+	return err
+}
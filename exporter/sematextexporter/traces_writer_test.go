@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sematextexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.uber.org/zap"
+)
+
+func newTestTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	return td
+}
+
+func TestSematextTracesWriterPushTraces(t *testing.T) {
+	var recordedRequest *http.Request
+	var recordedRequestBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		recordedRequest = r
+		var err error
+		recordedRequestBody, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	writer, err := newSematextTracesWriter(
+		newZapSematextLogger(zap.NewNop()),
+		&Config{
+			TracesConfig: TracesConfig{
+				TracesEndpoint: server.URL,
+				AppToken:       tracesAppToken,
+				TokenHeader:    defaultTracesTokenHeader,
+				Path:           defaultTracesPath,
+			},
+		},
+		componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+	require.NoError(t, writer.pushTraces(context.Background(), newTestTraces()))
+
+	require.NotNil(t, recordedRequest)
+	assert.Equal(t, "/v1/traces", recordedRequest.URL.Path)
+	assert.Equal(t, "application/x-protobuf", recordedRequest.Header.Get("Content-Type"))
+	assert.Equal(t, tracesAppToken, recordedRequest.Header.Get(defaultTracesTokenHeader))
+
+	req := ptraceotlp.NewExportRequest()
+	require.NoError(t, req.UnmarshalProto(recordedRequestBody))
+	assert.Equal(t, newTestTraces(), req.Traces())
+}
+
+func TestSematextTracesWriterPushTracesErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		name            string
+		status          int
+		expectErr       bool
+		expectPermanent bool
+	}{
+		{
+			name:   "accepted",
+			status: http.StatusOK,
+		},
+		{
+			name:      "server error",
+			status:    http.StatusInternalServerError,
+			expectErr: true,
+		},
+		{
+			name:            "bad request",
+			status:          http.StatusBadRequest,
+			expectErr:       true,
+			expectPermanent: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(testCase.status)
+			}))
+			t.Cleanup(server.Close)
+
+			writer := &sematextTracesWriter{
+				sematextHTTPClient: sematextHTTPClient{
+					httpClient:       server.Client(),
+					telemetryBuilder: newTestTelemetryBuilder(t),
+					logger:           newZapSematextLogger(zap.NewNop()),
+				},
+				writeURL:    server.URL,
+				token:       "test-token",
+				tokenHeader: defaultTracesTokenHeader,
+			}
+
+			err := writer.pushTraces(context.Background(), newTestTraces())
+			if !testCase.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestComposeTracesWriteURL(t *testing.T) {
+	writeURL, err := composeTracesWriteURL(&Config{TracesConfig: TracesConfig{TracesEndpoint: euTracesEndpoint, Path: defaultTracesPath}})
+	require.NoError(t, err)
+	assert.Equal(t, euTracesEndpoint+"/v1/traces", writeURL)
+
+	writeURL, err = composeTracesWriteURL(&Config{TracesConfig: TracesConfig{TracesEndpoint: euTracesEndpoint, Path: "otlp/v1/traces"}})
+	require.NoError(t, err)
+	assert.Equal(t, euTracesEndpoint+"/otlp/v1/traces", writeURL)
+}
@@ -6,11 +6,14 @@ package sematextexporter // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -21,6 +24,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
 )
 
 var _ otel2influx.InfluxWriter = (*sematextHTTPWriter)(nil)
@@ -31,15 +37,20 @@ type sematextHTTPWriter struct {
 
 	httpClientSettings confighttp.ClientConfig
 	telemetrySettings  component.TelemetrySettings
+	telemetryBuilder   *metadata.TelemetryBuilder
+	tags               *tagMapper
+	router             *metricsRouter
+	hostMetadata       *hostMetadata
+	delivered          *payloadCache
 	writeURL           string
 	payloadMaxLines    int
 	payloadMaxBytes    int
 	hostname           string
 	token              string
-	logger             common.Logger
+	logger             sematextLogger
 }
 
-func newSematextHTTPWriter(logger common.Logger, config *Config, telemetrySettings component.TelemetrySettings) (*sematextHTTPWriter, error) {
+func newSematextHTTPWriter(logger sematextLogger, config *Config, telemetrySettings component.TelemetrySettings) (*sematextHTTPWriter, error) {
 	writeURL, err := composeWriteURL(config)
 	if err != nil {
 		return nil, err
@@ -48,6 +59,22 @@ func newSematextHTTPWriter(logger common.Logger, config *Config, telemetrySettin
 	if err != nil {
 		return nil, fmt.Errorf("could not detect hostname: %w", err)
 	}
+	tags, err := newTagMapper(config.MetricsConfig.Tags)
+	if err != nil {
+		return nil, err
+	}
+	router, err := newMetricsRouter(config.MetricsConfig, telemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(telemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	var delivered *payloadCache
+	if config.BackOffConfig.Enabled {
+		delivered = newPayloadCache(config.BackOffConfig.MaxElapsedTime)
+	}
 
 	return &sematextHTTPWriter{
 		encoderPool: sync.Pool{
@@ -58,13 +85,19 @@ func newSematextHTTPWriter(logger common.Logger, config *Config, telemetrySettin
 				return e
 			},
 		},
-		telemetrySettings: telemetrySettings,
-		writeURL:          writeURL,
-		payloadMaxLines:   config.PayloadMaxLines,
-		payloadMaxBytes:   config.PayloadMaxBytes,
-		logger:            logger,
-		hostname:          hostname,
-		token:             config.MetricsConfig.AppToken,
+		httpClientSettings: config.ClientConfig,
+		telemetrySettings:  telemetrySettings,
+		telemetryBuilder:   telemetryBuilder,
+		tags:               tags,
+		router:             router,
+		hostMetadata:       newHostMetadata(config.MetricsConfig.HostMetadata),
+		delivered:          delivered,
+		writeURL:           writeURL,
+		payloadMaxLines:    config.PayloadMaxLines,
+		payloadMaxBytes:    config.PayloadMaxBytes,
+		logger:             logger,
+		hostname:           hostname,
+		token:              config.MetricsConfig.AppToken,
 	}, nil
 }
 
@@ -100,10 +133,65 @@ func (w *sematextHTTPWriter) Shutdown(_ context.Context) error {
 	if w.httpClient != nil {
 		w.httpClient.CloseIdleConnections() // Closes all idle connections for the HTTP client
 	}
+	w.telemetryBuilder.Shutdown()
 	w.logger.Debug("HTTP client connections closed successfully for Sematext HTTP Writer")
 	return nil
 }
 
+// writeMetrics converts md to line protocol with convert and sends it. When the
+// attempt fails after some payloads were delivered, these payloads are skipped
+// by the retries of the same request.
+func (w *sematextHTTPWriter) writeMetrics(ctx context.Context, md pmetric.Metrics, convert func(context.Context, pmetric.Metrics) error) error {
+	attempt := newWriteAttempt(w.delivered.get(md))
+	ctx = contextWithWriteAttempt(ctx, attempt)
+
+	var err error
+	if w.router == nil {
+		err = convert(ctx, md)
+	} else {
+		err = w.writeRouted(ctx, md, convert)
+	}
+	if err != nil && !consumererror.IsPermanent(err) {
+		w.delivered.set(md, attempt.delivered)
+	} else {
+		w.delivered.remove(md)
+	}
+	return err
+}
+
+// writeRouted converts and sends the metrics of every Monitoring App in
+// separate batches. The data of all apps is sent even if some of them fail.
+// Permanent failures are only returned when no app failed with a retryable
+// error, so that the data of the latter is not dropped.
+func (w *sematextHTTPWriter) writeRouted(ctx context.Context, md pmetric.Metrics, convert func(context.Context, pmetric.Metrics) error) error {
+	tokens, groups := w.router.split(ctx, md)
+	var permanentErrs, retryableErrs []error
+	var rejectedPoints int
+	for _, token := range tokens {
+		err := convert(contextWithAppToken(ctx, token), groups[token])
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			permanentErrs = append(permanentErrs, err)
+			rejectedPoints += groups[token].DataPointCount()
+		default:
+			retryableErrs = append(retryableErrs, err)
+		}
+	}
+	if len(retryableErrs) > 0 {
+		// Only the retryable errors are returned, so the rejected points are
+		// not reported as failed by the exporter helper.
+		for _, err := range permanentErrs {
+			w.logger.Warn("Sematext rejected the metrics of a Monitoring App", "error", err)
+		}
+		if rejectedPoints > 0 {
+			w.telemetryBuilder.ExporterSematextPointsDropped.Add(ctx, int64(rejectedPoints))
+		}
+		return errors.Join(retryableErrs...)
+	}
+	return errors.Join(permanentErrs...)
+}
+
 func (w *sematextHTTPWriter) NewBatch() otel2influx.InfluxWriterBatch {
 	return newSematextHTTPWriterBatch(w)
 }
@@ -135,21 +223,26 @@ func (b *sematextHTTPWriterBatch) EnqueuePoint(ctx context.Context, measurement
 	}
 
 	b.encoder.StartLine(measurement)
-	for _, tag := range b.optimizeTags(tags) {
+	for _, tag := range b.optimizeTags(ctx, b.tags.filterFor(measurement, fields), tags) {
 		b.encoder.AddTag(tag.k, tag.v)
 	}
-	for k, v := range b.convertFields(fields) {
-		b.encoder.AddField(k, v)
+	// Fields are added in a stable order, so that the same data always
+	// results in the same payload.
+	lpFields := b.convertFields(fields)
+	for _, k := range slices.Sorted(maps.Keys(lpFields)) {
+		b.encoder.AddField(k, lpFields[k])
 	}
 	b.encoder.EndLine(ts)
 
 	if err := b.encoder.Err(); err != nil {
-		b.encoder.Reset()
-		b.encoder.ClearErr()
-		b.encoderPool.Put(b.encoder)
-		b.encoder = nil
-		return consumererror.NewPermanent(fmt.Errorf("failed to encode point: %w", err))
+		// The encoder removed the partially encoded line; keep the lines
+		// before it and drop only this point.
+		b.encoder.SetBuffer(b.encoder.Bytes())
+		b.telemetryBuilder.ExporterSematextPointsDropped.Add(ctx, 1)
+		b.logger.Warn("dropping point that cannot be encoded", "measurement", measurement, "error", err)
+		return nil
 	}
+	b.telemetryBuilder.ExporterSematextPointsEncoded.Add(ctx, 1)
 
 	b.payloadLines++
 	if b.payloadLines >= b.payloadMaxLines || len(b.encoder.Bytes()) >= b.payloadMaxBytes {
@@ -175,10 +268,31 @@ func (b *sematextHTTPWriterBatch) WriteBatch(ctx context.Context) error {
 		b.payloadLines = 0
 	}()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.writeURL, bytes.NewReader(b.encoder.Bytes()))
+	if b.payloadLines == 0 {
+		// All points of the batch were dropped.
+		return nil
+	}
+
+	payload := b.encoder.Bytes()
+	attempt, tracked := writeAttemptFromContext(ctx)
+	tracked = tracked && b.delivered != nil
+	var hash uint64
+	if tracked {
+		hash = payloadHash(payload)
+		if attempt.skip(hash) {
+			b.logger.Debug("skipping payload delivered by a previous attempt", "lines", b.payloadLines)
+			return nil
+		}
+	}
+
+	b.telemetryBuilder.ExporterSematextBatchLines.Record(ctx, int64(b.payloadLines))
+	b.telemetryBuilder.ExporterSematextBatchSize.Record(ctx, int64(len(payload)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.writeURL, bytes.NewReader(payload))
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
+	start := time.Now()
 	res, err := b.httpClient.Do(req)
 	if err != nil {
 		return err
@@ -192,15 +306,14 @@ func (b *sematextHTTPWriterBatch) WriteBatch(ctx context.Context) error {
 		return err
 	}
 
-	switch res.StatusCode {
-	case http.StatusOK, http.StatusNoContent:
-		break
-	case http.StatusInternalServerError:
-		return fmt.Errorf("line protocol write returned %q %q", res.Status, string(body))
-	default:
-		return consumererror.NewPermanent(fmt.Errorf("line protocol write returned %q %q", res.Status, string(body)))
+	recordResponse(ctx, b.telemetryBuilder, "metrics", res.StatusCode, time.Since(start))
+	if err = checkResponse(res, body, "line protocol write"); err != nil {
+		return err
 	}
 
+	if tracked {
+		attempt.deliver(hash)
+	}
 	return nil
 }
 
@@ -208,31 +321,19 @@ type tag struct {
 	k, v string
 }
 
-// optimizeTags filters for allowed tags and sorts them
-func (b *sematextHTTPWriterBatch) optimizeTags(m map[string]string) []tag {
-	// Define allowed tags set
-	allowedTags := map[string]struct{}{
-		"service.name":              {},
-		"service.instance.id":       {},
-		"process.pid":               {},
-		"os.type":                   {},
-		"os.host":                   {},
-		"http.response.status_code": {},
-		"network.protocol.version":  {},
-		"jvm.memory.type":           {},
-		"http.request.method":       {},
-		"jvm.gc.name":               {},
-		"token":                     {},
-	}
-
+// optimizeTags filters for allowed tags, renames them and sorts them
+func (b *sematextHTTPWriterBatch) optimizeTags(ctx context.Context, filter *tagFilter, m map[string]string) []tag {
 	// Create filtered map with only allowed tags
 	filteredMap := make(map[string]string)
 
 	// Always ensure token and os.host are present
 	filteredMap["token"] = b.token
-	filteredMap["os.host"] = b.hostname
+	if token, ok := appTokenFromContext(ctx); ok {
+		filteredMap["token"] = token
+	}
+	filteredMap[hostTag] = b.hostMetadata.hostname(m, b.hostname)
 
-	// Only include allowed tags
+	dropped := 0
 	for k, v := range m {
 		// Skip empty keys/values
 		if k == "" || v == "" {
@@ -240,12 +341,24 @@ func (b *sematextHTTPWriterBatch) optimizeTags(m map[string]string) []tag {
 			continue
 		}
 
-		// Only include tags from our allowed list
-		if _, isAllowed := allowedTags[k]; isAllowed {
-			filteredMap[k] = v
-		} else {
-			b.logger.Debug("dropping non-allowed tag", "key", k)
+		// Include allowed tags under their configured name, so that the
+		// configured rules take precedence over the infrastructure mapping
+		if name, isAllowed := filter.resolve(k); isAllowed {
+			filteredMap[name] = v
+			continue
 		}
+
+		// Other infrastructure attributes are sent under their Sematext tag name
+		if name, isInfra := b.hostMetadata.tagName(k); isInfra {
+			filteredMap[name] = v
+			continue
+		}
+
+		dropped++
+		b.logger.Debug("dropping non-allowed tag", "key", k)
+	}
+	if dropped > 0 {
+		b.telemetryBuilder.ExporterSematextDroppedTags.Add(ctx, int64(dropped))
 	}
 
 	// Convert to sorted slice
@@ -4,31 +4,63 @@
 package sematextexporter
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/snappy"
 	"github.com/influxdata/influxdb-observability/common"
 	"github.com/influxdata/line-protocol/v2/lineprotocol"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sematextexporter/internal/metadatatest"
 )
 
+func newTestTelemetryBuilder(t *testing.T) *metadata.TelemetryBuilder {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return telemetryBuilder
+}
+
+func newTestTagMapper(t *testing.T, cfg TagsConfig) *tagMapper {
+	tags, err := newTagMapper(cfg)
+	require.NoError(t, err)
+	return tags
+}
+
 func TestSematextHTTPWriterBatchOptimizeTags(t *testing.T) {
 	batch := &sematextHTTPWriterBatch{
 		sematextHTTPWriter: &sematextHTTPWriter{
-			logger:   common.NoopLogger{},
-			token:    "test-token",
-			hostname: "test-host",
+			logger:           newZapSematextLogger(zap.NewNop()),
+			telemetryBuilder: newTestTelemetryBuilder(t),
+			token:            "test-token",
+			hostname:         "test-host",
 		},
 	}
+	filter, err := newTagFilter()
+	require.NoError(t, err)
 
 	for _, testCase := range []struct {
 		name         string
@@ -112,12 +144,304 @@ func TestSematextHTTPWriterBatchOptimizeTags(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
-			gotTags := batch.optimizeTags(testCase.m)
+			gotTags := batch.optimizeTags(context.Background(), filter, testCase.m)
 			assert.Equal(t, testCase.expectedTags, gotTags, "tags should match expected values")
 		})
 	}
 }
 
+func TestSematextHTTPWriterBatchHostMetadata(t *testing.T) {
+	filter, err := newTagFilter()
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		name         string
+		cfg          HostMetadataConfig
+		m            map[string]string
+		expectedTags []tag
+	}{
+		{
+			name: "host name",
+			cfg:  HostMetadataConfig{Enabled: true, HostnameSources: defaultHostnameSources},
+			m: map[string]string{
+				"host.name":      "web-1",
+				"service.name":   "checkout",
+				"cloud.provider": "aws",
+				"cloud.region":   "eu-west-1",
+			},
+			expectedTags: []tag{
+				{"cloud.provider", "aws"},
+				{"cloud.region", "eu-west-1"},
+				{"os.host", "web-1"},
+				{"service.name", "checkout"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name: "kubernetes pod",
+			cfg:  HostMetadataConfig{Enabled: true, HostnameSources: defaultHostnameSources},
+			m: map[string]string{
+				"k8s.pod.name":       "checkout-7d9f",
+				"k8s.namespace.name": "shop",
+				"k8s.node.name":      "node-3",
+				"container.id":       "4b2c",
+			},
+			expectedTags: []tag{
+				{"container.id", "4b2c"},
+				{"kubernetes.namespace", "shop"},
+				{"kubernetes.node.name", "node-3"},
+				{"kubernetes.pod.name", "checkout-7d9f"},
+				{"os.host", "node-3"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name: "configured priority",
+			cfg:  HostMetadataConfig{Enabled: true, HostnameSources: []string{"k8s.node.name", "host.name"}},
+			m: map[string]string{
+				"host.name":     "web-1",
+				"k8s.node.name": "node-3",
+			},
+			expectedTags: []tag{
+				{"kubernetes.node.name", "node-3"},
+				{"os.host", "node-3"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name: "collector hostname fallback",
+			cfg:  HostMetadataConfig{Enabled: true, HostnameSources: defaultHostnameSources},
+			m:    map[string]string{"service.name": "checkout"},
+			expectedTags: []tag{
+				{"os.host", "test-host"},
+				{"service.name", "checkout"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name: "disabled",
+			cfg:  HostMetadataConfig{Enabled: false, HostnameSources: defaultHostnameSources},
+			m: map[string]string{
+				"host.name":    "web-1",
+				"k8s.pod.name": "checkout-7d9f",
+			},
+			expectedTags: []tag{
+				{"os.host", "test-host"},
+				{"token", "test-token"},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			batch := &sematextHTTPWriterBatch{
+				sematextHTTPWriter: &sematextHTTPWriter{
+					logger:           newZapSematextLogger(zap.NewNop()),
+					telemetryBuilder: newTestTelemetryBuilder(t),
+					hostMetadata:     newHostMetadata(testCase.cfg),
+					token:            "test-token",
+					hostname:         "test-host",
+				},
+			}
+			assert.Equal(t, testCase.expectedTags, batch.optimizeTags(context.Background(), filter, testCase.m))
+		})
+	}
+}
+
+func TestSematextHTTPWriterBatchConfiguredTags(t *testing.T) {
+	tags := newTestTagMapper(t, TagsConfig{
+		TagRules: TagRules{
+			Allowed:         []string{"cloud.region"},
+			AllowedPatterns: []string{`^team\.`},
+			Rename:          map[string]string{"k8s.pod.name": "pod"},
+		},
+		MetricOverrides: map[string]TagRules{
+			"http.server.duration": {
+				Allowed: []string{"http.route"},
+				Rename:  map[string]string{"cloud.region": "region"},
+			},
+		},
+	})
+	batch := &sematextHTTPWriterBatch{
+		sematextHTTPWriter: &sematextHTTPWriter{
+			logger:           newZapSematextLogger(zap.NewNop()),
+			telemetryBuilder: newTestTelemetryBuilder(t),
+			tags:             tags,
+			token:            "test-token",
+			hostname:         "test-host",
+		},
+	}
+	attributes := map[string]string{
+		"cloud.region": "eu-west-1",
+		"team.name":    "payments",
+		"k8s.pod.name": "checkout-6d9f",
+		"http.route":   "/pay",
+		"random.tag":   "dropped",
+	}
+
+	for _, testCase := range []struct {
+		name         string
+		measurement  string
+		fields       map[string]any
+		hostMetadata *hostMetadata
+		expectedTags []tag
+	}{
+		{
+			name:        "global rules",
+			measurement: "prometheus",
+			fields:      map[string]any{"jvm.threads.count": 1.0},
+			expectedTags: []tag{
+				{"cloud.region", "eu-west-1"},
+				{"os.host", "test-host"},
+				{"pod", "checkout-6d9f"},
+				{"team.name", "payments"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name:         "rules take precedence over host metadata",
+			measurement:  "http.server.duration",
+			fields:       map[string]any{"gauge": 1.0},
+			hostMetadata: newHostMetadata(createDefaultConfig().(*Config).MetricsConfig.HostMetadata),
+			expectedTags: []tag{
+				{"http.route", "/pay"},
+				{"os.host", "test-host"},
+				{"pod", "checkout-6d9f"},
+				{"region", "eu-west-1"},
+				{"team.name", "payments"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name:        "override matched by field name",
+			measurement: "prometheus",
+			fields:      map[string]any{"http.server.duration_count": 1.0, "http.server.duration_sum": 2.0},
+			expectedTags: []tag{
+				{"http.route", "/pay"},
+				{"os.host", "test-host"},
+				{"pod", "checkout-6d9f"},
+				{"region", "eu-west-1"},
+				{"team.name", "payments"},
+				{"token", "test-token"},
+			},
+		},
+		{
+			name:        "override matched by measurement",
+			measurement: "http.server.duration",
+			fields:      map[string]any{"gauge": 1.0},
+			expectedTags: []tag{
+				{"http.route", "/pay"},
+				{"os.host", "test-host"},
+				{"pod", "checkout-6d9f"},
+				{"region", "eu-west-1"},
+				{"team.name", "payments"},
+				{"token", "test-token"},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			batch.hostMetadata = testCase.hostMetadata
+			filter := tags.filterFor(testCase.measurement, testCase.fields)
+			gotTags := batch.optimizeTags(context.Background(), filter, attributes)
+			assert.Equal(t, testCase.expectedTags, gotTags)
+		})
+	}
+}
+
+func TestSematextHTTPWriterBatchDroppedTagsTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	batch := &sematextHTTPWriterBatch{
+		sematextHTTPWriter: &sematextHTTPWriter{
+			logger:           newZapSematextLogger(zap.NewNop()),
+			telemetryBuilder: telemetryBuilder,
+			token:            "test-token",
+			hostname:         "test-host",
+		},
+	}
+	filter, err := newTagFilter()
+	require.NoError(t, err)
+
+	batch.optimizeTags(context.Background(), filter, map[string]string{
+		"service.name": "test-service",
+		"k8s.pod.name": "dropped",
+		"cloud.region": "dropped",
+	})
+	batch.optimizeTags(context.Background(), filter, map[string]string{"random.tag": "dropped"})
+
+	metadatatest.AssertEqualExporterSematextDroppedTags(t, tel, []metricdata.DataPoint[int64]{{Value: 3}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestSematextHTTPWriterBatchEncodingTelemetry(t *testing.T) {
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requestBody = string(body)
+	}))
+	t.Cleanup(server.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	core, logs := observer.New(zap.WarnLevel)
+
+	writer := &sematextHTTPWriter{
+		encoderPool: sync.Pool{
+			New: func() any {
+				e := new(lineprotocol.Encoder)
+				e.SetLax(false)
+				e.SetPrecision(lineprotocol.Nanosecond)
+				return e
+			},
+		},
+		httpClient:       server.Client(),
+		telemetryBuilder: telemetryBuilder,
+		tags:             newTestTagMapper(t, TagsConfig{}),
+		writeURL:         server.URL,
+		payloadMaxLines:  10,
+		payloadMaxBytes:  10_000_000,
+		logger:           newZapSematextLogger(zap.New(core)),
+		hostname:         "test-host",
+		token:            "test-token",
+	}
+
+	batch := writer.NewBatch()
+	ctx := context.Background()
+	require.NoError(t, batch.EnqueuePoint(ctx, "m", nil, map[string]any{"f": int64(1)}, time.Unix(1, 0), common.InfluxMetricValueTypeGauge))
+	require.NoError(t, batch.EnqueuePoint(ctx, "m", nil, map[string]any{"f": int64(2)}, time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC), common.InfluxMetricValueTypeGauge),
+		"points that cannot be encoded are dropped")
+	require.NoError(t, batch.EnqueuePoint(ctx, "m", nil, map[string]any{"f": int64(3)}, time.Unix(3, 0), common.InfluxMetricValueTypeGauge))
+	require.NoError(t, batch.WriteBatch(ctx))
+
+	assert.Equal(t, "m,os.host=test-host,token=test-token f=1i 1000000000\n"+
+		"m,os.host=test-host,token=test-token f=3i 3000000000\n", requestBody)
+	metadatatest.AssertEqualExporterSematextPointsEncoded(t, tel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExporterSematextPointsDropped(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	dropped := logs.FilterMessage("dropping point that cannot be encoded").All()
+	require.Len(t, dropped, 1)
+	assert.Contains(t, dropped[0].ContextMap(), "error")
+	metadatatest.AssertEqualExporterSematextBatchLines(t, tel, []metricdata.HistogramDataPoint[int64]{{
+		Count:        1,
+		Sum:          2,
+		Bounds:       []float64{1, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+		BucketCounts: []uint64{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		Min:          metricdata.NewExtrema[int64](2),
+		Max:          metricdata.NewExtrema[int64](2),
+	}}, metricdatatest.IgnoreTimestamp())
+	size := int64(len(requestBody))
+	metadatatest.AssertEqualExporterSematextBatchSize(t, tel, []metricdata.HistogramDataPoint[int64]{{
+		Count:        1,
+		Sum:          size,
+		Bounds:       []float64{1000, 10000, 50000, 100000, 200000, 300000, 500000, 1000000, 5000000},
+		BucketCounts: []uint64{1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		Min:          metricdata.NewExtrema(size),
+		Max:          metricdata.NewExtrema(size),
+	}}, metricdatatest.IgnoreTimestamp())
+}
+
 func TestSematextHTTPWriterBatchMaxPayload(t *testing.T) {
 	for _, testCase := range []struct {
 		name                   string
@@ -162,13 +486,15 @@ func TestSematextHTTPWriterBatchMaxPayload(t *testing.T) {
 							return e
 						},
 					},
-					httpClient:      &http.Client{},
-					writeURL:        mockHTTPService.URL,
-					payloadMaxLines: testCase.payloadMaxLines,
-					payloadMaxBytes: testCase.payloadMaxBytes,
-					logger:          common.NoopLogger{},
-					hostname:        "test-host",
-					token:           "test-token",
+					httpClient:       &http.Client{},
+					telemetryBuilder: newTestTelemetryBuilder(t),
+					tags:             newTestTagMapper(t, TagsConfig{}),
+					writeURL:         mockHTTPService.URL,
+					payloadMaxLines:  testCase.payloadMaxLines,
+					payloadMaxBytes:  testCase.payloadMaxBytes,
+					logger:           newZapSematextLogger(zap.NewNop()),
+					hostname:         "test-host",
+					token:            "test-token",
 				},
 			}
 			defer batch.sematextHTTPWriter.httpClient.CloseIdleConnections()
@@ -189,6 +515,73 @@ func TestSematextHTTPWriterBatchMaxPayload(t *testing.T) {
 	}
 }
 
+func TestSematextHTTPWriterRetryAfterPartialFlush(t *testing.T) {
+	var requestBodies []string
+	requests := 0
+	mockHTTPService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requestBodies = append(requestBodies, strings.TrimSpace(string(body)))
+		requests++
+		if requests == 2 || requests == 4 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	t.Cleanup(mockHTTPService.Close)
+
+	writer := &sematextHTTPWriter{
+		encoderPool: sync.Pool{
+			New: func() any {
+				e := new(lineprotocol.Encoder)
+				e.SetLax(false)
+				e.SetPrecision(lineprotocol.Nanosecond)
+				return e
+			},
+		},
+		httpClient:       &http.Client{},
+		telemetryBuilder: newTestTelemetryBuilder(t),
+		tags:             newTestTagMapper(t, TagsConfig{}),
+		delivered:        newPayloadCache(time.Minute),
+		writeURL:         mockHTTPService.URL,
+		payloadMaxLines:  1,
+		payloadMaxBytes:  10_000_000,
+		logger:           newZapSematextLogger(zap.NewNop()),
+		hostname:         "test-host",
+		token:            "test-token",
+	}
+	defer writer.httpClient.CloseIdleConnections()
+
+	// convert emits one line per point, so every point is flushed in its own request.
+	convert := func(ctx context.Context, _ pmetric.Metrics) error {
+		batch := writer.NewBatch()
+		for i := int64(1); i <= 3; i++ {
+			if err := batch.EnqueuePoint(ctx, "m", nil, map[string]any{"f": i}, time.Unix(i, 0), common.InfluxMetricValueTypeGauge); err != nil {
+				return err
+			}
+		}
+		return batch.WriteBatch(ctx)
+	}
+	line := func(i int) string {
+		return fmt.Sprintf("m,os.host=test-host,token=test-token f=%di %d000000000", i, i)
+	}
+
+	md := pmetric.NewMetrics()
+	err := writer.writeMetrics(context.Background(), md, convert)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	err = writer.writeMetrics(context.Background(), md, convert)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.NoError(t, writer.writeMetrics(context.Background(), md, convert))
+	assert.Equal(t, []string{line(1), line(2), line(2), line(3), line(3)}, requestBodies,
+		"the points delivered before a failure must not be sent again by any retry")
+
+	// Another request with the same content is sent in full.
+	requestBodies = nil
+	require.NoError(t, writer.writeMetrics(context.Background(), pmetric.NewMetrics(), convert))
+	assert.Equal(t, []string{line(1), line(2), line(3)}, requestBodies)
+}
+
 func TestSematextHTTPWriterBatchEnqueuePointEmptyTagValue(t *testing.T) {
 	var recordedRequest *http.Request
 	var recordedRequestBody []byte
@@ -204,7 +597,7 @@ func TestSematextHTTPWriterBatchEnqueuePointEmptyTagValue(t *testing.T) {
 	nowTime := time.Unix(1628605794, 318000000)
 
 	sematextWriter, err := newSematextHTTPWriter(
-		new(common.NoopLogger),
+		newZapSematextLogger(zap.NewNop()),
 		&Config{
 			MetricsConfig: MetricsConfig{
 				MetricsEndpoint: noopHTTPServer.URL,
@@ -237,6 +630,116 @@ func TestSematextHTTPWriterBatchEnqueuePointEmptyTagValue(t *testing.T) {
 	}
 }
 
+func decompressBody(t *testing.T, encoding string, body io.Reader) []byte {
+	var r io.Reader
+	switch encoding {
+	case "":
+		r = body
+	case "gzip":
+		gr, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		r = gr
+	case "zstd":
+		zr, err := zstd.NewReader(body)
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	case "snappy":
+		r = snappy.NewReader(body)
+	default:
+		require.Failf(t, "unexpected content encoding", "%q", encoding)
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return b
+}
+
+func TestSematextHTTPWriterClientConfig(t *testing.T) {
+	for _, compression := range []configcompression.Type{"", configcompression.TypeGzip, configcompression.TypeZstd, configcompression.TypeSnappy} {
+		t.Run("compression="+string(compression), func(t *testing.T) {
+			var recordedHeaders http.Header
+			var recordedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				recordedHeaders = r.Header.Clone()
+				recordedBody = decompressBody(t, r.Header.Get("Content-Encoding"), r.Body)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			t.Cleanup(server.Close)
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Compression = compression
+			cfg.Headers["X-Custom-Header"] = "custom"
+			cfg.MetricsEndpoint = server.URL
+			cfg.MetricsConfig.AppToken = metricsAppToken
+
+			writer, err := newSematextHTTPWriter(newZapSematextLogger(zap.NewNop()), cfg, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+			assert.Equal(t, cfg.Timeout, writer.httpClient.Timeout)
+
+			batch := writer.NewBatch()
+			require.NoError(t, batch.EnqueuePoint(context.Background(), "m", nil, map[string]any{"f": int64(1)}, time.Unix(1, 0), common.InfluxMetricValueTypeGauge))
+			require.NoError(t, batch.WriteBatch(context.Background()))
+
+			require.NotNil(t, recordedHeaders)
+			assert.Equal(t, string(compression), recordedHeaders.Get("Content-Encoding"))
+			assert.Equal(t, "OpenTelemetry -> Sematext", recordedHeaders.Get("User-Agent"))
+			assert.Equal(t, "custom", recordedHeaders.Get("X-Custom-Header"))
+			expected := fmt.Sprintf("m,os.host=%s,token=%s f=1i 1000000000", writer.hostname, metricsAppToken)
+			assert.Equal(t, expected, strings.TrimSpace(string(recordedBody)))
+		})
+	}
+}
+
+func TestSematextHTTPWriterTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotNil(t, r.TLS)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	for _, testCase := range []struct {
+		name      string
+		tls       configtls.ClientConfig
+		expectErr bool
+	}{
+		{
+			name: "trusted CA",
+			tls:  configtls.ClientConfig{Config: configtls.Config{CAFile: caFile}},
+		},
+		{
+			name:      "unknown CA",
+			expectErr: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.TLSSetting = testCase.tls
+			cfg.MetricsEndpoint = server.URL
+			cfg.MetricsConfig.AppToken = metricsAppToken
+
+			writer, err := newSematextHTTPWriter(newZapSematextLogger(zap.NewNop()), cfg, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			require.NoError(t, writer.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, writer.Shutdown(context.Background())) })
+
+			batch := writer.NewBatch()
+			require.NoError(t, batch.EnqueuePoint(context.Background(), "m", nil, map[string]any{"f": int64(1)}, time.Unix(1, 0), common.InfluxMetricValueTypeGauge))
+			err = batch.WriteBatch(context.Background())
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestComposeWriteURLDoesNotPanic(t *testing.T) {
 	assert.NotPanics(t, func() {
 		cfg := &Config{
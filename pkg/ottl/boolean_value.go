@@ -13,15 +13,35 @@ type boolExpressionEvaluator[K any] func(ctx context.Context, tCtx K) (bool, err
 
 type BoolExpr[K any] struct {
 	boolExpressionEvaluator[K]
+	// constant holds the result of the expression when it is known at parse time.
+	constant *bool
 }
 
 func (e BoolExpr[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
 	return e.boolExpressionEvaluator(ctx, tCtx)
 }
 
+// isConstant returns the result of the expression and true if it is known at parse time.
+func (e BoolExpr[K]) isConstant() (bool, bool) {
+	if e.constant == nil {
+		return false, false
+	}
+	return *e.constant, true
+}
+
+func constantBoolExpr[K any](value bool) BoolExpr[K] {
+	if value {
+		return BoolExpr[K]{boolExpressionEvaluator: alwaysTrue[K], constant: &value}
+	}
+	return BoolExpr[K]{boolExpressionEvaluator: alwaysFalse[K], constant: &value}
+}
+
 //nolint:unparam
 func not[K any](original BoolExpr[K]) (BoolExpr[K], error) {
-	return BoolExpr[K]{func(ctx context.Context, tCtx K) (bool, error) {
+	if value, ok := original.isConstant(); ok {
+		return constantBoolExpr[K](!value), nil
+	}
+	return BoolExpr[K]{boolExpressionEvaluator: func(ctx context.Context, tCtx K) (bool, error) {
 		result, err := original.Eval(ctx, tCtx)
 		return !result, err
 	}}, nil
@@ -35,10 +55,37 @@ func alwaysFalse[K any](context.Context, K) (bool, error) {
 	return false, nil
 }
 
+// foldFuncs removes the operands that do not change the result of a short-circuited
+// AND (absorbing is false) or OR (absorbing is true), and drops the operands following
+// one that is always equal to absorbing, as they are never evaluated.
+// The operands before it are kept, so their errors are still returned.
+func foldFuncs[K any](funcs []BoolExpr[K], absorbing bool) (folded []BoolExpr[K], absorbed bool) {
+	for _, f := range funcs {
+		value, ok := f.isConstant()
+		if !ok {
+			folded = append(folded, f)
+			continue
+		}
+		if value == absorbing {
+			return folded, true
+		}
+	}
+	return folded, false
+}
+
 // builds a function that returns a short-circuited result of ANDing
 // boolExpressionEvaluator funcs
 func andFuncs[K any](funcs []BoolExpr[K]) BoolExpr[K] {
-	return BoolExpr[K]{func(ctx context.Context, tCtx K) (bool, error) {
+	funcs, absorbed := foldFuncs(funcs, false)
+	switch {
+	case len(funcs) == 0:
+		return constantBoolExpr[K](!absorbed)
+	case len(funcs) == 1 && !absorbed:
+		return funcs[0]
+	case absorbed:
+		funcs = append(funcs, constantBoolExpr[K](false))
+	}
+	return BoolExpr[K]{boolExpressionEvaluator: func(ctx context.Context, tCtx K) (bool, error) {
 		for _, f := range funcs {
 			result, err := f.Eval(ctx, tCtx)
 			if err != nil {
@@ -55,7 +102,16 @@ func andFuncs[K any](funcs []BoolExpr[K]) BoolExpr[K] {
 // builds a function that returns a short-circuited result of ORing
 // boolExpressionEvaluator funcs
 func orFuncs[K any](funcs []BoolExpr[K]) BoolExpr[K] {
-	return BoolExpr[K]{func(ctx context.Context, tCtx K) (bool, error) {
+	funcs, absorbed := foldFuncs(funcs, true)
+	switch {
+	case len(funcs) == 0:
+		return constantBoolExpr[K](absorbed)
+	case len(funcs) == 1 && !absorbed:
+		return funcs[0]
+	case absorbed:
+		funcs = append(funcs, constantBoolExpr[K](true))
+	}
+	return BoolExpr[K]{boolExpressionEvaluator: func(ctx context.Context, tCtx K) (bool, error) {
 		for _, f := range funcs {
 			result, err := f.Eval(ctx, tCtx)
 			if err != nil {
@@ -71,7 +127,7 @@ func orFuncs[K any](funcs []BoolExpr[K]) BoolExpr[K] {
 
 func (p *Parser[K]) newComparisonEvaluator(comparison *comparison) (BoolExpr[K], error) {
 	if comparison == nil {
-		return BoolExpr[K]{alwaysTrue[K]}, nil
+		return constantBoolExpr[K](true), nil
 	}
 	left, err := p.newGetter(comparison.Left)
 	if err != nil {
@@ -82,8 +138,15 @@ func (p *Parser[K]) newComparisonEvaluator(comparison *comparison) (BoolExpr[K],
 		return BoolExpr[K]{}, err
 	}
 
+	// Comparisons between literals are evaluated once at parse time.
+	if l, ok := left.(*literal[K]); ok {
+		if r, ok := right.(*literal[K]); ok {
+			return constantBoolExpr[K](p.compare(l.value, r.value, comparison.Op)), nil
+		}
+	}
+
 	// The parser ensures that we'll never get an invalid comparison.Op, so we don't have to check that case.
-	return BoolExpr[K]{func(ctx context.Context, tCtx K) (bool, error) {
+	return BoolExpr[K]{boolExpressionEvaluator: func(ctx context.Context, tCtx K) (bool, error) {
 		a, leftErr := left.Get(ctx, tCtx)
 		if leftErr != nil {
 			return false, leftErr
@@ -98,7 +161,7 @@ func (p *Parser[K]) newComparisonEvaluator(comparison *comparison) (BoolExpr[K],
 
 func (p *Parser[K]) newBoolExpr(expr *booleanExpression) (BoolExpr[K], error) {
 	if expr == nil {
-		return BoolExpr[K]{alwaysTrue[K]}, nil
+		return constantBoolExpr[K](true), nil
 	}
 	f, err := p.newBooleanTermEvaluator(expr.Left)
 	if err != nil {
@@ -118,7 +181,7 @@ func (p *Parser[K]) newBoolExpr(expr *booleanExpression) (BoolExpr[K], error) {
 
 func (p *Parser[K]) newBooleanTermEvaluator(term *term) (BoolExpr[K], error) {
 	if term == nil {
-		return BoolExpr[K]{alwaysTrue[K]}, nil
+		return constantBoolExpr[K](true), nil
 	}
 	f, err := p.newBooleanValueEvaluator(term.Left)
 	if err != nil {
@@ -138,7 +201,7 @@ func (p *Parser[K]) newBooleanTermEvaluator(term *term) (BoolExpr[K], error) {
 
 func (p *Parser[K]) newBooleanValueEvaluator(value *booleanValue) (BoolExpr[K], error) {
 	if value == nil {
-		return BoolExpr[K]{alwaysTrue[K]}, nil
+		return constantBoolExpr[K](true), nil
 	}
 
 	var boolExpr BoolExpr[K]
@@ -152,11 +215,7 @@ func (p *Parser[K]) newBooleanValueEvaluator(value *booleanValue) (BoolExpr[K],
 	case value.ConstExpr != nil:
 		switch {
 		case value.ConstExpr.Boolean != nil:
-			if *value.ConstExpr.Boolean {
-				boolExpr = BoolExpr[K]{alwaysTrue[K]}
-			} else {
-				boolExpr = BoolExpr[K]{alwaysFalse[K]}
-			}
+			boolExpr = constantBoolExpr[K](bool(*value.ConstExpr.Boolean))
 		case value.ConstExpr.Converter != nil:
 			boolExpr, err = p.newConverterEvaluator(*value.ConstExpr.Converter)
 			if err != nil {
@@ -185,7 +244,7 @@ func (p *Parser[K]) newConverterEvaluator(c converter) (BoolExpr[K], error) {
 	if err != nil {
 		return BoolExpr[K]{}, err
 	}
-	return BoolExpr[K]{func(ctx context.Context, tCtx K) (bool, error) {
+	return BoolExpr[K]{boolExpressionEvaluator: func(ctx context.Context, tCtx K) (bool, error) {
 		result, err := getter.Get(ctx, tCtx)
 		if err != nil {
 			return false, err
@@ -5,6 +5,7 @@ package ottl
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -229,7 +230,7 @@ func False() (ExprFunc[any], error) {
 
 func Test_newBooleanExpressionEvaluator(t *testing.T) {
 	functions := defaultFunctionsForTests()
-	functions["True"] = createFactory("True", &struct{}{}, True)
+	functions["True"] = createFactory("Yes", &struct{}{}, True)
 	functions["False"] = createFactory("False", &struct{}{}, False)
 
 	p, _ := NewParser(
@@ -665,3 +666,123 @@ func Test_newBooleanExpressionEvaluator_invalid(t *testing.T) {
 		})
 	}
 }
+
+func Test_newBoolExpr_constantFolding(t *testing.T) {
+	functions := CreateFactoryMap(
+		createFactory("Fail", &struct{}{}, func() (ExprFunc[any], error) {
+			return func(context.Context, any) (any, error) {
+				return nil, errors.New("failed")
+			}, nil
+		}),
+		createFactory("Yes", &struct{}{}, func() (ExprFunc[any], error) {
+			return func(context.Context, any) (any, error) {
+				return true, nil
+			}, nil
+		}),
+	)
+	p, _ := NewParser(
+		functions,
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+	)
+
+	tests := []struct {
+		condition string
+		constant  bool
+		expected  bool
+		errorMsg  string
+	}{
+		{condition: "true and false", constant: true, expected: false},
+		{condition: "true or false", constant: true, expected: true},
+		{condition: "not true", constant: true, expected: false},
+		{condition: "1 == 1", constant: true, expected: true},
+		{condition: `"a" != "a"`, constant: true, expected: false},
+		{condition: "1 + 2 == 3", constant: true, expected: true},
+		{condition: "not (1 > 2)", constant: true, expected: true},
+		{condition: `false and name == "x"`, constant: true, expected: false},
+		{condition: `true or name == "x"`, constant: true, expected: true},
+		{condition: `true and name == "x"`, constant: false, expected: true},
+		{condition: `false or name == "x"`, constant: false, expected: true},
+		{condition: `name == "x" or true`, constant: false, expected: true},
+		{condition: `name == "y" and 1 == 1`, constant: false, expected: false},
+		{condition: "Yes()", constant: false, expected: true},
+		{condition: "Fail() and false", constant: false, errorMsg: "failed"},
+		{condition: "Fail() or true", constant: false, errorMsg: "failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			parsed, err := parseCondition(tt.condition)
+			require.NoError(t, err)
+			expr, err := p.newBoolExpr(parsed)
+			require.NoError(t, err)
+
+			value, constant := expr.isConstant()
+			assert.Equal(t, tt.constant, constant)
+			if constant {
+				assert.Equal(t, tt.expected, value)
+			}
+
+			result, err := expr.Eval(context.Background(), "x")
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Benchmark_BoolExpr_constantFolding(b *testing.B) {
+	p, _ := NewParser(
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+	)
+	parsed, err := parseCondition(`1 + 1 == 2 and name == "x"`)
+	require.NoError(b, err)
+	folded, err := p.newBoolExpr(parsed)
+	require.NoError(b, err)
+
+	// unfolded evaluates the same expression the way it was before constant folding.
+	sum := exprGetter[any]{expr: Expr[any]{exprFunc: func(context.Context, any) (any, error) {
+		return performMathOperation(int64(1), int64(1), add)
+	}}}
+	comparison := BoolExpr[any]{boolExpressionEvaluator: func(ctx context.Context, tCtx any) (bool, error) {
+		left, err := sum.Get(ctx, tCtx)
+		if err != nil {
+			return false, err
+		}
+		return p.compare(left, int64(2), eq), nil
+	}}
+	parsed, err = parseCondition(`name == "x"`)
+	require.NoError(b, err)
+	name, err := p.newBoolExpr(parsed)
+	require.NoError(b, err)
+	unfolded := BoolExpr[any]{boolExpressionEvaluator: func(ctx context.Context, tCtx any) (bool, error) {
+		for _, f := range []BoolExpr[any]{comparison, name} {
+			result, err := f.Eval(ctx, tCtx)
+			if err != nil || !result {
+				return false, err
+			}
+		}
+		return true, nil
+	}}
+
+	for _, bench := range []struct {
+		name string
+		expr BoolExpr[any]
+	}{
+		{name: "unfolded", expr: unfolded},
+		{name: "folded", expr: folded},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = bench.expr.Eval(ctx, "x")
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// maxConditionSlots is the maximum number of distinct where clauses of a StatementSequence
+// whose results are tracked, so they fit in a bit set.
+const maxConditionSlots = 64
+
+type conditionCacheKey struct{}
+
+// conditionCache holds the where clauses known to be false for all the records executed with
+// the same context.Context, as they only read data shared by these records.
+type conditionCache struct {
+	sharedContexts map[string]struct{}
+	// sequences holds the cached results of each StatementSequence, keyed by its conditionSlots.
+	sequences map[*int]*cachedConditions
+}
+
+// cachedConditions holds the results of the where clauses of a StatementSequence, as bit sets
+// indexed by their slot.
+type cachedConditions struct {
+	// covered has the bits of the where clauses only reading shared data set.
+	covered uint64
+	// falseConditions has the bits of the covered where clauses known to be false set.
+	falseConditions uint64
+	// paths holds the data read by the covered where clauses, indexed by their slot.
+	paths [][]pathRef
+}
+
+// WithConditionCache returns a copy of ctx that lets the StatementSequence executed with it skip
+// the statements whose where clause was already false for a previous record, when the where
+// clause only reads paths of the given contexts.
+//
+// The returned context must only be used to execute statements for records sharing the same
+// data for these contexts, such as the spans of a single scope for the "resource" and "scope"
+// contexts, and is not safe for concurrent use. The cached result of a where clause is discarded
+// whenever a statement which may modify the data it reads is executed. Where clauses invoking
+// converters are never cached, as their result may change between evaluations.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithConditionCache(ctx context.Context, sharedContexts ...string) context.Context {
+	c := &conditionCache{
+		sharedContexts: make(map[string]struct{}, len(sharedContexts)),
+		sequences:      map[*int]*cachedConditions{},
+	}
+	for _, sharedContext := range sharedContexts {
+		c.sharedContexts[canonicalPathContext(sharedContext)] = struct{}{}
+	}
+	return context.WithValue(ctx, conditionCacheKey{}, c)
+}
+
+func conditionCacheFromContext(ctx context.Context) *conditionCache {
+	c, _ := ctx.Value(conditionCacheKey{}).(*conditionCache)
+	return c
+}
+
+// forSequence returns the cached results of the where clauses of the given statements.
+func forSequence[K any](c *conditionCache, statements []*Statement[K], slots []int) *cachedConditions {
+	key := &slots[0]
+	if cached, ok := c.sequences[key]; ok {
+		return cached
+	}
+	cached := &cachedConditions{}
+	for i, statement := range statements {
+		slot := slots[i]
+		if slot < 0 || !c.covers(statement.conditionPaths) {
+			continue
+		}
+		cached.covered |= 1 << slot
+		for len(cached.paths) <= slot {
+			cached.paths = append(cached.paths, nil)
+		}
+		cached.paths[slot] = statement.conditionPaths
+	}
+	c.sequences[key] = cached
+	return cached
+}
+
+// covers returns true if all the given paths belong to shared contexts.
+func (c *conditionCache) covers(paths []pathRef) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	for _, p := range paths {
+		if _, ok := c.sharedContexts[p.context]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidate discards the cached results of the where clauses reading data an editor referencing
+// the given paths may have modified. A nil slice means the modified data is unknown.
+func (c *conditionCache) invalidate(editorPaths []pathRef) {
+	if editorPaths != nil {
+		modifiesShared := false
+		for _, p := range editorPaths {
+			if _, ok := c.sharedContexts[p.context]; ok {
+				modifiesShared = true
+				break
+			}
+		}
+		if !modifiesShared {
+			return
+		}
+	}
+	for _, cached := range c.sequences {
+		if editorPaths == nil {
+			cached.falseConditions = 0
+			continue
+		}
+		for slot, paths := range cached.paths {
+			if cached.falseConditions&(1<<slot) != 0 && pathsOverlap(paths, editorPaths) {
+				cached.falseConditions &^= 1 << slot
+			}
+		}
+	}
+}
+
+// newConditionSlots assigns a slot to each distinct where clause of the statements, so the
+// statements sharing it can reuse its result as long as no editor was invoked in between.
+// It returns nil if none of the where clauses can be tracked.
+func newConditionSlots[K any](statements []*Statement[K]) []int {
+	slots := make([]int, len(statements))
+	ids := map[string]int{}
+	for i, s := range statements {
+		slots[i] = -1
+		if s.conditionKey == "" {
+			continue
+		}
+		id, ok := ids[s.conditionKey]
+		if !ok {
+			if len(ids) == maxConditionSlots {
+				continue
+			}
+			id = len(ids)
+			ids[s.conditionKey] = id
+		}
+		slots[i] = id
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return slots
+}
+
+// newModifiedConditions returns for each statement the bits of the where clauses whose result may
+// change when its function is run, as the function may modify the data they read.
+func newModifiedConditions[K any](statements []*Statement[K], slots []int) []uint64 {
+	if slots == nil {
+		return nil
+	}
+	modified := make([]uint64, len(statements))
+	for i, s := range statements {
+		for j, slot := range slots {
+			if slot < 0 {
+				continue
+			}
+			conditionPaths := statements[j].conditionPaths
+			if s.editorPaths == nil || conditionPaths == nil || pathsOverlap(conditionPaths, s.editorPaths) {
+				modified[i] |= 1 << slot
+			}
+		}
+	}
+	return modified
+}
+
+// whereClauseKey returns the tokens of the where clause of the statement, identifying it
+// regardless of its formatting, or an empty string if the statement has no where clause.
+func whereClauseKey(statement string) string {
+	tokens, err := tokenize(statement)
+	if err != nil {
+		return ""
+	}
+	parts, ok := splitStatement(statement, tokens)
+	if !ok || parts.where == "" {
+		return ""
+	}
+	whereTokens, err := tokenize(parts.where)
+	if err != nil {
+		return ""
+	}
+	values := make([]string, len(whereTokens))
+	for i, t := range whereTokens {
+		values[i] = t.value
+	}
+	return strings.Join(values, " ")
+}
+
+// pathRef identifies the data read or modified through a path: its context, its first field and
+// the keys of this field known at parse time.
+type pathRef struct {
+	context string
+	name    string
+	// keys are the keys of the first field, up to the first one evaluated at execution time.
+	keys []string
+}
+
+// pathContextAliases maps the names of the contexts referring to the data of another context.
+var pathContextAliases = map[string]string{
+	"instrumentation_scope": "scope",
+}
+
+func canonicalPathContext(context string) string {
+	if alias, ok := pathContextAliases[context]; ok {
+		return alias
+	}
+	return context
+}
+
+// newPathRefs returns the data referenced by the given paths, or nil if there are no paths or
+// the data of any of them is unknown.
+func (p *Parser[K]) newPathRefs(paths []path) []pathRef {
+	if len(p.pathContextNames) == 0 || len(paths) == 0 {
+		return nil
+	}
+	refs := make([]pathRef, 0, len(paths))
+	for _, pa := range paths {
+		if pa.Context == "" || len(pa.Fields) == 0 {
+			return nil
+		}
+		ref := pathRef{
+			context: canonicalPathContext(pa.Context),
+			// Timestamps can be accessed both as time.Time and as nanoseconds.
+			name: strings.TrimSuffix(pa.Fields[0].Name, "_unix_nano"),
+		}
+		for _, k := range pa.Fields[0].Keys {
+			if k.String != nil {
+				ref.keys = append(ref.keys, strconv.Quote(*k.String))
+			} else if k.Int != nil {
+				ref.keys = append(ref.keys, strconv.FormatInt(*k.Int, 10))
+			} else {
+				break
+			}
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// overlaps returns true if the data referenced by r and o may be the same, that is when one of
+// them is the other or contains it.
+func (r pathRef) overlaps(o pathRef) bool {
+	if r.context != o.context || r.name != o.name {
+		return false
+	}
+	for i := 0; i < len(r.keys) && i < len(o.keys); i++ {
+		if r.keys[i] != o.keys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// pathsOverlap returns true if any of the data referenced by a overlaps the data referenced by b.
+func pathsOverlap(a, b []pathRef) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ra.overlaps(rb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// grammarConverterVisitor is used to find out whether a booleanExpression invokes converters.
+type grammarConverterVisitor struct {
+	found bool
+}
+
+func (v *grammarConverterVisitor) visitEditor(_ *editor)                   {}
+func (v *grammarConverterVisitor) visitValue(_ *value)                     {}
+func (v *grammarConverterVisitor) visitPath(_ *path)                       {}
+func (v *grammarConverterVisitor) visitMathExprLiteral(_ *mathExprLiteral) {}
+
+func (v *grammarConverterVisitor) visitConverter(_ *converter) {
+	v.found = true
+}
+
+// hasConverters returns true if the boolean expression invokes converters, whose result may not
+// only depend on the paths it reads, for instance Now() or UUID().
+func hasConverters(be *booleanExpression) bool {
+	visitor := &grammarConverterVisitor{}
+	be.accept(visitor)
+	return visitor.found
+}
@@ -0,0 +1,480 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type cacheTestContext struct {
+	resource      pcommon.Map
+	span          pcommon.Map
+	resourceReads int
+	spanReads     int
+	calls         int64
+}
+
+type cacheTestSetArguments struct {
+	Target Setter[*cacheTestContext]
+	Value  Getter[*cacheTestContext]
+}
+
+func newCacheTestParser(t testing.TB) Parser[*cacheTestContext] {
+	functions := CreateFactoryMap(
+		NewFactory("set", &cacheTestSetArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[*cacheTestContext], error) {
+			a := args.(*cacheTestSetArguments)
+			return func(ctx context.Context, tCtx *cacheTestContext) (any, error) {
+				v, err := a.Value.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				return nil, a.Target.Set(ctx, tCtx, v)
+			}, nil
+		}),
+		NewFactory("noop", nil, func(FunctionContext, Arguments) (ExprFunc[*cacheTestContext], error) {
+			return func(context.Context, *cacheTestContext) (any, error) {
+				return nil, nil
+			}, nil
+		}),
+		NewFactory("Calls", nil, func(FunctionContext, Arguments) (ExprFunc[*cacheTestContext], error) {
+			return func(_ context.Context, tCtx *cacheTestContext) (any, error) {
+				tCtx.calls++
+				return tCtx.calls, nil
+			}, nil
+		}),
+		NewFactory("fail", nil, func(FunctionContext, Arguments) (ExprFunc[*cacheTestContext], error) {
+			return func(context.Context, *cacheTestContext) (any, error) {
+				return nil, fmt.Errorf("failed")
+			}, nil
+		}),
+	)
+	p, err := NewParser(
+		functions,
+		func(path Path[*cacheTestContext]) (GetSetter[*cacheTestContext], error) {
+			if path.Name() != "attributes" || len(path.Keys()) != 1 {
+				return nil, fmt.Errorf("bad path %v", path)
+			}
+			key, err := path.Keys()[0].String(context.Background(), nil)
+			if err != nil {
+				return nil, err
+			}
+			attributes := func(tCtx *cacheTestContext) pcommon.Map {
+				if path.Context() == "resource" {
+					tCtx.resourceReads++
+					return tCtx.resource
+				}
+				tCtx.spanReads++
+				return tCtx.span
+			}
+			return &StandardGetSetter[*cacheTestContext]{
+				Getter: func(_ context.Context, tCtx *cacheTestContext) (any, error) {
+					val, ok := attributes(tCtx).Get(*key)
+					if !ok {
+						return nil, nil
+					}
+					return ottlcommon.GetValue(val), nil
+				},
+				Setter: func(_ context.Context, tCtx *cacheTestContext, val any) error {
+					attributes(tCtx).PutStr(*key, val.(string))
+					return nil
+				},
+			}, nil
+		},
+		componenttest.NewNopTelemetrySettings(),
+		WithPathContextNames[*cacheTestContext]([]string{"resource", "span"}),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func newCacheTestSequence(t testing.TB, statements ...string) StatementSequence[*cacheTestContext] {
+	return newCacheTestSequenceWithOptions(t, nil, statements...)
+}
+
+func newCacheTestSequenceWithOptions(t testing.TB, options []StatementSequenceOption[*cacheTestContext], statements ...string) StatementSequence[*cacheTestContext] {
+	p := newCacheTestParser(t)
+	parsed, err := p.ParseStatements(statements)
+	require.NoError(t, err)
+	return NewStatementSequence(parsed, componenttest.NewNopTelemetrySettings(), options...)
+}
+
+func newCacheTestContext(resource, span map[string]any) *cacheTestContext {
+	tCtx := &cacheTestContext{resource: pcommon.NewMap(), span: pcommon.NewMap()}
+	_ = tCtx.resource.FromRaw(resource)
+	_ = tCtx.span.FromRaw(span)
+	return tCtx
+}
+
+func Test_StatementSequence_Execute_sharedConditions(t *testing.T) {
+	statements := newCacheTestSequence(t,
+		`set(span.attributes["a"], "1") where span.attributes["x"] == "y"`,
+		`set(span.attributes["b"], "2") where span.attributes["x"] == "y"`,
+		`set(span.attributes["c"], "3") where span.attributes["x"]=="y"`,
+	)
+	assert.Equal(t, []int{0, 0, 0}, statements.conditionSlots)
+
+	tCtx := newCacheTestContext(nil, map[string]any{"x": "z"})
+	require.NoError(t, statements.Execute(context.Background(), tCtx))
+	assert.Equal(t, 1, tCtx.spanReads)
+	assert.Equal(t, map[string]any{"x": "z"}, tCtx.span.AsRaw())
+
+	// Only the where clauses known to be false are skipped.
+	tCtx = newCacheTestContext(nil, map[string]any{"x": "y"})
+	require.NoError(t, statements.Execute(context.Background(), tCtx))
+	assert.Equal(t, 6, tCtx.spanReads)
+	assert.Equal(t, map[string]any{"x": "y", "a": "1", "b": "2", "c": "3"}, tCtx.span.AsRaw())
+}
+
+func Test_StatementSequence_Execute_sharedConditions_modified(t *testing.T) {
+	statements := newCacheTestSequence(t,
+		`set(span.attributes["a"], "1") where span.attributes["x"] == "y"`,
+		`set(span.attributes["x"], "y")`,
+		`set(span.attributes["b"], "2") where span.attributes["x"] == "y"`,
+	)
+	assert.Equal(t, []int{0, -1, 0}, statements.conditionSlots)
+
+	tCtx := newCacheTestContext(nil, nil)
+	require.NoError(t, statements.Execute(context.Background(), tCtx))
+	assert.Equal(t, map[string]any{"x": "y", "b": "2"}, tCtx.span.AsRaw())
+}
+
+func Test_StatementSequence_Execute_sharedConditions_otherPathModified(t *testing.T) {
+	statements := newCacheTestSequence(t,
+		`set(span.attributes["a"], "1") where span.attributes["x"] == "y"`,
+		`set(span.attributes["b"], "2")`,
+		`set(span.attributes["c"], "3") where span.attributes["x"] == "y"`,
+	)
+	assert.Equal(t, []int{0, -1, 0}, statements.conditionSlots)
+
+	// The second statement does not modify the attribute read by the shared where clause.
+	tCtx := newCacheTestContext(nil, map[string]any{"x": "z"})
+	require.NoError(t, statements.Execute(context.Background(), tCtx))
+	assert.Equal(t, 2, tCtx.spanReads)
+	assert.Equal(t, map[string]any{"x": "z", "b": "2"}, tCtx.span.AsRaw())
+}
+
+func Test_StatementSequence_Execute_nonDeterministicConditions(t *testing.T) {
+	statements := newCacheTestSequence(t,
+		`set(span.attributes["a"], "1") where resource.attributes["env"] == "dev" and Calls() == 2`,
+		`set(span.attributes["b"], "2") where resource.attributes["env"] == "dev" and Calls() == 2`,
+	)
+	assert.Nil(t, statements.conditionSlots)
+
+	for _, ctx := range []context.Context{context.Background(), WithConditionCache(context.Background(), "resource")} {
+		resource := pcommon.NewMap()
+		resource.PutStr("env", "dev")
+		for range 2 {
+			tCtx := newCacheTestContext(nil, nil)
+			tCtx.resource = resource
+			require.NoError(t, statements.Execute(ctx, tCtx))
+			assert.Equal(t, map[string]any{"b": "2"}, tCtx.span.AsRaw())
+			assert.Equal(t, int64(2), tCtx.calls)
+		}
+	}
+}
+
+func Test_StatementSequence_Execute_sharedConditions_errorHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		expectedResource map[string]any
+		expectedSpans    []map[string]any
+	}{
+		{
+			name:             "without cache",
+			ctx:              context.Background(),
+			expectedResource: map[string]any{"ottl.error": "failed"},
+			expectedSpans: []map[string]any{
+				{"ottl.error": "failed", "a": "1", "b": "2"},
+				{"ottl.error": "failed", "a": "1", "b": "2"},
+			},
+		},
+		{
+			name:             "with cache",
+			ctx:              WithConditionCache(context.Background(), "resource"),
+			expectedResource: map[string]any{"ottl.error": "failed"},
+			expectedSpans: []map[string]any{
+				{"ottl.error": "failed", "a": "1", "b": "2"},
+				{"ottl.error": "failed", "a": "1", "b": "2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := newCacheTestSequenceWithOptions(t,
+				[]StatementSequenceOption[*cacheTestContext]{
+					WithStatementSequenceErrorMode[*cacheTestContext](IgnoreError),
+					WithStatementSequenceErrorHandler(func(_ context.Context, tCtx *cacheTestContext, _ int, _ string, err error) {
+						tCtx.resource.PutStr("ottl.error", err.Error())
+						tCtx.span.PutStr("ottl.error", err.Error())
+					}),
+				},
+				`set(span.attributes["a"], "1") where resource.attributes["ottl.error"] != nil`,
+				`set(span.attributes["b"], "2") where span.attributes["ottl.error"] != nil`,
+				`fail()`,
+				`set(span.attributes["a"], "1") where resource.attributes["ottl.error"] != nil`,
+				`set(span.attributes["b"], "2") where span.attributes["ottl.error"] != nil`,
+			)
+			assert.Equal(t, []int{0, 1, -1, 0, 1}, statements.conditionSlots)
+
+			resource := pcommon.NewMap()
+			for i := range tt.expectedSpans {
+				tCtx := newCacheTestContext(nil, nil)
+				tCtx.resource = resource
+				require.NoError(t, statements.Execute(tt.ctx, tCtx))
+				assert.Equal(t, tt.expectedSpans[i], tCtx.span.AsRaw())
+			}
+			assert.Equal(t, tt.expectedResource, resource.AsRaw())
+		})
+	}
+}
+
+func Test_StatementSequence_Execute_conditionCache(t *testing.T) {
+	statements := newCacheTestSequence(t,
+		`set(span.attributes["a"], "1") where resource.attributes["env"] == "prod"`,
+		`set(span.attributes["b"], "2") where span.attributes["x"] == "y"`,
+		`set(resource.attributes["env"], "prod") where span.attributes["x"] == "promote"`,
+		`noop() where span.attributes["x"] == "noop"`,
+	)
+
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		spans             []map[string]any
+		expectedResource  map[string]any
+		expectedSpans     []map[string]any
+		expectedResReads  int
+		expectedSpanReads int
+	}{
+		{
+			name:              "without cache",
+			ctx:               context.Background(),
+			spans:             []map[string]any{{"x": "y"}, {"x": "y"}, {"x": "y"}},
+			expectedResource:  map[string]any{"env": "dev"},
+			expectedSpans:     []map[string]any{{"x": "y", "b": "2"}, {"x": "y", "b": "2"}, {"x": "y", "b": "2"}},
+			expectedResReads:  3,
+			expectedSpanReads: 12,
+		},
+		{
+			name:              "with cache",
+			ctx:               WithConditionCache(context.Background(), "resource"),
+			spans:             []map[string]any{{"x": "y"}, {"x": "y"}, {"x": "y"}},
+			expectedResource:  map[string]any{"env": "dev"},
+			expectedSpans:     []map[string]any{{"x": "y", "b": "2"}, {"x": "y", "b": "2"}, {"x": "y", "b": "2"}},
+			expectedResReads:  1,
+			expectedSpanReads: 12,
+		},
+		{
+			name:              "cache for other contexts",
+			ctx:               WithConditionCache(context.Background(), "scope"),
+			spans:             []map[string]any{{"x": "y"}, {"x": "y"}},
+			expectedResource:  map[string]any{"env": "dev"},
+			expectedSpans:     []map[string]any{{"x": "y", "b": "2"}, {"x": "y", "b": "2"}},
+			expectedResReads:  2,
+			expectedSpanReads: 8,
+		},
+		{
+			name:              "cache invalidated by a function modifying the resource",
+			ctx:               WithConditionCache(context.Background(), "resource"),
+			spans:             []map[string]any{{"x": "promote"}, {"x": "y"}},
+			expectedResource:  map[string]any{"env": "prod"},
+			expectedSpans:     []map[string]any{{"x": "promote"}, {"x": "y", "a": "1", "b": "2"}},
+			expectedResReads:  3,
+			expectedSpanReads: 8,
+		},
+		{
+			name:              "cache invalidated by a function without paths",
+			ctx:               WithConditionCache(context.Background(), "resource"),
+			spans:             []map[string]any{{"x": "noop"}, {"x": "y"}},
+			expectedResource:  map[string]any{"env": "dev"},
+			expectedSpans:     []map[string]any{{"x": "noop"}, {"x": "y", "b": "2"}},
+			expectedResReads:  2,
+			expectedSpanReads: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := pcommon.NewMap()
+			resource.PutStr("env", "dev")
+			var resourceReads, spanReads int
+			for i, span := range tt.spans {
+				tCtx := newCacheTestContext(nil, span)
+				tCtx.resource = resource
+				require.NoError(t, statements.Execute(tt.ctx, tCtx))
+				assert.Equal(t, tt.expectedSpans[i], tCtx.span.AsRaw())
+				resourceReads += tCtx.resourceReads
+				spanReads += tCtx.spanReads
+			}
+			assert.Equal(t, tt.expectedResource, resource.AsRaw())
+			assert.Equal(t, tt.expectedResReads, resourceReads)
+			assert.Equal(t, tt.expectedSpanReads, spanReads)
+		})
+	}
+}
+
+func Test_newConditionSlots(t *testing.T) {
+	p := newCacheTestParser(t)
+	statements, err := p.ParseStatements([]string{
+		`set(span.attributes["a"], "1") where span.attributes["x"] == "y"`,
+		`set(span.attributes["b"], "2") where resource.attributes["x"] == "y"`,
+		`set(span.attributes["c"], "3") where span.attributes["x"] == "y"`,
+		`set(span.attributes["d"], "4")`,
+		`set(span.attributes["e"], "5") where 1 == 1`,
+		`set(span.attributes["f"], "6") where 1 == 1`,
+		`set(span.attributes["g"], "7") where resource.attributes["x"] == "y"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 0, -1, -1, -1, 1}, newConditionSlots(statements))
+	assert.Nil(t, newConditionSlots(statements[3:6]))
+}
+
+func Test_newModifiedConditions(t *testing.T) {
+	p := newCacheTestParser(t)
+	statements, err := p.ParseStatements([]string{
+		`set(span.attributes["a"], "1") where span.attributes["x"] == "y"`,
+		`set(span.attributes["x"], "z") where resource.attributes["x"] == "y"`,
+		`set(resource.attributes["x"], "z")`,
+		`noop()`,
+	})
+	require.NoError(t, err)
+	slots := newConditionSlots(statements)
+	assert.Equal(t, []int{0, 1, -1, -1}, slots)
+	assert.Equal(t, []uint64{0, 1, 2, 3}, newModifiedConditions(statements, slots))
+}
+
+func Test_pathRef_overlaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        pathRef
+		b        pathRef
+		expected bool
+	}{
+		{
+			name:     "same key",
+			a:        pathRef{context: "span", name: "attributes", keys: []string{`"a"`}},
+			b:        pathRef{context: "span", name: "attributes", keys: []string{`"a"`}},
+			expected: true,
+		},
+		{
+			name:     "other key",
+			a:        pathRef{context: "span", name: "attributes", keys: []string{`"a"`}},
+			b:        pathRef{context: "span", name: "attributes", keys: []string{`"b"`}},
+			expected: false,
+		},
+		{
+			name:     "whole map",
+			a:        pathRef{context: "span", name: "attributes", keys: []string{`"a"`, "0"}},
+			b:        pathRef{context: "span", name: "attributes"},
+			expected: true,
+		},
+		{
+			name:     "other field",
+			a:        pathRef{context: "span", name: "attributes"},
+			b:        pathRef{context: "span", name: "name"},
+			expected: false,
+		},
+		{
+			name:     "other context",
+			a:        pathRef{context: "span", name: "attributes"},
+			b:        pathRef{context: "resource", name: "attributes"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.a.overlaps(tt.b))
+			assert.Equal(t, tt.expected, tt.b.overlaps(tt.a))
+		})
+	}
+}
+
+func Test_whereClauseKey(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  string
+	}{
+		{
+			statement: `set(attributes["a"], "b")`,
+			expected:  "",
+		},
+		{
+			statement: `set(attributes["a"], "b") where attributes["c"] == "d"`,
+			expected:  `attributes [ "c" ] == "d"`,
+		},
+		{
+			statement: `set(attributes["a"], "b") where   attributes[ "c" ]=="d"`,
+			expected:  `attributes [ "c" ] == "d"`,
+		},
+		{
+			statement: `set(attributes["a"], "b where") where IsMatch(name, "where")`,
+			expected:  `IsMatch ( name , "where" )`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			assert.Equal(t, tt.expected, whereClauseKey(tt.statement))
+		})
+	}
+}
+
+func Benchmark_StatementSequence_sharedConditions(b *testing.B) {
+	for _, shared := range []bool{false, true} {
+		b.Run(fmt.Sprintf("shared=%v", shared), func(b *testing.B) {
+			statements := make([]string, 10)
+			for i := range statements {
+				condition := `span.attributes["x"] == "y"`
+				if !shared {
+					// The constant comparison is folded at parse time, so the conditions have the
+					// same cost but cannot be shared as they are written differently.
+					condition += fmt.Sprintf(" and %d == %d", i, i)
+				}
+				statements[i] = fmt.Sprintf(`set(span.attributes["a%d"], "1") where %s`, i, condition)
+			}
+			sequence := newCacheTestSequence(b, statements...)
+			tCtx := newCacheTestContext(nil, map[string]any{"x": "z"})
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = sequence.Execute(ctx, tCtx)
+			}
+		})
+	}
+}
+
+func Benchmark_StatementSequence_conditionCache(b *testing.B) {
+	statements := newCacheTestSequence(b,
+		`set(span.attributes["a"], "1") where resource.attributes["env"] == "prod"`,
+		`set(span.attributes["b"], "2") where resource.attributes["env"] == "prod" and span.attributes["x"] == "y"`,
+		`set(span.attributes["c"], "3") where span.attributes["x"] == "y"`,
+	)
+	spans := make([]*cacheTestContext, 100)
+	for i := range spans {
+		spans[i] = newCacheTestContext(map[string]any{"env": "dev"}, map[string]any{"x": "z"})
+	}
+	for _, cached := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%v", cached), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ctx := context.Background()
+				if cached {
+					ctx = WithConditionCache(ctx, "resource")
+				}
+				for _, tCtx := range spans {
+					_ = statements.Execute(ctx, tCtx)
+				}
+			}
+		})
+	}
+}
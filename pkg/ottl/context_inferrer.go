@@ -16,9 +16,11 @@ import (
 
 var defaultContextInferPriority = []string{
 	"log",
+	"profile",
 	"datapoint",
 	"metric",
 	"spanevent",
+	"spanlink",
 	"span",
 	"resource",
 	"scope",
@@ -222,6 +224,10 @@ func (s *priorityContextInferrer) getParsedStatementHints(parsed *parsedStatemen
 	if parsed.WhereClause != nil {
 		parsed.WhereClause.accept(&visitor)
 	}
+	if parsed.Loop != nil {
+		visitor.paths = parsed.Loop.withoutVariables(visitor.paths)
+		parsed.Loop.Collection.accept(&visitor)
+	}
 	return visitor.paths, visitor.functions, visitor.enumsSymbols
 }
 
@@ -163,9 +163,11 @@ func Test_NewPriorityContextInferrer_InvalidStatement(t *testing.T) {
 func Test_DefaultPriorityContextInferrer(t *testing.T) {
 	expectedPriority := []string{
 		"log",
+		"profile",
 		"datapoint",
 		"metric",
 		"spanevent",
+		"spanlink",
 		"span",
 		"resource",
 		"scope",
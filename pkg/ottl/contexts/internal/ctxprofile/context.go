@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+const (
+	Name   = "profile"
+	DocRef = "https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/pkg/ottl/contexts/ottlprofile"
+)
+
+type Context interface {
+	GetProfile() pprofile.Profile
+	// GetProfileAttributes returns the attributes of the profile resolved from its attribute
+	// table. The same map is returned for the whole lifetime of the context, so editors can
+	// modify it in place before it is written back to the profile.
+	GetProfileAttributes() pcommon.Map
+}
@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxerror"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxutil"
+)
+
+func PathGetSetter[K Context](path ottl.Path[K]) (ottl.GetSetter[K], error) {
+	if path == nil {
+		return nil, ctxerror.New("nil", "nil", Name, DocRef)
+	}
+	switch path.Name() {
+	case "time_unix_nano":
+		return accessTimeUnixNano[K](), nil
+	case "time":
+		return accessTime[K](), nil
+	case "duration_unix_nano":
+		return accessDurationUnixNano[K](), nil
+	case "duration":
+		return accessDuration[K](), nil
+	case "attributes":
+		if path.Keys() == nil {
+			return accessAttributes[K](), nil
+		}
+		return accessAttributesKey(path.Keys()), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount[K](), nil
+	case "sample_type":
+		if path.Keys() == nil {
+			return accessSampleType[K](), nil
+		}
+		return accessSampleTypeKey(path.Keys()), nil
+	case "original_payload_format":
+		return accessOriginalPayloadFormat[K](), nil
+	case "profile_id":
+		nextPath := path.Next()
+		if nextPath != nil {
+			if nextPath.Name() == "string" {
+				return accessStringProfileID[K](), nil
+			}
+			return nil, ctxerror.New(nextPath.Name(), nextPath.String(), Name, DocRef)
+		}
+		return accessProfileID[K](), nil
+	default:
+		return nil, ctxerror.New(path.Name(), path.String(), Name, DocRef)
+	}
+}
+
+func accessTimeUnixNano[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Time().AsTime().UnixNano(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetTime(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessTime[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Time().AsTime(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(time.Time); ok {
+				tCtx.GetProfile().SetTime(pcommon.NewTimestampFromTime(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessDurationUnixNano[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return int64(tCtx.GetProfile().Duration()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetDuration(pcommon.Timestamp(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessDuration[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return time.Duration(tCtx.GetProfile().Duration()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if d, ok := val.(time.Duration); ok {
+				tCtx.GetProfile().SetDuration(pcommon.Timestamp(d))
+			}
+			return nil
+		},
+	}
+}
+
+// The attributes of a profile are stored as indices of the attribute table, so they are
+// accessed through the map resolved by the context. The setters write the map back to the
+// profile right away, while the modifications made in place by editors are written back by
+// the context once the statements were executed.
+func accessAttributes[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfileAttributes(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			newAttrs := pcommon.NewMap()
+			if err := ctxutil.SetMap(newAttrs, val); err != nil {
+				return err
+			}
+			attrs := tCtx.GetProfileAttributes()
+			newAttrs.CopyTo(attrs)
+			return SetAttributes(tCtx.GetProfile(), attrs)
+		},
+	}
+}
+
+func accessAttributesKey[K Context](key []ottl.Key[K]) ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (any, error) {
+			return ctxutil.GetMapValue[K](ctx, tCtx, tCtx.GetProfileAttributes(), key)
+		},
+		Setter: func(ctx context.Context, tCtx K, val any) error {
+			attrs := tCtx.GetProfileAttributes()
+			if err := ctxutil.SetMapValue[K](ctx, tCtx, attrs, key, val); err != nil {
+				return err
+			}
+			return SetAttributes(tCtx.GetProfile(), attrs)
+		},
+	}
+}
+
+// SetAttributes replaces the attributes of the profile. Entries of the attribute table are
+// never removed, as they may be referenced by other records of the profile.
+func SetAttributes(profile pprofile.Profile, attrs pcommon.Map) error {
+	profile.AttributeIndices().FromRaw(nil)
+	var errs error
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		errs = errors.Join(errs, pprofile.AddAttribute(profile.AttributeTable(), profile, k, v))
+		return true
+	})
+	return errs
+}
+
+func accessDroppedAttributesCount[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return int64(tCtx.GetProfile().DroppedAttributesCount()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetDroppedAttributesCount(uint32(i))
+			}
+			return nil
+		},
+	}
+}
+
+// The sample types of a profile are represented as a slice of maps with the "type", "unit" and
+// "aggregation_temporality" keys, with the type and unit resolved from the string table.
+func accessSampleType[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return getSampleTypes(tCtx.GetProfile()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			sampleTypes := pcommon.NewSlice()
+			switch v := val.(type) {
+			case pcommon.Slice:
+				sampleTypes = v
+			case []any:
+				if err := sampleTypes.FromRaw(v); err != nil {
+					return err
+				}
+			default:
+				return nil
+			}
+			return setSampleTypes(tCtx.GetProfile(), sampleTypes)
+		},
+	}
+}
+
+func accessSampleTypeKey[K Context](key []ottl.Key[K]) ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (any, error) {
+			return ctxutil.GetSliceValue[K](ctx, tCtx, getSampleTypes(tCtx.GetProfile()), key)
+		},
+		Setter: func(ctx context.Context, tCtx K, val any) error {
+			profile := tCtx.GetProfile()
+			sampleTypes := getSampleTypes(profile)
+			if err := ctxutil.SetSliceValue[K](ctx, tCtx, sampleTypes, key, val); err != nil {
+				return err
+			}
+			return setSampleTypes(profile, sampleTypes)
+		},
+	}
+}
+
+// getSampleTypes returns a copy of the sample types of the profile as a slice of maps, with the
+// string table indices resolved.
+func getSampleTypes(profile pprofile.Profile) pcommon.Slice {
+	sampleTypes := pcommon.NewSlice()
+	sampleTypes.EnsureCapacity(profile.SampleType().Len())
+	for i := 0; i < profile.SampleType().Len(); i++ {
+		vt := profile.SampleType().At(i)
+		m := sampleTypes.AppendEmpty().SetEmptyMap()
+		m.PutStr("type", getString(profile.StringTable(), vt.TypeStrindex()))
+		m.PutStr("unit", getString(profile.StringTable(), vt.UnitStrindex()))
+		m.PutInt("aggregation_temporality", int64(vt.AggregationTemporality()))
+	}
+	return sampleTypes
+}
+
+func setSampleTypes(profile pprofile.Profile, sampleTypes pcommon.Slice) error {
+	valueTypes := pprofile.NewValueTypeSlice()
+	valueTypes.EnsureCapacity(sampleTypes.Len())
+	for i := 0; i < sampleTypes.Len(); i++ {
+		item := sampleTypes.At(i)
+		if item.Type() != pcommon.ValueTypeMap {
+			return fmt.Errorf("sample type must be a map, got %s", item.Type())
+		}
+		vt := valueTypes.AppendEmpty()
+		if typ, ok := item.Map().Get("type"); ok {
+			vt.SetTypeStrindex(putString(profile.StringTable(), typ.AsString()))
+		}
+		if unit, ok := item.Map().Get("unit"); ok {
+			vt.SetUnitStrindex(putString(profile.StringTable(), unit.AsString()))
+		}
+		if temporality, ok := item.Map().Get("aggregation_temporality"); ok {
+			if temporality.Type() != pcommon.ValueTypeInt {
+				return fmt.Errorf("aggregation_temporality must be an int, got %s", temporality.Type())
+			}
+			vt.SetAggregationTemporality(pprofile.AggregationTemporality(temporality.Int()))
+		}
+	}
+	valueTypes.CopyTo(profile.SampleType())
+	return nil
+}
+
+func accessOriginalPayloadFormat[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().OriginalPayloadFormat(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if s, ok := val.(string); ok {
+				tCtx.GetProfile().SetOriginalPayloadFormat(s)
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().ProfileID(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if newProfileID, ok := val.(pprofile.ProfileID); ok {
+				tCtx.GetProfile().SetProfileID(newProfileID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringProfileID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			id := tCtx.GetProfile().ProfileID()
+			return hex.EncodeToString(id[:]), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				id, err := ctxcommon.ParseProfileID(str)
+				if err != nil {
+					return err
+				}
+				tCtx.GetProfile().SetProfileID(id)
+			}
+			return nil
+		},
+	}
+}
+
+// getString returns the string at the given index of the string table, or an empty string if
+// the index is out of range.
+func getString(table pcommon.StringSlice, index int32) string {
+	if index < 0 || int(index) >= table.Len() {
+		return ""
+	}
+	return table.At(int(index))
+}
+
+// putString returns the index of the given string in the string table, adding it if missing.
+func putString(table pcommon.StringSlice, s string) int32 {
+	for i := 0; i < table.Len(); i++ {
+		if table.At(i) == s {
+			return int32(i) //nolint:gosec // the string table can't have more than math.MaxInt32 entries
+		}
+	}
+	if table.Len() >= math.MaxInt32 {
+		return 0
+	}
+	table.Append(s)
+	return int32(table.Len() - 1) //nolint:gosec // overflow checked
+}
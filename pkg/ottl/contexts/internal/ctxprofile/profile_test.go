@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	profileID1 = pprofile.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	profileID2 = pprofile.ProfileID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+
+	time1 = time.Date(1970, 1, 1, 0, 0, 0, 100000000, time.UTC)
+	time2 = time.Date(1970, 1, 1, 0, 0, 0, 200000000, time.UTC)
+)
+
+func TestPathGetSetter(t *testing.T) {
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	origAttrs := pcommon.NewMap()
+	origAttrs.PutStr("str", "val")
+	origAttrs.PutInt("int", 10)
+
+	origSampleTypes := pcommon.NewSlice()
+	origSampleType := origSampleTypes.AppendEmpty().SetEmptyMap()
+	origSampleType.PutStr("type", "samples")
+	origSampleType.PutStr("unit", "count")
+	origSampleType.PutInt("aggregation_temporality", int64(pprofile.AggregationTemporalityDelta))
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*testContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.Profile)
+	}{
+		{
+			name: "time_unix_nano",
+			path: &pathtest.Path[*testContext]{
+				N: "time_unix_nano",
+			},
+			orig:   time1.UnixNano(),
+			newVal: time2.UnixNano(),
+			modified: func(profile pprofile.Profile) {
+				profile.SetTime(pcommon.NewTimestampFromTime(time2))
+			},
+		},
+		{
+			name: "time",
+			path: &pathtest.Path[*testContext]{
+				N: "time",
+			},
+			orig:   time1,
+			newVal: time2,
+			modified: func(profile pprofile.Profile) {
+				profile.SetTime(pcommon.NewTimestampFromTime(time2))
+			},
+		},
+		{
+			name: "duration_unix_nano",
+			path: &pathtest.Path[*testContext]{
+				N: "duration_unix_nano",
+			},
+			orig:   int64(time.Second),
+			newVal: int64(time.Minute),
+			modified: func(profile pprofile.Profile) {
+				profile.SetDuration(pcommon.Timestamp(time.Minute))
+			},
+		},
+		{
+			name: "duration",
+			path: &pathtest.Path[*testContext]{
+				N: "duration",
+			},
+			orig:   time.Second,
+			newVal: time.Minute,
+			modified: func(profile pprofile.Profile) {
+				profile.SetDuration(pcommon.Timestamp(time.Minute))
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+			},
+			orig:   origAttrs,
+			newVal: newAttrs,
+			modified: func(profile pprofile.Profile) {
+				entry := profile.AttributeTable().AppendEmpty()
+				entry.SetKey("hello")
+				entry.Value().SetStr("world")
+				profile.AttributeIndices().FromRaw([]int32{2})
+			},
+		},
+		{
+			name: "attributes string",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(profile pprofile.Profile) {
+				entry := profile.AttributeTable().AppendEmpty()
+				entry.SetKey("str")
+				entry.Value().SetStr("newVal")
+				profile.AttributeIndices().FromRaw([]int32{2, 1})
+			},
+		},
+		{
+			name: "attributes new key",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("new"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: int64(10),
+			modified: func(profile pprofile.Profile) {
+				entry := profile.AttributeTable().AppendEmpty()
+				entry.SetKey("new")
+				entry.Value().SetInt(10)
+				profile.AttributeIndices().FromRaw([]int32{0, 1, 2})
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &pathtest.Path[*testContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.Profile) {
+				profile.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "sample_type",
+			path: &pathtest.Path[*testContext]{
+				N: "sample_type",
+			},
+			orig: origSampleTypes,
+			newVal: []any{
+				map[string]any{
+					"type":                    "cpu",
+					"unit":                    "nanoseconds",
+					"aggregation_temporality": int64(pprofile.AggregationTemporalityCumulative),
+				},
+				map[string]any{
+					"type": "samples",
+				},
+			},
+			modified: func(profile pprofile.Profile) {
+				profile.StringTable().Append("cpu", "nanoseconds")
+				profile.SampleType().RemoveIf(func(pprofile.ValueType) bool { return true })
+				cpu := profile.SampleType().AppendEmpty()
+				cpu.SetTypeStrindex(3)
+				cpu.SetUnitStrindex(4)
+				cpu.SetAggregationTemporality(pprofile.AggregationTemporalityCumulative)
+				samples := profile.SampleType().AppendEmpty()
+				samples.SetTypeStrindex(1)
+			},
+		},
+		{
+			name: "sample_type index",
+			path: &pathtest.Path[*testContext]{
+				N: "sample_type",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						I: ottltest.Intp(0),
+					},
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("unit"),
+					},
+				},
+			},
+			orig:   "count",
+			newVal: "nanoseconds",
+			modified: func(profile pprofile.Profile) {
+				profile.StringTable().Append("nanoseconds")
+				profile.SampleType().At(0).SetUnitStrindex(3)
+			},
+		},
+		{
+			name: "original_payload_format",
+			path: &pathtest.Path[*testContext]{
+				N: "original_payload_format",
+			},
+			orig:   "pprofext",
+			newVal: "jfr",
+			modified: func(profile pprofile.Profile) {
+				profile.SetOriginalPayloadFormat("jfr")
+			},
+		},
+		{
+			name: "profile_id",
+			path: &pathtest.Path[*testContext]{
+				N: "profile_id",
+			},
+			orig:   profileID1,
+			newVal: profileID2,
+			modified: func(profile pprofile.Profile) {
+				profile.SetProfileID(profileID2)
+			},
+		},
+		{
+			name: "profile_id string",
+			path: &pathtest.Path[*testContext]{
+				N: "profile_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:   hex.EncodeToString(profileID1[:]),
+			newVal: hex.EncodeToString(profileID2[:]),
+			modified: func(profile pprofile.Profile) {
+				profile.SetProfileID(profileID2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxprofile.PathGetSetter(tt.path)
+			require.NoError(t, err)
+
+			profile := createProfile()
+
+			got, err := accessor.Get(context.Background(), newTestContext(profile))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), newTestContext(profile), tt.newVal)
+			assert.NoError(t, err)
+
+			expectedProfile := createProfile()
+			tt.modified(expectedProfile)
+
+			assert.Equal(t, expectedProfile, profile)
+		})
+	}
+}
+
+func TestPathGetSetter_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path ottl.Path[*testContext]
+	}{
+		{
+			name: "unknown path",
+			path: &pathtest.Path[*testContext]{
+				N: "unknown",
+			},
+		},
+		{
+			name: "unknown profile_id sub path",
+			path: &pathtest.Path[*testContext]{
+				N: "profile_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "unknown",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ctxprofile.PathGetSetter(tt.path)
+			assert.ErrorContains(t, err, `segment "unknown" from path`)
+		})
+	}
+}
+
+func TestSampleType_SetInvalid(t *testing.T) {
+	accessor, err := ctxprofile.PathGetSetter[*testContext](&pathtest.Path[*testContext]{N: "sample_type"})
+	require.NoError(t, err)
+
+	err = accessor.Set(context.Background(), newTestContext(createProfile()), []any{"cpu"})
+	assert.EqualError(t, err, "sample type must be a map, got Str")
+
+	err = accessor.Set(context.Background(), newTestContext(createProfile()), []any{map[string]any{"aggregation_temporality": "delta"}})
+	assert.EqualError(t, err, "aggregation_temporality must be an int, got Str")
+}
+
+func createProfile() pprofile.Profile {
+	profile := pprofile.NewProfile()
+	profile.SetTime(pcommon.NewTimestampFromTime(time1))
+	profile.SetDuration(pcommon.Timestamp(time.Second))
+	profile.SetDroppedAttributesCount(10)
+	profile.SetOriginalPayloadFormat("pprofext")
+	profile.SetProfileID(profileID1)
+	profile.StringTable().Append("", "samples", "count")
+
+	sampleType := profile.SampleType().AppendEmpty()
+	sampleType.SetTypeStrindex(1)
+	sampleType.SetUnitStrindex(2)
+	sampleType.SetAggregationTemporality(pprofile.AggregationTemporalityDelta)
+
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "str", pcommon.NewValueStr("val"))
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "int", pcommon.NewValueInt(10))
+	return profile
+}
+
+type testContext struct {
+	profile    pprofile.Profile
+	attributes *pcommon.Map
+}
+
+func (p *testContext) GetProfile() pprofile.Profile {
+	return p.profile
+}
+
+func (p *testContext) GetProfileAttributes() pcommon.Map {
+	if p.attributes == nil {
+		attrs := pprofile.FromAttributeIndices(p.profile.AttributeTable(), p.profile)
+		p.attributes = &attrs
+	}
+	return *p.attributes
+}
+
+func newTestContext(profile pprofile.Profile) *testContext {
+	return &testContext{profile: profile}
+}
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+var SymbolTable = map[ottl.EnumSymbol]ottl.Enum{
+	"AGGREGATION_TEMPORALITY_UNSPECIFIED": ottl.Enum(pprofile.AggregationTemporalityUnspecified),
+	"AGGREGATION_TEMPORALITY_DELTA":       ottl.Enum(pprofile.AggregationTemporalityDelta),
+	"AGGREGATION_TEMPORALITY_CUMULATIVE":  ottl.Enum(pprofile.AggregationTemporalityCumulative),
+}
@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxspanlink_test
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxspanlink"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func TestPathGetSetter(t *testing.T) {
+	refSpanLink := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*testContext]
+		orig     any
+		newVal   any
+		modified func(spanLink ptrace.SpanLink)
+	}{
+		{
+			name: "trace_id",
+			path: &pathtest.Path[*testContext]{
+				N: "trace_id",
+			},
+			orig:   pcommon.TraceID(traceID),
+			newVal: pcommon.TraceID(traceID2),
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id",
+			path: &pathtest.Path[*testContext]{
+				N: "span_id",
+			},
+			orig:   pcommon.SpanID(spanID),
+			newVal: pcommon.SpanID(spanID2),
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "trace_id string",
+			path: &pathtest.Path[*testContext]{
+				N: "trace_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:   hex.EncodeToString(traceID[:]),
+			newVal: hex.EncodeToString(traceID2[:]),
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id string",
+			path: &pathtest.Path[*testContext]{
+				N: "span_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:   hex.EncodeToString(spanID[:]),
+			newVal: hex.EncodeToString(spanID2[:]),
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "trace_state",
+			path: &pathtest.Path[*testContext]{
+				N: "trace_state",
+			},
+			orig:   "key1=val1,key2=val2",
+			newVal: "key=newVal",
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.TraceState().FromRaw("key=newVal")
+			},
+		},
+		{
+			name: "trace_state key",
+			path: &pathtest.Path[*testContext]{
+				N: "trace_state",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("key1"),
+					},
+				},
+			},
+			orig:   "val1",
+			newVal: "newVal",
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.TraceState().FromRaw("key1=newVal,key2=val2")
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+			},
+			orig:   refSpanLink.Attributes(),
+			newVal: newAttrs,
+			modified: func(spanLink ptrace.SpanLink) {
+				newAttrs.CopyTo(spanLink.Attributes())
+			},
+		},
+		{
+			name: "attributes raw map",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+			},
+			orig:   refSpanLink.Attributes(),
+			newVal: newAttrs.AsRaw(),
+			modified: func(spanLink ptrace.SpanLink) {
+				_ = spanLink.Attributes().FromRaw(newAttrs.AsRaw())
+			},
+		},
+		{
+			name: "attributes string",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.Attributes().PutStr("str", "newVal")
+			},
+		},
+		{
+			name: "attributes int",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("int"),
+					},
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.Attributes().PutInt("int", 20)
+			},
+		},
+		{
+			name: "attributes nested",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("slice"),
+					},
+					&pathtest.Key[*testContext]{
+						I: ottltest.Intp(0),
+					},
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("map"),
+					},
+				},
+			},
+			orig:   "pass",
+			newVal: "new",
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.Attributes().PutEmptySlice("slice").AppendEmpty().SetEmptyMap().PutStr("map", "new")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &pathtest.Path[*testContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanLink ptrace.SpanLink) {
+				spanLink.SetDroppedAttributesCount(20)
+			},
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[*testContext])
+		pathWithContext.C = ctxspanlink.Name
+		testWithContext.path = ottl.Path[*testContext](&pathWithContext)
+		tests = append(tests, testWithContext)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxspanlink.PathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			spanLink := createTelemetry()
+
+			tCtx := newTestContext(spanLink)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exSpanLink := createTelemetry()
+			tt.modified(exSpanLink)
+			assert.Equal(t, exSpanLink, spanLink)
+		})
+	}
+}
+
+func TestPathGetSetterInvalidPath(t *testing.T) {
+	_, err := ctxspanlink.PathGetSetter[*testContext](&pathtest.Path[*testContext]{
+		N: "trace_id",
+		NextPath: &pathtest.Path[*testContext]{
+			N: "bytes",
+		},
+	})
+	assert.Error(t, err)
+
+	_, err = ctxspanlink.PathGetSetter[*testContext](&pathtest.Path[*testContext]{N: "name"})
+	assert.Error(t, err)
+}
+
+func createTelemetry() ptrace.SpanLink {
+	spanLink := ptrace.NewSpan().Links().AppendEmpty()
+
+	spanLink.SetTraceID(traceID)
+	spanLink.SetSpanID(spanID)
+	spanLink.TraceState().FromRaw("key1=val1,key2=val2")
+	spanLink.SetDroppedAttributesCount(10)
+
+	spanLink.Attributes().PutStr("str", "val")
+	spanLink.Attributes().PutInt("int", 10)
+
+	s := spanLink.Attributes().PutEmptySlice("slice")
+	s.AppendEmpty().SetEmptyMap().PutStr("map", "pass")
+
+	return spanLink
+}
+
+type testContext struct {
+	spanLink ptrace.SpanLink
+}
+
+func (l *testContext) GetSpanLink() ptrace.SpanLink {
+	return l.spanLink
+}
+
+func newTestContext(spanLink ptrace.SpanLink) *testContext {
+	return &testContext{spanLink: spanLink}
+}
@@ -0,0 +1,438 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlprofile
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	profileID  = pprofile.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	profileID2 = pprofile.ProfileID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refProfile, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.Profile, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map)
+	}{
+		{
+			name: "time",
+			path: &pathtest.Path[TransformContext]{
+				N: "time",
+			},
+			orig:   time.Date(1970, 1, 1, 0, 0, 0, 100000000, time.UTC),
+			newVal: time.Date(1970, 1, 1, 0, 0, 0, 200000000, time.UTC),
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "time_unix_nano",
+			path: &pathtest.Path[TransformContext]{
+				N: "time_unix_nano",
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "duration",
+			path: &pathtest.Path[TransformContext]{
+				N: "duration",
+			},
+			orig:   10 * time.Second,
+			newVal: 20 * time.Second,
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetDuration(pcommon.Timestamp(20 * time.Second))
+			},
+		},
+		{
+			name: "duration_unix_nano",
+			path: &pathtest.Path[TransformContext]{
+				N: "duration_unix_nano",
+			},
+			orig:   int64(10 * time.Second),
+			newVal: int64(20 * time.Second),
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetDuration(pcommon.Timestamp(20 * time.Second))
+			},
+		},
+		{
+			name: "original_payload_format",
+			path: &pathtest.Path[TransformContext]{
+				N: "original_payload_format",
+			},
+			orig:   "pprofext",
+			newVal: "jfr",
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetOriginalPayloadFormat("jfr")
+			},
+		},
+		{
+			name: "profile_id",
+			path: &pathtest.Path[TransformContext]{
+				N: "profile_id",
+			},
+			orig:   profileID,
+			newVal: profileID2,
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetProfileID(profileID2)
+			},
+		},
+		{
+			name: "profile_id string",
+			path: &pathtest.Path[TransformContext]{
+				N: "profile_id",
+				NextPath: &pathtest.Path[TransformContext]{
+					N: "string",
+				},
+			},
+			orig:   hex.EncodeToString(profileID[:]),
+			newVal: hex.EncodeToString(profileID2[:]),
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetProfileID(profileID2)
+			},
+		},
+		{
+			name: "cache",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(_ pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[TransformContext]{
+				N: "attributes",
+			},
+			orig:   pprofile.FromAttributeIndices(refProfile.AttributeTable(), refProfile),
+			newVal: newAttrs,
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.AttributeIndices().FromRaw(nil)
+				assert.NoError(t, pprofile.AddAttribute(profile.AttributeTable(), profile, "hello", pcommon.NewValueStr("world")))
+			},
+		},
+		{
+			name: "attributes string",
+			path: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.AttributeIndices().FromRaw(nil)
+				assert.NoError(t, pprofile.AddAttribute(profile.AttributeTable(), profile, "str", pcommon.NewValueStr("newVal")))
+				assert.NoError(t, pprofile.AddAttribute(profile.AttributeTable(), profile, "int", pcommon.NewValueInt(10)))
+			},
+		},
+		{
+			name: "attributes int",
+			path: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("int"),
+					},
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.AttributeIndices().FromRaw(nil)
+				assert.NoError(t, pprofile.AddAttribute(profile.AttributeTable(), profile, "str", pcommon.NewValueStr("val")))
+				assert.NoError(t, pprofile.AddAttribute(profile.AttributeTable(), profile, "int", pcommon.NewValueInt(20)))
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &pathtest.Path[TransformContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.Profile, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetDroppedAttributesCount(20)
+			},
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[TransformContext])
+		pathWithContext.C = ctxprofile.Name
+		testWithContext.path = &pathWithContext
+		tests = append(tests, testWithContext)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache := pcommon.NewMap()
+			cacheGetter := func(_ TransformContext) pcommon.Map {
+				return testCache
+			}
+			pep := pathExpressionParser{
+				cacheGetSetter: ctxcache.PathExpressionParser(cacheGetter),
+			}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			profile, il, resource := createTelemetry()
+
+			tCtx := NewTransformContext(profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles(), WithCache(&testCache))
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			tCtx = NewTransformContext(profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles(), WithCache(&testCache))
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exProfile, exIl, exRes := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exProfile, exIl, exRes, exCache)
+
+			assert.Equal(t, exProfile, profile)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+			assert.Equal(t, exCache, testCache)
+		})
+	}
+}
+
+func Test_newPathGetSetter_higherContextPath(t *testing.T) {
+	profile, instrumentationScope, resource := createTelemetry()
+	ctx := NewTransformContext(profile, instrumentationScope, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles())
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		expected any
+	}{
+		{
+			name: "resource",
+			path: &pathtest.Path[TransformContext]{C: "", N: "resource", NextPath: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			}},
+			expected: "val",
+		},
+		{
+			name: "resource with context",
+			path: &pathtest.Path[TransformContext]{C: "resource", N: "attributes", KeySlice: []ottl.Key[TransformContext]{
+				&pathtest.Key[TransformContext]{
+					S: ottltest.Strp("str"),
+				},
+			}},
+			expected: "val",
+		},
+		{
+			name:     "instrumentation_scope",
+			path:     &pathtest.Path[TransformContext]{N: "instrumentation_scope", NextPath: &pathtest.Path[TransformContext]{N: "name"}},
+			expected: instrumentationScope.Name(),
+		},
+		{
+			name:     "instrumentation_scope with context",
+			path:     &pathtest.Path[TransformContext]{C: "instrumentation_scope", N: "name"},
+			expected: instrumentationScope.Name(),
+		},
+	}
+
+	pep := pathExpressionParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := pep.parsePath(tt.path)
+			require.NoError(t, err)
+
+			got, err := accessor.Get(context.Background(), ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_newPathGetSetter_WithCache(t *testing.T) {
+	cacheValue := pcommon.NewMap()
+	cacheValue.PutStr("test", "pass")
+
+	tCtx := NewTransformContext(
+		pprofile.NewProfile(),
+		pcommon.NewInstrumentationScope(),
+		pcommon.NewResource(),
+		pprofile.NewScopeProfiles(),
+		pprofile.NewResourceProfiles(),
+		WithCache(&cacheValue),
+	)
+
+	assert.Equal(t, cacheValue, getCache(tCtx))
+}
+
+func Test_WriteAttributes(t *testing.T) {
+	profile, il, resource := createTelemetry()
+	tCtx := NewTransformContext(profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles())
+
+	require.NoError(t, tCtx.WriteAttributes())
+	assert.Equal(t, 2, profile.AttributeIndices().Len())
+
+	pep := &pathExpressionParser{}
+	accessor, err := pep.parsePath(&pathtest.Path[TransformContext]{N: "attributes"})
+	require.NoError(t, err)
+	got, err := accessor.Get(context.Background(), tCtx)
+	require.NoError(t, err)
+	attrs, ok := got.(pcommon.Map)
+	require.True(t, ok)
+
+	// Editors modify the map in place, and the next accesses must see the modification
+	// even before it is written back.
+	attrs.Remove("str")
+	attrs.PutStr("new", "value")
+	got, err = accessor.Get(context.Background(), tCtx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"int": int64(10), "new": "value"}, got.(pcommon.Map).AsRaw())
+
+	require.NoError(t, tCtx.WriteAttributes())
+	assert.Equal(t, map[string]any{"int": int64(10), "new": "value"}, pprofile.FromAttributeIndices(profile.AttributeTable(), profile).AsRaw())
+}
+
+func Test_ParseConditions(t *testing.T) {
+	parser, err := NewParser(ottl.CreateFactoryMap[TransformContext](), componenttest.NewNopTelemetrySettings(), EnablePathContextNames())
+	require.NoError(t, err)
+
+	_, err = parser.ParseConditions([]string{`profile.original_payload_format == "jfr" and resource.attributes["str"] == "val"`})
+	assert.NoError(t, err)
+
+	_, err = parser.ParseConditions([]string{`original_payload_format == "jfr"`})
+	assert.Error(t, err)
+}
+
+func createTelemetry() (pprofile.Profile, pcommon.InstrumentationScope, pcommon.Resource) {
+	profile := pprofile.NewProfile()
+	profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	profile.SetDuration(pcommon.Timestamp(10 * time.Second))
+	profile.SetOriginalPayloadFormat("pprofext")
+	profile.SetProfileID(profileID)
+	profile.SetDroppedAttributesCount(10)
+
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "str", pcommon.NewValueStr("val"))
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "int", pcommon.NewValueInt(10))
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("str", "val")
+
+	return profile, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "AGGREGATION_TEMPORALITY_UNSPECIFIED",
+			want: ottl.Enum(pprofile.AggregationTemporalityUnspecified),
+		},
+		{
+			name: "AGGREGATION_TEMPORALITY_DELTA",
+			want: ottl.Enum(pprofile.AggregationTemporalityDelta),
+		},
+		{
+			name: "AGGREGATION_TEMPORALITY_CUMULATIVE",
+			want: ottl.Enum(pprofile.AggregationTemporalityCumulative),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	tests := []struct {
+		name       string
+		enumSymbol *ottl.EnumSymbol
+	}{
+		{
+			name:       "unknown enum symbol",
+			enumSymbol: (*ottl.EnumSymbol)(ottltest.Strp("not an enum")),
+		},
+		{
+			name:       "nil enum symbol",
+			enumSymbol: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum(tt.enumSymbol)
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
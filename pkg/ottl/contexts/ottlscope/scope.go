@@ -23,6 +23,10 @@ import (
 // Experimental: *NOTE* this constant is subject to change or removal in the future.
 const ContextName = ctxscope.Name
 
+// LegacyContextName is the former name of the scope context, still accepted in paths.
+// Experimental: *NOTE* this constant is subject to change or removal in the future.
+const LegacyContextName = ctxscope.LegacyName
+
 var (
 	_ ctxresource.Context     = (*TransformContext)(nil)
 	_ ctxscope.Context        = (*TransformContext)(nil)
@@ -135,6 +139,12 @@ func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceO
 	}
 }
 
+func WithStatementSequenceErrorHandler(handler ottl.StatementErrorHandler[TransformContext]) StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceErrorHandler[TransformContext](handler)(s)
+	}
+}
+
 func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
 	s := ottl.NewStatementSequence(statements, telemetrySettings)
 	for _, op := range options {
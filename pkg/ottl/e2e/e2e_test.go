@@ -492,6 +492,12 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "A:B")
 			},
 		},
+		{
+			statement: `set(attributes["test"], CommunityID("128.232.110.120", "66.35.250.204", 34855, 80, 6))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "1:LQU9qZlK+B5F3KDmev6m5PMibrg=")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ConvertCase(attributes["http.method"], "upper"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -721,6 +727,30 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("10.1.2.3", ["192.168.0.0/16", "10.0.0.0/8"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsPrivateIP("::ffff:192.168.1.1")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], IPVersion("2001:db8::1"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 6)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseIP("::ffff:10.0.0.1"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "10.0.0.1")
+			},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where IsString("")`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -739,6 +769,72 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutDouble("test", 0)
 			},
 		},
+		{
+			statement: `set(attributes["test"], Abs(-5))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Avg([1, 2, 4.5]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 2.5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Ceil(1.2))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 2)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Floor(1.8))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 1)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Max([attributes["things"][0]["value"], attributes["things"][1]["value"]]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Min([3, 1.5]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 1.5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Percentile([1, 2, 3, 4], 50))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 2.5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Pow(2, 3))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 8)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Round(3.14159, 2))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 3.14)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Sqrt(16))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 4)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Sum([attributes["int_value"], 1, 2]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 3)
+			},
+		},
 		{
 			statement: `set(attributes["test"], MD5("pass"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -820,6 +916,50 @@ func Test_e2e_converters(t *testing.T) {
 				m.AppendEmpty().SetStr("value2")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ParseLogfmt("k1=v1 k2=\"v 2\" debug"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("k1", "v1")
+				m.PutStr("k2", "v 2")
+				m.PutBool("debug", true)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseYAML("id: 1\ntags: [a, b]"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("id", 1)
+				s := m.PutEmptySlice("tags")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ToJSON({"b": 2, "a": [true, "x"]}))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", `{"a":[true,"x"],"b":2}`)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ToLogfmt({"k2": "v 2"}))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", `k2="v 2"`)
+			},
+		},
+		{
+			// map literals have no defined order, so the order of the entries is checked with an attribute.
+			statement: `set(attributes["test"], ToLogfmt(attributes["things"][0]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "name=foo value=2")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ToYAML({"b": 2, "a": "x"}))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "a: x\nb: 2\n")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ParseKeyValue("k1=v1 k2=v2"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -1311,6 +1451,22 @@ func Test_e2e_ottl_features(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("my.environment.2", "ost")
 			},
 		},
+		{
+			name:      "loop over map entries",
+			statement: `for each k, v in attributes["foo"]: set(attributes[Concat(["foo", k], ".")], v) where IsString(v)`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("foo.bar", "pass")
+				tCtx.GetLogRecord().Attributes().PutStr("foo.flags", "pass")
+			},
+		},
+		{
+			name:      "loop over list elements",
+			statement: `for each i, v in attributes["things"]: set(attributes["things"][i]["name"], ToUpperCase(v["name"])) where v["value"] > 3`,
+			want: func(tCtx ottllog.TransformContext) {
+				things, _ := tCtx.GetLogRecord().Attributes().Get("things")
+				things.Slice().At(1).Map().PutStr("name", "BAR")
+			},
+		},
 	}
 
 	for _, tt := range tests {
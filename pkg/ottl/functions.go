@@ -227,7 +227,7 @@ func (p *Parser[K]) newKeys(keys []key) ([]Key[K], error) {
 		var getter Getter[K]
 		if keys[i].Expression != nil {
 			if keys[i].Expression.Path != nil {
-				g, err := p.buildGetSetterFromPath(keys[i].Expression.Path)
+				g, err := p.newPathGetter(keys[i].Expression.Path)
 				if err != nil {
 					return nil, err
 				}
@@ -526,6 +526,9 @@ func (p *Parser[K]) buildSliceArg(argVal value, argType reflect.Type) (any, erro
 }
 
 func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
+	if _, ok, _ := p.newLoopVariableGetter(path); ok {
+		return nil, fmt.Errorf("loop variable %q cannot be set, use the path of the collection indexed by the loop key instead", buildOriginalText(path))
+	}
 	np, err := p.newPath(path)
 	if err != nil {
 		return nil, err
@@ -19,12 +19,14 @@ require (
 	go.opentelemetry.io/collector/component v1.27.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/component/componenttest v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/pdata v1.27.1-0.20250313100724-0885401136ff
+	go.opentelemetry.io/collector/pdata/pprofile v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/semconv v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/otel/trace v1.35.0
 	go.uber.org/goleak v1.3.0
 	go.uber.org/zap v1.27.0
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842
 	golang.org/x/net v0.37.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -54,7 +56,6 @@ require (
 	google.golang.org/grpc v1.71.0 // indirect
 	google.golang.org/protobuf v1.36.5 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal => ../../internal/coreinternal
@@ -13,6 +13,7 @@ import (
 
 // parsedStatement represents a parsed statement. It is the entry point into the statement DSL.
 type parsedStatement struct {
+	Loop   *loop  `parser:"( @@ )?"`
 	Editor editor `parser:"(@@"`
 	// If converter is matched then return error
 	Converter   *converter         `parser:"|@@)"`
@@ -25,6 +26,9 @@ func (p *parsedStatement) checkForCustomError() error {
 		validator.add(fmt.Errorf("editor names must start with a lowercase letter but got '%v'", p.Converter.Function))
 	}
 
+	if p.Loop != nil {
+		p.Loop.checkForCustomError(validator)
+	}
 	p.Editor.accept(validator)
 	if p.WhereClause != nil {
 		p.WhereClause.accept(validator)
@@ -33,6 +37,48 @@ func (p *parsedStatement) checkForCustomError() error {
 	return validator.join()
 }
 
+// loop represents the optional `for each` clause of a statement, which executes the statement once
+// per entry of a map or element of a list. With a single variable, it holds the map value or list
+// element; with two variables, the first one holds the map key or list index.
+type loop struct {
+	Variables  []string `parser:"'for' 'each' @Lowercase ( ',' @Lowercase )?"`
+	Collection value    `parser:"'in' @@ ':'"`
+}
+
+func (l *loop) checkForCustomError(validator *grammarCustomErrorsVisitor) {
+	if len(l.Variables) == 2 && l.Variables[0] == l.Variables[1] {
+		validator.add(fmt.Errorf("loop variables must have different names but got '%v' twice", l.Variables[0]))
+	}
+	l.Collection.accept(validator)
+}
+
+// isVariable returns true if the path refers to one of the loop variables.
+func (l *loop) isVariable(p *path) bool {
+	if l == nil || p.Context != "" || len(p.Fields) != 1 {
+		return false
+	}
+	for _, v := range l.Variables {
+		if p.Fields[0].Name == v {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutVariables returns the given paths excluding the ones referring to the loop variables.
+func (l *loop) withoutVariables(paths []path) []path {
+	if l == nil {
+		return paths
+	}
+	result := paths[:0]
+	for i := range paths {
+		if !l.isVariable(&paths[i]) {
+			result = append(result, paths[i])
+		}
+	}
+	return result
+}
+
 type constExpr struct {
 	Boolean   *boolean   `parser:"( @Boolean"`
 	Converter *converter `parser:"| @@ )"`
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+// DefaultMaxLoopIterations is the maximum number of entries a statement can iterate over
+// unless another limit is set with WithMaxLoopIterations.
+const DefaultMaxLoopIterations = 1000
+
+// WithMaxLoopIterations sets the maximum number of map entries or list elements a `for each`
+// statement can iterate over. Executing the statement on a bigger collection fails without
+// executing the statement for any entry.
+func WithMaxLoopIterations[K any](maxIterations int) Option[K] {
+	return func(p *Parser[K]) {
+		p.maxLoopIterations = maxIterations
+	}
+}
+
+// loopEntryKey is the context.Context key of the loopEntry being iterated over.
+type loopEntryKey struct{}
+
+// loopEntry holds the values of the loop variables for the current iteration.
+type loopEntry struct {
+	key   any
+	value any
+}
+
+// loopExpr iterates over the map or list returned by the collection Getter.
+type loopExpr[K any] struct {
+	collection    Getter[K]
+	maxIterations int
+}
+
+// forEach calls fn once per entry of the collection, with a context holding the entry.
+// The keys of maps and the length of lists are read before iterating, so fn may modify
+// the collection: entries removed by fn are skipped and entries added by fn are not
+// iterated over.
+func (l *loopExpr[K]) forEach(ctx context.Context, tCtx K, fn func(ctx context.Context) error) error {
+	val, err := l.collection.Get(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	entry := &loopEntry{}
+	ctx = context.WithValue(ctx, loopEntryKey{}, entry)
+
+	switch c := val.(type) {
+	case nil:
+		return nil
+	case pcommon.Map:
+		if err = l.checkLen(c.Len()); err != nil {
+			return err
+		}
+		keys := make([]string, 0, c.Len())
+		c.Range(func(k string, _ pcommon.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		for _, k := range keys {
+			v, ok := c.Get(k)
+			if !ok {
+				continue
+			}
+			entry.key, entry.value = k, ottlcommon.GetValue(v)
+			if err = fn(ctx); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		if err = l.checkLen(len(c)); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(c))
+		for k := range c {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			entry.key, entry.value = k, c[k]
+			if err = fn(ctx); err != nil {
+				return err
+			}
+		}
+	case pcommon.Slice:
+		n := c.Len()
+		if err = l.checkLen(n); err != nil {
+			return err
+		}
+		for i := 0; i < n && i < c.Len(); i++ {
+			entry.key, entry.value = int64(i), ottlcommon.GetValue(c.At(i))
+			if err = fn(ctx); err != nil {
+				return err
+			}
+		}
+	case []byte:
+		return TypeError("cannot iterate over a byte slice")
+	default:
+		rv := reflect.ValueOf(val)
+		if rv.Kind() != reflect.Slice {
+			return TypeError(fmt.Sprintf("cannot iterate over %T, only maps and lists are supported", val))
+		}
+		if err = l.checkLen(rv.Len()); err != nil {
+			return err
+		}
+		for i := 0; i < rv.Len(); i++ {
+			entry.key, entry.value = int64(i), rv.Index(i).Interface()
+			if err = fn(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (l *loopExpr[K]) checkLen(n int) error {
+	if n > l.maxIterations {
+		return fmt.Errorf("cannot iterate over %d entries, the maximum is %d", n, l.maxIterations)
+	}
+	return nil
+}
+
+// loopVariable is the Getter of a loop variable, holding either the key or the value of
+// the entry being iterated over.
+type loopVariable[K any] struct {
+	name  string
+	isKey bool
+}
+
+func (v loopVariable[K]) Get(ctx context.Context, _ K) (any, error) {
+	entry, ok := ctx.Value(loopEntryKey{}).(*loopEntry)
+	if !ok {
+		return nil, fmt.Errorf("loop variable %q used outside of a loop", v.name)
+	}
+	if v.isKey {
+		return entry.key, nil
+	}
+	return entry.value, nil
+}
+
+func (p *Parser[K]) newLoop(l *loop) (*loopExpr[K], error) {
+	collection, err := p.newGetter(l.Collection)
+	if err != nil {
+		return nil, err
+	}
+	maxIterations := p.maxLoopIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxLoopIterations
+	}
+	return &loopExpr[K]{
+		collection:    collection,
+		maxIterations: maxIterations,
+	}, nil
+}
+
+// withLoopVariables returns a copy of the Parser resolving the given loop variable names
+// to the entry being iterated over instead of to a path.
+func (p *Parser[K]) withLoopVariables(l *loop) *Parser[K] {
+	lp := *p
+	lp.loopVariables = make(map[string]bool, len(l.Variables))
+	for i, name := range l.Variables {
+		lp.loopVariables[name] = len(l.Variables) == 2 && i == 0
+	}
+	return &lp
+}
+
+// newLoopVariableGetter returns the Getter of the loop variable the path refers to, if any.
+func (p *Parser[K]) newLoopVariableGetter(path *path) (Getter[K], bool, error) {
+	if p.loopVariables == nil || path.Context != "" || len(path.Fields) != 1 {
+		return nil, false, nil
+	}
+	f := path.Fields[0]
+	isKey, ok := p.loopVariables[f.Name]
+	if !ok {
+		return nil, false, nil
+	}
+	variable := loopVariable[K]{name: f.Name, isKey: isKey}
+	if len(f.Keys) == 0 {
+		return variable, true, nil
+	}
+	for _, k := range f.Keys {
+		if k.String == nil && k.Int == nil {
+			return nil, true, fmt.Errorf("loop variable %q can only be indexed with string and int literals", f.Name)
+		}
+	}
+	return &exprGetter[K]{
+		expr: Expr[K]{exprFunc: variable.Get},
+		keys: f.Keys,
+	}, true, nil
+}
+
+// newPathGetter returns the Getter of a path, which can refer to a loop variable.
+func (p *Parser[K]) newPathGetter(path *path) (Getter[K], error) {
+	g, ok, err := p.newLoopVariableGetter(path)
+	if ok {
+		return g, err
+	}
+	return p.buildGetSetterFromPath(path)
+}
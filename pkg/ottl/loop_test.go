@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_loopExpr_forEach(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("b", "1")
+	m.PutInt("a", 2)
+	s := pcommon.NewSlice()
+	s.AppendEmpty().SetStr("x")
+	s.AppendEmpty().SetBool(true)
+
+	tests := []struct {
+		name          string
+		collection    any
+		maxIterations int
+		expected      [][2]any
+		wantErr       string
+	}{
+		{
+			name:       "pcommon.Map",
+			collection: m,
+			expected:   [][2]any{{"b", "1"}, {"a", int64(2)}},
+		},
+		{
+			name:       "raw map sorted by key",
+			collection: map[string]any{"b": "1", "a": int64(2)},
+			expected:   [][2]any{{"a", int64(2)}, {"b", "1"}},
+		},
+		{
+			name:       "pcommon.Slice",
+			collection: s,
+			expected:   [][2]any{{int64(0), "x"}, {int64(1), true}},
+		},
+		{
+			name:       "typed slice",
+			collection: []string{"x", "y"},
+			expected:   [][2]any{{int64(0), "x"}, {int64(1), "y"}},
+		},
+		{
+			name:       "nil",
+			collection: nil,
+		},
+		{
+			name:          "too many entries",
+			collection:    []int64{1, 2, 3},
+			maxIterations: 2,
+			wantErr:       "cannot iterate over 3 entries, the maximum is 2",
+		},
+		{
+			name:       "bytes",
+			collection: []byte{1},
+			wantErr:    "cannot iterate over a byte slice",
+		},
+		{
+			name:       "string",
+			collection: "foo",
+			wantErr:    "cannot iterate over string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &loopExpr[any]{
+				collection:    literal[any]{value: tt.collection},
+				maxIterations: DefaultMaxLoopIterations,
+			}
+			if tt.maxIterations > 0 {
+				l.maxIterations = tt.maxIterations
+			}
+			var got [][2]any
+			err := l.forEach(context.Background(), nil, func(ctx context.Context) error {
+				key, err := loopVariable[any]{isKey: true}.Get(ctx, nil)
+				require.NoError(t, err)
+				val, err := loopVariable[any]{}.Get(ctx, nil)
+				require.NoError(t, err)
+				got = append(got, [2]any{key, val})
+				return nil
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Empty(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_loopExpr_forEach_modifiedCollection(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("a", "1")
+	m.PutStr("b", "2")
+	l := &loopExpr[any]{
+		collection:    literal[any]{value: m},
+		maxIterations: DefaultMaxLoopIterations,
+	}
+	var keys []any
+	err := l.forEach(context.Background(), nil, func(ctx context.Context) error {
+		key, err := loopVariable[any]{isKey: true}.Get(ctx, nil)
+		require.NoError(t, err)
+		keys = append(keys, key)
+		m.Remove("b")
+		m.PutStr("c", "3")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"a"}, keys)
+}
+
+type recordArguments[K any] struct {
+	Key   Getter[K]
+	Value Getter[K]
+}
+
+type setTargetArguments[K any] struct {
+	Target Setter[K]
+}
+
+func newLoopTestParser(t *testing.T, recorded *[]string, options ...Option[any]) Parser[any] {
+	functions := CreateFactoryMap(
+		NewFactory("record", &recordArguments[any]{}, func(_ FunctionContext, args Arguments) (ExprFunc[any], error) {
+			a := args.(*recordArguments[any])
+			return func(ctx context.Context, tCtx any) (any, error) {
+				k, err := a.Key.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				v, err := a.Value.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				switch typed := v.(type) {
+				case pcommon.Map:
+					v = typed.AsRaw()
+				case pcommon.Slice:
+					v = typed.AsRaw()
+				}
+				*recorded = append(*recorded, fmt.Sprintf("%v=%v", k, v))
+				return v, nil
+			}, nil
+		}),
+		NewFactory("set_target", &setTargetArguments[any]{}, func(FunctionContext, Arguments) (ExprFunc[any], error) {
+			return func(context.Context, any) (any, error) {
+				return nil, nil
+			}, nil
+		}),
+	)
+	p, err := NewParser(
+		functions,
+		func(path Path[any]) (GetSetter[any], error) {
+			if path.Name() != "attributes" {
+				return nil, fmt.Errorf("bad path %v", path)
+			}
+			keys := path.Keys()
+			return &StandardGetSetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (any, error) {
+					if keys == nil {
+						return tCtx, nil
+					}
+					key, err := keys[0].String(ctx, tCtx)
+					if err != nil {
+						return nil, err
+					}
+					if key == nil {
+						g, err := keys[0].ExpressionGetter(ctx, tCtx)
+						if err != nil {
+							return nil, err
+						}
+						k, err := g.Get(ctx, tCtx)
+						if err != nil {
+							return nil, err
+						}
+						key = ottltest.Strp(k.(string))
+					}
+					val, ok := tCtx.(pcommon.Map).Get(*key)
+					if !ok {
+						return nil, nil
+					}
+					return ottlcommon.GetValue(val), nil
+				},
+				Setter: func(context.Context, any, any) error {
+					return nil
+				},
+			}, nil
+		},
+		componenttest.NewNopTelemetrySettings(),
+		options...,
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_Statement_Execute_loop(t *testing.T) {
+	tests := []struct {
+		name              string
+		statement         string
+		expectedRecorded  []string
+		expectedCondition bool
+		expectedResult    any
+	}{
+		{
+			name:              "key and value",
+			statement:         `for each k, v in attributes: record(k, v)`,
+			expectedRecorded:  []string{"http=map[method:get]", "name=foo", "tags=[a b]"},
+			expectedCondition: true,
+			expectedResult:    []any{"a", "b"},
+		},
+		{
+			name:              "value only",
+			statement:         `for each v in attributes["tags"]: record("tag", v)`,
+			expectedRecorded:  []string{"tag=a", "tag=b"},
+			expectedCondition: true,
+			expectedResult:    "b",
+		},
+		{
+			name:              "index",
+			statement:         `for each i, v in attributes["tags"]: record(i, v)`,
+			expectedRecorded:  []string{"0=a", "1=b"},
+			expectedCondition: true,
+			expectedResult:    "b",
+		},
+		{
+			name:              "where clause",
+			statement:         `for each k, v in attributes: record(k, v) where k == "name" or v == "a"`,
+			expectedRecorded:  []string{"name=foo"},
+			expectedCondition: true,
+			expectedResult:    "foo",
+		},
+		{
+			name:              "no match",
+			statement:         `for each k, v in attributes: record(k, v) where k == "unknown"`,
+			expectedCondition: false,
+		},
+		{
+			name:              "indexed loop variable",
+			statement:         `for each k, v in attributes: record(k, v["method"]) where k == "http"`,
+			expectedRecorded:  []string{"http=get"},
+			expectedCondition: true,
+			expectedResult:    "get",
+		},
+		{
+			name:              "loop variable as key",
+			statement:         `for each k in ["name"]: record(k, attributes[k])`,
+			expectedRecorded:  []string{"name=foo"},
+			expectedCondition: true,
+			expectedResult:    "foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorded []string
+			p := newLoopTestParser(t, &recorded)
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMap()
+			attributes.PutEmptyMap("http").PutStr("method", "get")
+			attributes.PutStr("name", "foo")
+			require.NoError(t, attributes.PutEmptySlice("tags").FromRaw([]any{"a", "b"}))
+			result, condition, err := statement.Execute(context.Background(), attributes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRecorded, recorded)
+			assert.Equal(t, tt.expectedCondition, condition)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func Test_ParseStatement_loop_error(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "setting a loop variable",
+			statement: `for each k, v in attributes: set_target(v)`,
+			wantErr:   `loop variable "v" cannot be set`,
+		},
+		{
+			name:      "indexing a loop variable with an expression",
+			statement: `for each k, v in attributes: record(k, v[k])`,
+			wantErr:   `loop variable "v" can only be indexed with string and int literals`,
+		},
+		{
+			name:      "loop variable in the collection",
+			statement: `for each k, v in v: record(k, v)`,
+			wantErr:   "bad path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newLoopTestParser(t, nil)
+			_, err := p.ParseStatement(tt.statement)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Statement_Execute_loop_maxIterations(t *testing.T) {
+	var recorded []string
+	p := newLoopTestParser(t, &recorded, WithMaxLoopIterations[any](2))
+	statement, err := p.ParseStatement(`for each k, v in attributes: record(k, v)`)
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("a", "1")
+	attributes.PutStr("b", "2")
+	_, condition, err := statement.Execute(context.Background(), attributes)
+	require.NoError(t, err)
+	assert.True(t, condition)
+	assert.Equal(t, []string{"a=1", "b=2"}, recorded)
+
+	attributes.PutStr("c", "3")
+	recorded = nil
+	_, condition, err = statement.Execute(context.Background(), attributes)
+	assert.ErrorContains(t, err, "cannot iterate over 3 entries, the maximum is 2")
+	assert.False(t, condition)
+	assert.Empty(t, recorded)
+}
@@ -53,6 +53,15 @@ func (p *Parser[K]) evaluateMathValue(val *mathValue) (Getter[K], error) {
 }
 
 func attemptMathOperation[K any](lhs Getter[K], op mathOp, rhs Getter[K]) Getter[K] {
+	// Operations between literals are evaluated once at parse time. Failing ones, such as
+	// divisions by zero, are kept so their error is returned when the statement is executed.
+	if x, ok := lhs.(*literal[K]); ok {
+		if y, ok := rhs.(*literal[K]); ok {
+			if result, err := performMathOperation(x.value, y.value, op); err == nil {
+				return &literal[K]{value: result}
+			}
+		}
+	}
 	return exprGetter[K]{
 		expr: Expr[K]{
 			exprFunc: func(ctx context.Context, tCtx K) (any, error) {
@@ -64,53 +73,57 @@ func attemptMathOperation[K any](lhs Getter[K], op mathOp, rhs Getter[K]) Getter
 				if err != nil {
 					return nil, err
 				}
-				switch newX := x.(type) {
-				case int64:
-					switch newY := y.(type) {
-					case int64:
-						result, err := performOp[int64](newX, newY, op)
-						if err != nil {
-							return nil, err
-						}
-						return result, nil
-					case float64:
-						result, err := performOp[float64](float64(newX), newY, op)
-						if err != nil {
-							return nil, err
-						}
-						return result, nil
-					default:
-						return nil, fmt.Errorf("%v must be int64 or float64", y)
-					}
-				case float64:
-					switch newY := y.(type) {
-					case int64:
-						result, err := performOp[float64](newX, float64(newY), op)
-						if err != nil {
-							return nil, err
-						}
-						return result, nil
-					case float64:
-						result, err := performOp[float64](newX, newY, op)
-						if err != nil {
-							return nil, err
-						}
-						return result, nil
-					default:
-						return nil, fmt.Errorf("%v must be int64 or float64", y)
-					}
-				case time.Time:
-					return performOpTime(newX, y, op)
-				case time.Duration:
-					return performOpDuration(newX, y, op)
-				default:
-					return nil, fmt.Errorf("%v must be int64, float64, time.Time or time.Duration", x)
-				}
+				return performMathOperation(x, y, op)
 			},
 		},
 	}
 }
 
+func performMathOperation(x any, y any, op mathOp) (any, error) {
+	switch newX := x.(type) {
+	case int64:
+		switch newY := y.(type) {
+		case int64:
+			result, err := performOp[int64](newX, newY, op)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		case float64:
+			result, err := performOp[float64](float64(newX), newY, op)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		default:
+			return nil, fmt.Errorf("%v must be int64 or float64", y)
+		}
+	case float64:
+		switch newY := y.(type) {
+		case int64:
+			result, err := performOp[float64](newX, float64(newY), op)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		case float64:
+			result, err := performOp[float64](newX, newY, op)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		default:
+			return nil, fmt.Errorf("%v must be int64 or float64", y)
+		}
+	case time.Time:
+		return performOpTime(newX, y, op)
+	case time.Duration:
+		return performOpDuration(newX, y, op)
+	default:
+		return nil, fmt.Errorf("%v must be int64, float64, time.Time or time.Duration", x)
+	}
+}
+
 func performOpTime(x time.Time, y any, op mathOp) (any, error) {
 	switch op {
 	case add:
@@ -1154,3 +1154,27 @@ func Test_evaluateMathExpressionTimeDuration(t *testing.T) {
 		assert.Equal(t, tt.expected, result)
 	}
 }
+
+func Test_evaluateMathExpression_constantFolding(t *testing.T) {
+	p, _ := NewParser[any](
+		CreateFactoryMap[any](),
+		mathParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+	)
+	mathParser := newParser[value]()
+
+	parsed, err := mathParser.ParseString("", "1 + 2 * 3")
+	require.NoError(t, err)
+	getter, err := p.evaluateMathExpression(parsed.MathExpression)
+	require.NoError(t, err)
+	assert.Equal(t, &literal[any]{value: int64(7)}, getter)
+
+	// Operations failing at parse time are not folded, so their error is returned when evaluated.
+	parsed, err = mathParser.ParseString("", "1 / 0")
+	require.NoError(t, err)
+	getter, err = p.evaluateMathExpression(parsed.MathExpression)
+	require.NoError(t, err)
+	assert.IsType(t, exprGetter[any]{}, getter)
+	_, err = getter.Get(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type AbsArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewAbsFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Abs", &AbsArguments[K]{}, createAbsFunction[K])
+}
+
+func createAbsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AbsArguments[K])
+
+	if !ok {
+		return nil, errors.New("AbsFactory args must be of type *AbsArguments[K]")
+	}
+
+	return abs(args.Target), nil
+}
+
+func abs[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := value.(pcommon.Value); ok {
+			switch v.Type() {
+			case pcommon.ValueTypeInt:
+				value = v.Int()
+			case pcommon.ValueTypeDouble:
+				value = v.Double()
+			}
+		}
+		switch v := value.(type) {
+		case int64:
+			if v == math.MinInt64 {
+				return nil, fmt.Errorf("invalid input: the absolute value of %d overflows int64", v)
+			}
+			if v < 0 {
+				return -v, nil
+			}
+			return v, nil
+		case float64:
+			return math.Abs(v), nil
+		default:
+			return nil, fmt.Errorf("unsupported type: %T, expected int64 or float64", value)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Abs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+		errorStr string
+	}{
+		{
+			name:     "negative int64",
+			value:    int64(-5),
+			expected: int64(5),
+		},
+		{
+			name:     "positive int64",
+			value:    int64(5),
+			expected: int64(5),
+		},
+		{
+			name:     "negative float64",
+			value:    -2.5,
+			expected: 2.5,
+		},
+		{
+			name:     "int value",
+			value:    pcommon.NewValueInt(-3),
+			expected: int64(3),
+		},
+		{
+			name:     "double value",
+			value:    pcommon.NewValueDouble(-3.5),
+			expected: 3.5,
+		},
+		{
+			name:     "min int64",
+			value:    int64(math.MinInt64),
+			errorStr: "overflows int64",
+		},
+		{
+			name:     "string",
+			value:    "-5",
+			errorStr: "unsupported type: string",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			errorStr: "unsupported type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := abs[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			if tt.errorStr != "" {
+				assert.ErrorContains(t, err, tt.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type AvgArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewAvgFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Avg", &AvgArguments[K]{}, createAvgFunction[K])
+}
+
+func createAvgFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AvgArguments[K])
+
+	if !ok {
+		return nil, errors.New("AvgFactory args must be of type *AvgArguments[K]")
+	}
+
+	return avg(args.Target), nil
+}
+
+func avg[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		values, err := getNumericList(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		if values.len() == 0 {
+			return nil, errors.New("cannot compute the average of an empty list")
+		}
+		var total float64
+		for _, v := range values.floats {
+			total += v
+		}
+		return total / float64(values.len()), nil
+	}
+}
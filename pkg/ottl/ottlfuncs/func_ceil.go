@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type CeilArguments[K any] struct {
+	Target ottl.FloatLikeGetter[K]
+}
+
+func NewCeilFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Ceil", &CeilArguments[K]{}, createCeilFunction[K])
+}
+
+func createCeilFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*CeilArguments[K])
+
+	if !ok {
+		return nil, errors.New("CeilFactory args must be of type *CeilArguments[K]")
+	}
+
+	return ceil(args.Target), nil
+}
+
+func ceil[K any](target ottl.FloatLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, fmt.Errorf("invalid input: %v", value)
+		}
+		return math.Ceil(*value), nil
+	}
+}
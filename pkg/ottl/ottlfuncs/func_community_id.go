@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" // #nosec
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+	protoSCTP   = 132
+)
+
+// icmpEquivalents and icmpv6Equivalents map the types of ICMP request messages to the type
+// of their replies and the other way around, as defined by the Community ID specification.
+var (
+	icmpEquivalents = map[uint16]uint16{
+		8: 0, 0: 8, // Echo
+		13: 14, 14: 13, // Timestamp
+		15: 16, 16: 15, // Information
+		10: 9, 9: 10, // Router solicitation and advertisement
+		17: 18, 18: 17, // Address mask
+	}
+	icmpv6Equivalents = map[uint16]uint16{
+		128: 129, 129: 128, // Echo
+		133: 134, 134: 133, // Router solicitation and advertisement
+		135: 136, 136: 135, // Neighbor solicitation and advertisement
+		130: 131, 131: 130, // Multicast listener query and report
+		139: 140, 140: 139, // Node information query and response
+		144: 145, 145: 144, // Home agent address discovery request and reply
+	}
+)
+
+type CommunityIDArguments[K any] struct {
+	SourceIP        ottl.StringGetter[K]
+	DestinationIP   ottl.StringGetter[K]
+	SourcePort      ottl.IntLikeGetter[K]
+	DestinationPort ottl.IntLikeGetter[K]
+	Protocol        ottl.IntLikeGetter[K]
+	Seed            ottl.Optional[int64]
+}
+
+func NewCommunityIDFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("CommunityID", &CommunityIDArguments[K]{}, createCommunityIDFunction[K])
+}
+
+func createCommunityIDFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*CommunityIDArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("CommunityIDFactory args must be of type *CommunityIDArguments[K]")
+	}
+
+	return communityID(args.SourceIP, args.DestinationIP, args.SourcePort, args.DestinationPort, args.Protocol, args.Seed)
+}
+
+func communityID[K any](sourceIP, destinationIP ottl.StringGetter[K], sourcePort, destinationPort, protocol ottl.IntLikeGetter[K], seed ottl.Optional[int64]) (ottl.ExprFunc[K], error) {
+	var seedValue int64
+	if !seed.IsEmpty() {
+		seedValue = seed.Get()
+	}
+	if seedValue < 0 || seedValue > math.MaxUint16 {
+		return nil, fmt.Errorf("the seed supplied to CommunityID must be between 0 and %d", math.MaxUint16)
+	}
+	return func(ctx context.Context, tCtx K) (any, error) {
+		src, err := getAddrBytes(ctx, tCtx, sourceIP)
+		if err != nil {
+			return nil, err
+		}
+		dst, err := getAddrBytes(ctx, tCtx, destinationIP)
+		if err != nil {
+			return nil, err
+		}
+		if len(src) != len(dst) {
+			return nil, errors.New("the source and destination IP addresses supplied to CommunityID must have the same version")
+		}
+		proto, err := getUint(ctx, tCtx, protocol, math.MaxUint8, "protocol")
+		if err != nil {
+			return nil, err
+		}
+		sport, err := getUint(ctx, tCtx, sourcePort, math.MaxUint16, "source port")
+		if err != nil {
+			return nil, err
+		}
+		dport, err := getUint(ctx, tCtx, destinationPort, math.MaxUint16, "destination port")
+		if err != nil {
+			return nil, err
+		}
+		return computeCommunityID(uint16(seedValue), src, dst, uint8(proto), uint16(sport), uint16(dport)), nil
+	}, nil
+}
+
+// computeCommunityID returns the version 1 Community ID of a flow, with the ports holding the
+// type and the code of ICMP messages.
+func computeCommunityID(seed uint16, src, dst []byte, proto uint8, sport, dport uint16) string {
+	hasPorts := true
+	oneWay := false
+	switch proto {
+	case protoICMP, protoICMPv6:
+		equivalents := icmpEquivalents
+		if proto == protoICMPv6 {
+			equivalents = icmpv6Equivalents
+		}
+		if reply, ok := equivalents[sport]; ok {
+			dport = reply
+		} else {
+			oneWay = true
+		}
+	case protoTCP, protoUDP, protoSCTP:
+	default:
+		hasPorts = false
+	}
+
+	// The flow is hashed in the same direction regardless of which endpoint sent the message.
+	if !oneWay {
+		if c := bytes.Compare(src, dst); c > 0 || (c == 0 && hasPorts && sport > dport) {
+			src, dst = dst, src
+			sport, dport = dport, sport
+		}
+	}
+
+	buf := make([]byte, 0, 2+2*len(src)+6)
+	buf = binary.BigEndian.AppendUint16(buf, seed)
+	buf = append(buf, src...)
+	buf = append(buf, dst...)
+	buf = append(buf, proto, 0)
+	if hasPorts {
+		buf = binary.BigEndian.AppendUint16(buf, sport)
+		buf = binary.BigEndian.AppendUint16(buf, dport)
+	}
+	sum := sha1.Sum(buf) // #nosec
+	return "1:" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func getAddrBytes[K any](ctx context.Context, tCtx K, getter ottl.StringGetter[K]) ([]byte, error) {
+	val, err := getter.Get(ctx, tCtx)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := parseAddr(val)
+	if err != nil {
+		return nil, err
+	}
+	return addr.AsSlice(), nil
+}
+
+func getUint[K any](ctx context.Context, tCtx K, getter ottl.IntLikeGetter[K], maxValue int64, name string) (int64, error) {
+	val, err := getter.Get(ctx, tCtx)
+	if err != nil {
+		return 0, err
+	}
+	if val == nil {
+		return 0, fmt.Errorf("the %s supplied to CommunityID must be an integer", name)
+	}
+	if *val < 0 || *val > maxValue {
+		return 0, fmt.Errorf("the %s supplied to CommunityID must be between 0 and %d but got %d", name, maxValue, *val)
+	}
+	return *val, nil
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_CommunityID(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      any
+		dst      any
+		sport    any
+		dport    any
+		proto    any
+		seed     ottl.Optional[int64]
+		expected string
+	}{
+		{
+			name:     "TCP",
+			src:      "128.232.110.120",
+			dst:      "66.35.250.204",
+			sport:    int64(34855),
+			dport:    int64(80),
+			proto:    int64(6),
+			expected: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			name:     "TCP reversed",
+			src:      "66.35.250.204",
+			dst:      "128.232.110.120",
+			sport:    int64(80),
+			dport:    int64(34855),
+			proto:    int64(6),
+			expected: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			name:     "ports and protocol as strings",
+			src:      "128.232.110.120",
+			dst:      "66.35.250.204",
+			sport:    "34855",
+			dport:    "80",
+			proto:    "6",
+			expected: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			name:     "seed",
+			src:      "128.232.110.120",
+			dst:      "66.35.250.204",
+			sport:    int64(34855),
+			dport:    int64(80),
+			proto:    int64(6),
+			seed:     ottl.NewTestingOptional[int64](1),
+			expected: "1:3V71V58M3Ksw/yuFALMcW0LAHvc=",
+		},
+		{
+			name:     "UDP",
+			src:      "192.168.1.52",
+			dst:      "8.8.8.8",
+			sport:    int64(54585),
+			dport:    int64(53),
+			proto:    int64(17),
+			expected: "1:d/FP5EW3wiY1vCndhwleRRKHowQ=",
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			src:      "::ffff:192.168.1.52",
+			dst:      "8.8.8.8",
+			sport:    int64(54585),
+			dport:    int64(53),
+			proto:    int64(17),
+			expected: "1:d/FP5EW3wiY1vCndhwleRRKHowQ=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := communityID[any](
+				getterFor(tt.src),
+				getterFor(tt.dst),
+				intLikeGetterFor(tt.sport),
+				intLikeGetterFor(tt.dport),
+				intLikeGetterFor(tt.proto),
+				tt.seed,
+			)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_CommunityID_ICMP(t *testing.T) {
+	request := computeCommunityID(0, []byte{192, 168, 0, 89}, []byte{192, 168, 0, 1}, protoICMP, 8, 0)
+	reply := computeCommunityID(0, []byte{192, 168, 0, 1}, []byte{192, 168, 0, 89}, protoICMP, 0, 0)
+	assert.Equal(t, "1:X0snYXpgwiv9TZtqg64sgzUn6Dk=", request)
+	assert.Equal(t, request, reply)
+
+	// Destination unreachable messages have no reply, so their direction matters.
+	unreachable := computeCommunityID(0, []byte{192, 168, 0, 1}, []byte{192, 168, 0, 89}, protoICMP, 3, 1)
+	reversed := computeCommunityID(0, []byte{192, 168, 0, 89}, []byte{192, 168, 0, 1}, protoICMP, 3, 1)
+	assert.NotEqual(t, unreachable, reversed)
+}
+
+func Test_CommunityID_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		src           any
+		dst           any
+		sport         any
+		expectedError string
+	}{
+		{
+			name:          "invalid address",
+			src:           "10.0.0",
+			dst:           "10.0.0.2",
+			sport:         int64(1),
+			expectedError: `invalid IP address "10.0.0"`,
+		},
+		{
+			name:          "different versions",
+			src:           "10.0.0.1",
+			dst:           "2001:db8::1",
+			sport:         int64(1),
+			expectedError: "the source and destination IP addresses supplied to CommunityID must have the same version",
+		},
+		{
+			name:          "port out of range",
+			src:           "10.0.0.1",
+			dst:           "10.0.0.2",
+			sport:         int64(65536),
+			expectedError: "the source port supplied to CommunityID must be between 0 and 65535 but got 65536",
+		},
+		{
+			name:          "missing port",
+			src:           "10.0.0.1",
+			dst:           "10.0.0.2",
+			sport:         nil,
+			expectedError: "the source port supplied to CommunityID must be an integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := communityID[any](
+				getterFor(tt.src),
+				getterFor(tt.dst),
+				intLikeGetterFor(tt.sport),
+				intLikeGetterFor(int64(80)),
+				intLikeGetterFor(int64(6)),
+				ottl.Optional[int64]{},
+			)
+			require.NoError(t, err)
+			_, err = exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+
+	_, err := communityID[any](getterFor("10.0.0.1"), getterFor("10.0.0.2"), intLikeGetterFor(int64(1)), intLikeGetterFor(int64(2)), intLikeGetterFor(int64(6)), ottl.NewTestingOptional[int64](65536))
+	assert.ErrorContains(t, err, "the seed supplied to CommunityID must be between 0 and 65535")
+}
+
+func getterFor(value any) ottl.StringGetter[any] {
+	return &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return value, nil
+		},
+	}
+}
+
+func intLikeGetterFor(value any) ottl.IntLikeGetter[any] {
+	return &ottl.StandardIntLikeGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return value, nil
+		},
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type FloorArguments[K any] struct {
+	Target ottl.FloatLikeGetter[K]
+}
+
+func NewFloorFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Floor", &FloorArguments[K]{}, createFloorFunction[K])
+}
+
+func createFloorFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FloorArguments[K])
+
+	if !ok {
+		return nil, errors.New("FloorFactory args must be of type *FloorArguments[K]")
+	}
+
+	return floor(args.Target), nil
+}
+
+func floor[K any](target ottl.FloatLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, fmt.Errorf("invalid input: %v", value)
+		}
+		return math.Floor(*value), nil
+	}
+}
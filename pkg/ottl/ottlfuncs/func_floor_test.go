@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Floor(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected float64
+	}{
+		{
+			name:     "positive float64",
+			value:    2.7,
+			expected: 2.0,
+		},
+		{
+			name:     "negative float64",
+			value:    -2.2,
+			expected: -3.0,
+		},
+		{
+			name:     "int64",
+			value:    int64(5),
+			expected: 5.0,
+		},
+		{
+			name:     "string",
+			value:    "1.5",
+			expected: 1.0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := floor[any](&ottl.StandardFloatLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Floor_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		errorStr string
+	}{
+		{
+			name:     "not a number string",
+			value:    "test",
+			errorStr: "invalid",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			errorStr: "invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := floor[any](&ottl.StandardFloatLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.ErrorContains(t, err, tt.errorStr)
+			assert.Nil(t, result)
+		})
+	}
+}
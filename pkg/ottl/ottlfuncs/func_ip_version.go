@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IPVersionArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewIPVersionFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IPVersion", &IPVersionArguments[K]{}, createIPVersionFunction[K])
+}
+
+func createIPVersionFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IPVersionArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IPVersionFactory args must be of type *IPVersionArguments[K]")
+	}
+
+	return ipVersion(args.Target), nil
+}
+
+func ipVersion[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := parseAddr(val)
+		if err != nil {
+			return nil, err
+		}
+		if addr.Is4() {
+			return int64(4), nil
+		}
+		return int64(6), nil
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IPVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         any
+		expected      any
+		expectedError string
+	}{
+		{
+			name:     "IPv4",
+			value:    "8.8.8.8",
+			expected: int64(4),
+		},
+		{
+			name:     "IPv6",
+			value:    "2001:db8::1",
+			expected: int64(6),
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			value:    "::ffff:8.8.8.8",
+			expected: int64(4),
+		},
+		{
+			name:          "invalid address",
+			value:         "not an address",
+			expectedError: `invalid IP address "not an address"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := ipVersion[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
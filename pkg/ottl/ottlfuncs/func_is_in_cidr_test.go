@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsInCIDR(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		networks []string
+		expected bool
+	}{
+		{
+			name:     "in network",
+			value:    "10.1.2.3",
+			networks: []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "in one of the networks",
+			value:    "192.168.1.1",
+			networks: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			expected: true,
+		},
+		{
+			name:     "not in network",
+			value:    "11.0.0.1",
+			networks: []string{"10.0.0.0/8"},
+			expected: false,
+		},
+		{
+			name:     "network with host bits",
+			value:    "10.0.0.200",
+			networks: []string{"10.0.0.1/24"},
+			expected: true,
+		},
+		{
+			name:     "IPv6",
+			value:    "2001:db8::1",
+			networks: []string{"2001:db8::/32"},
+			expected: true,
+		},
+		{
+			name:     "IPv4 address in IPv6 network",
+			value:    "10.0.0.1",
+			networks: []string{"2001:db8::/32"},
+			expected: false,
+		},
+		{
+			name:     "IPv4-mapped IPv6 address",
+			value:    "::ffff:10.0.0.1",
+			networks: []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "IPv4-mapped IPv6 network",
+			value:    "10.0.0.1",
+			networks: []string{"::ffff:10.0.0.0/104"},
+			expected: true,
+		},
+		{
+			name:     "invalid address",
+			value:    "10.0.0",
+			networks: []string{"10.0.0.0/8"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := isInCIDR[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			}, tt.networks)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_IsInCIDR_error(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "10.0.0.1", nil
+		},
+	}
+	_, err := isInCIDR[any](target, nil)
+	assert.ErrorContains(t, err, "IsInCIDR requires at least one network")
+
+	_, err = isInCIDR[any](target, []string{"10.0.0.0/33"})
+	assert.ErrorContains(t, err, "the network supplied to IsInCIDR is not a valid CIDR")
+
+	_, err = isInCIDR[any](target, []string{"10.0.0.1"})
+	assert.ErrorContains(t, err, "the network supplied to IsInCIDR is not a valid CIDR")
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsPrivateIPArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewIsPrivateIPFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsPrivateIP", &IsPrivateIPArguments[K]{}, createIsPrivateIPFunction[K])
+}
+
+func createIsPrivateIPFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsPrivateIPArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IsPrivateIPFactory args must be of type *IsPrivateIPArguments[K]")
+	}
+
+	return isPrivateIP(args.Target), nil
+}
+
+// isPrivateIP reports whether the target is an RFC 1918 IPv4 address or an RFC 4193
+// IPv6 unique local address. Values that are not IP addresses are not private.
+func isPrivateIP[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := parseAddr(val)
+		if err != nil {
+			return false, nil
+		}
+		return addr.IsPrivate(), nil
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsPrivateIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected bool
+	}{
+		{
+			name:     "10.0.0.0/8",
+			value:    "10.1.2.3",
+			expected: true,
+		},
+		{
+			name:     "172.16.0.0/12",
+			value:    "172.31.255.255",
+			expected: true,
+		},
+		{
+			name:     "192.168.0.0/16",
+			value:    "192.168.0.1",
+			expected: true,
+		},
+		{
+			name:     "public IPv4",
+			value:    "172.32.0.1",
+			expected: false,
+		},
+		{
+			name:     "loopback",
+			value:    "127.0.0.1",
+			expected: false,
+		},
+		{
+			name:     "IPv6 unique local address",
+			value:    "fd12:3456:789a::1",
+			expected: true,
+		},
+		{
+			name:     "public IPv6",
+			value:    "2001:4860:4860::8888",
+			expected: false,
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			value:    "::ffff:192.168.1.1",
+			expected: true,
+		},
+		{
+			name:     "invalid address",
+			value:    "192.168.1",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isPrivateIP[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_IsPrivateIP_error(t *testing.T) {
+	exprFunc := isPrivateIP[any](&ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return int64(1), nil
+		},
+	})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected string but got int64")
+}
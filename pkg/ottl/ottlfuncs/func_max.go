@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type MaxArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewMaxFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Max", &MaxArguments[K]{}, createMaxFunction[K])
+}
+
+func createMaxFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MaxArguments[K])
+
+	if !ok {
+		return nil, errors.New("MaxFactory args must be of type *MaxArguments[K]")
+	}
+
+	return maximum(args.Target), nil
+}
+
+func maximum[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		values, err := getNumericList(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		if values.len() == 0 {
+			return nil, errors.New("cannot compute the maximum of an empty list")
+		}
+		if values.ints != nil {
+			return slices.Max(values.ints), nil
+		}
+		return slices.Max(values.floats), nil
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type MinArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewMinFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Min", &MinArguments[K]{}, createMinFunction[K])
+}
+
+func createMinFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MinArguments[K])
+
+	if !ok {
+		return nil, errors.New("MinFactory args must be of type *MinArguments[K]")
+	}
+
+	return minimum(args.Target), nil
+}
+
+func minimum[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		values, err := getNumericList(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		if values.len() == 0 {
+			return nil, errors.New("cannot compute the minimum of an empty list")
+		}
+		if values.ints != nil {
+			return slices.Min(values.ints), nil
+		}
+		return slices.Min(values.floats), nil
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Min(t *testing.T) {
+	sliceValue := pcommon.NewValueSlice()
+	_ = sliceValue.Slice().FromRaw([]any{int64(4), int64(5)})
+
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+		errorStr string
+	}{
+		{
+			name:     "list of int64",
+			value:    []any{int64(2), int64(1), int64(3)},
+			expected: int64(1),
+		},
+		{
+			name:     "list of int64 and float64",
+			value:    []any{int64(1), 2.5},
+			expected: 1.0,
+		},
+		{
+			name:     "pcommon.Value",
+			value:    sliceValue,
+			expected: int64(4),
+		},
+		{
+			name:     "empty list",
+			value:    pcommon.NewSlice(),
+			errorStr: "cannot compute the minimum of an empty list",
+		},
+		{
+			name:     "map",
+			value:    pcommon.NewMap(),
+			errorStr: "unsupported type: pcommon.Map",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := minimum[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			if tt.errorStr != "" {
+				assert.ErrorContains(t, err, tt.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseIPArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseIPFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseIP", &ParseIPArguments[K]{}, createParseIPFunction[K])
+}
+
+func createParseIPFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseIPArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseIPFactory args must be of type *ParseIPArguments[K]")
+	}
+
+	return parseIP(args.Target), nil
+}
+
+func parseIP[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := parseAddr(val)
+		if err != nil {
+			return nil, err
+		}
+		return addr.String(), nil
+	}
+}
+
+// parseAddr parses an IPv4 or IPv6 address, returning IPv4-mapped IPv6 addresses
+// such as ::ffff:10.0.0.1 as IPv4 addresses.
+func parseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q: %w", s, err)
+	}
+	return addr.Unmap(), nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         any
+		expected      any
+		expectedError string
+	}{
+		{
+			name:     "IPv4",
+			value:    "192.168.1.10",
+			expected: "192.168.1.10",
+		},
+		{
+			name:     "IPv6",
+			value:    "2001:DB8:0:0:0:0:0:1",
+			expected: "2001:db8::1",
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			value:    "::ffff:10.0.0.1",
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "IPv6 with zone",
+			value:    "fe80::1%eth0",
+			expected: "fe80::1%eth0",
+		},
+		{
+			name:          "invalid address",
+			value:         "10.0.0",
+			expectedError: `invalid IP address "10.0.0"`,
+		},
+		{
+			name:          "non-string",
+			value:         int64(1),
+			expectedError: "expected string but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseIP[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
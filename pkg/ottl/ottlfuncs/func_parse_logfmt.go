@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseLogfmtArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseLogfmtFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseLogfmt", &ParseLogfmtArguments[K]{}, createParseLogfmtFunction[K])
+}
+
+func createParseLogfmtFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseLogfmtArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseLogfmtFactory args must be of type *ParseLogfmtArguments[K]")
+	}
+
+	return parseLogfmt(args.Target), nil
+}
+
+func parseLogfmt[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty target")
+		}
+		return decodeLogfmt(source)
+	}
+}
+
+// decodeLogfmt parses logfmt pairs such as `level=info msg="hello world" debug`.
+// Values are strings, and keys without a value are set to true. If a key is
+// repeated, its last value is kept.
+func decodeLogfmt(source string) (pcommon.Map, error) {
+	result := pcommon.NewMap()
+	i := 0
+	for {
+		for i < len(source) && isLogfmtSpace(source[i]) {
+			i++
+		}
+		if i == len(source) {
+			return result, nil
+		}
+
+		start := i
+		for i < len(source) && isLogfmtKeyByte(source[i]) {
+			i++
+		}
+		if i == start {
+			return pcommon.Map{}, fmt.Errorf("unexpected %q at position %d, expected a key", source[i], i)
+		}
+		key := source[start:i]
+		if i == len(source) || source[i] != '=' {
+			if i < len(source) && !isLogfmtSpace(source[i]) {
+				return pcommon.Map{}, fmt.Errorf("unexpected %q at position %d after key %q", source[i], i, key)
+			}
+			result.PutBool(key, true)
+			continue
+		}
+		i++
+
+		if i < len(source) && source[i] == '"' {
+			end, err := endOfQuotedValue(source, i)
+			if err != nil {
+				return pcommon.Map{}, err
+			}
+			value, err := strconv.Unquote(source[i:end])
+			if err != nil {
+				return pcommon.Map{}, fmt.Errorf("invalid quoted value of key %q: %w", key, err)
+			}
+			result.PutStr(key, value)
+			i = end
+			if i < len(source) && !isLogfmtSpace(source[i]) {
+				return pcommon.Map{}, fmt.Errorf("unexpected %q at position %d after the value of key %q", source[i], i, key)
+			}
+			continue
+		}
+
+		start = i
+		for i < len(source) && !isLogfmtSpace(source[i]) {
+			if source[i] == '"' || source[i] == '=' {
+				return pcommon.Map{}, fmt.Errorf("unexpected %q at position %d in the value of key %q", source[i], i, key)
+			}
+			i++
+		}
+		result.PutStr(key, source[start:i])
+	}
+}
+
+// endOfQuotedValue returns the position following the closing quote of the quoted value
+// starting at the given position.
+func endOfQuotedValue(source string, start int) (int, error) {
+	for i := start + 1; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted value starting at position %d", start)
+}
+
+func isLogfmtSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func isLogfmtKeyByte(b byte) bool {
+	return b > ' ' && b != '=' && b != '"' && b != 0x7f
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseLogfmt(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "simple pairs",
+			target: `level=info msg=started port=8080`,
+			expected: map[string]any{
+				"level": "info",
+				"msg":   "started",
+				"port":  "8080",
+			},
+		},
+		{
+			name:   "quoted values",
+			target: `msg="hello \"world\"" path="/a b" unicode="café"`,
+			expected: map[string]any{
+				"msg":     `hello "world"`,
+				"path":    "/a b",
+				"unicode": "café",
+			},
+		},
+		{
+			name:   "bare keys and empty values",
+			target: `debug empty= quoted_empty=""`,
+			expected: map[string]any{
+				"debug":        true,
+				"empty":        "",
+				"quoted_empty": "",
+			},
+		},
+		{
+			name:   "extra whitespace and repeated keys",
+			target: "  a=1 \t a=2\n b=3  ",
+			expected: map[string]any{
+				"a": "2",
+				"b": "3",
+			},
+		},
+		{
+			name:   "keys with punctuation",
+			target: `http.method=GET http.status_code=200`,
+			expected: map[string]any{
+				"http.method":      "GET",
+				"http.status_code": "200",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseLogfmt[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_ParseLogfmt_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "cannot parse from empty target",
+		},
+		{
+			name:          "missing key",
+			target:        `=value`,
+			expectedError: `unexpected '=' at position 0, expected a key`,
+		},
+		{
+			name:          "unterminated quoted value",
+			target:        `msg="hello`,
+			expectedError: "unterminated quoted value starting at position 4",
+		},
+		{
+			name:          "text after quoted value",
+			target:        `msg="hello"world`,
+			expectedError: `unexpected 'w' at position 11 after the value of key "msg"`,
+		},
+		{
+			name:          "quote in unquoted value",
+			target:        `msg=hello"world"`,
+			expectedError: `unexpected '"' at position 9 in the value of key "msg"`,
+		},
+		{
+			name:          "quote after key",
+			target:        `msg"hello"`,
+			expectedError: `unexpected '"' at position 3 after key "msg"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseLogfmt[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseYAMLArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseYAMLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseYAML", &ParseYAMLArguments[K]{}, createParseYAMLFunction[K])
+}
+
+func createParseYAMLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseYAMLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseYAMLFactory args must be of type *ParseYAMLArguments[K]")
+	}
+
+	return parseYAML(args.Target), nil
+}
+
+// parseYAML returns a `pcommon.Map` or `pcommon.Slice` struct that is a result of parsing the
+// first document of the target string as YAML. Each YAML type is converted into a `pdata.Value`
+// using the following map:
+//
+//	YAML boolean   -> bool
+//	YAML integer   -> int64
+//	YAML float     -> float64
+//	YAML string    -> string
+//	YAML timestamp -> string, in the RFC 3339 format
+//	YAML null      -> nil
+//	YAML sequence  -> pdata.SliceValue
+//	YAML mapping   -> pdata.MapValue, with keys converted to strings
+func parseYAML[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		var parsedValue any
+		if err = yaml.Unmarshal([]byte(targetVal), &parsedValue); err != nil {
+			return nil, err
+		}
+		switch v := normalizeYAML(parsedValue).(type) {
+		case []any:
+			result := pcommon.NewSlice()
+			err = result.FromRaw(v)
+			return result, err
+		case map[string]any:
+			result := pcommon.NewMap()
+			err = result.FromRaw(v)
+			return result, err
+		default:
+			return nil, fmt.Errorf("could not convert parsed value of type %T to YAML object", v)
+		}
+	}
+}
+
+// normalizeYAML converts the values decoded by the YAML parser into types supported by pdata.
+func normalizeYAML(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return result
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+		return v
+	case int:
+		return int64(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case uint64:
+		if v > math.MaxInt64 {
+			return float64(v)
+		}
+		return int64(v)
+	default:
+		return val
+	}
+}
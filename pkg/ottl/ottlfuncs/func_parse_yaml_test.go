@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected any
+	}{
+		{
+			name: "map",
+			target: `
+name: checkout
+count: 3
+ratio: 0.5
+enabled: true
+missing: null
+date: 2001-12-14
+tags: [a, b]
+nested:
+  key: value
+`,
+			expected: map[string]any{
+				"name":    "checkout",
+				"count":   int64(3),
+				"ratio":   0.5,
+				"enabled": true,
+				"missing": nil,
+				"date":    "2001-12-14T00:00:00Z",
+				"tags":    []any{"a", "b"},
+				"nested":  map[string]any{"key": "value"},
+			},
+		},
+		{
+			name:     "non-string keys",
+			target:   "1: one\ntrue: yes\n",
+			expected: map[string]any{"1": "one", "true": "yes"},
+		},
+		{
+			name:     "sequence",
+			target:   "- a\n- 1\n- {key: value}\n",
+			expected: []any{"a", int64(1), map[string]any{"key": "value"}},
+		},
+		{
+			name:     "first document",
+			target:   "a: 1\n---\nb: 2\n",
+			expected: map[string]any{"a": int64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseYAML[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			switch expected := tt.expected.(type) {
+			case map[string]any:
+				assert.Equal(t, expected, result.(pcommon.Map).AsRaw())
+			case []any:
+				assert.Equal(t, expected, result.(pcommon.Slice).AsRaw())
+			}
+		})
+	}
+}
+
+func Test_ParseYAML_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "scalar",
+			target:        "hello",
+			expectedError: "could not convert parsed value of type string to YAML object",
+		},
+		{
+			name:          "empty",
+			target:        "",
+			expectedError: "could not convert parsed value of type <nil> to YAML object",
+		},
+		{
+			name:          "invalid",
+			target:        "a: [1, 2",
+			expectedError: "yaml: line 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseYAML[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
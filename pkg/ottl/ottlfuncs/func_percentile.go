@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type PercentileArguments[K any] struct {
+	Target     ottl.Getter[K]
+	Percentile ottl.FloatLikeGetter[K]
+}
+
+func NewPercentileFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Percentile", &PercentileArguments[K]{}, createPercentileFunction[K])
+}
+
+func createPercentileFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*PercentileArguments[K])
+
+	if !ok {
+		return nil, errors.New("PercentileFactory args must be of type *PercentileArguments[K]")
+	}
+
+	return percentile(args.Target, args.Percentile), nil
+}
+
+// percentile computes the percentile of the values by linear interpolation between the
+// closest ranks, as done by most spreadsheets and statistics libraries by default.
+func percentile[K any](target ottl.Getter[K], p ottl.FloatLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		pValue, err := p.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if pValue == nil {
+			return nil, errors.New("invalid percentile: expected a number between 0 and 100 but got nil")
+		}
+		if *pValue < 0 || *pValue > 100 || math.IsNaN(*pValue) {
+			return nil, fmt.Errorf("invalid percentile: expected a number between 0 and 100 but got %v", *pValue)
+		}
+		values, err := getNumericList(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		if values.len() == 0 {
+			return nil, errors.New("cannot compute the percentile of an empty list")
+		}
+		sorted := slices.Clone(values.floats)
+		slices.Sort(sorted)
+		rank := *pValue / 100 * float64(len(sorted)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower)), nil
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Percentile(t *testing.T) {
+	values := []any{int64(15), int64(20), int64(35), int64(40), int64(50)}
+	tests := []struct {
+		name       string
+		value      any
+		percentile any
+		expected   any
+		errorStr   string
+	}{
+		{
+			name:       "median",
+			value:      values,
+			percentile: int64(50),
+			expected:   35.0,
+		},
+		{
+			name:       "interpolated",
+			value:      values,
+			percentile: 37.5,
+			expected:   27.5,
+		},
+		{
+			name:       "minimum",
+			value:      values,
+			percentile: int64(0),
+			expected:   15.0,
+		},
+		{
+			name:       "maximum",
+			value:      values,
+			percentile: int64(100),
+			expected:   50.0,
+		},
+		{
+			name:       "unsorted",
+			value:      []float64{3, 1, 2},
+			percentile: "50",
+			expected:   2.0,
+		},
+		{
+			name:       "single value",
+			value:      []any{int64(7)},
+			percentile: 90.0,
+			expected:   7.0,
+		},
+		{
+			name:       "empty list",
+			value:      []any{},
+			percentile: 90.0,
+			errorStr:   "cannot compute the percentile of an empty list",
+		},
+		{
+			name:       "percentile out of range",
+			value:      values,
+			percentile: 101.0,
+			errorStr:   "invalid percentile: expected a number between 0 and 100 but got 101",
+		},
+		{
+			name:       "nil percentile",
+			value:      values,
+			percentile: nil,
+			errorStr:   "invalid percentile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := percentile[any](
+				&ottl.StandardGetSetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+				&ottl.StandardFloatLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.percentile, nil
+					},
+				},
+			)
+			result, err := exprFunc(nil, nil)
+			if tt.errorStr != "" {
+				assert.ErrorContains(t, err, tt.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type PowArguments[K any] struct {
+	Base     ottl.FloatLikeGetter[K]
+	Exponent ottl.FloatLikeGetter[K]
+}
+
+func NewPowFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Pow", &PowArguments[K]{}, createPowFunction[K])
+}
+
+func createPowFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*PowArguments[K])
+
+	if !ok {
+		return nil, errors.New("PowFactory args must be of type *PowArguments[K]")
+	}
+
+	return pow(args.Base, args.Exponent), nil
+}
+
+func pow[K any](base ottl.FloatLikeGetter[K], exponent ottl.FloatLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		b, err := base.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		e, err := exponent.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil || e == nil {
+			return nil, fmt.Errorf("invalid input: base %v, exponent %v", b, e)
+		}
+		result := math.Pow(*b, *e)
+		if math.IsNaN(result) || math.IsInf(result, 0) {
+			return nil, fmt.Errorf("invalid input: %v to the power of %v is not a finite number", *b, *e)
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Pow(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     any
+		exponent any
+		expected any
+		errorStr string
+	}{
+		{
+			name:     "int64",
+			base:     int64(2),
+			exponent: int64(10),
+			expected: 1024.0,
+		},
+		{
+			name:     "float64",
+			base:     9.0,
+			exponent: 0.5,
+			expected: 3.0,
+		},
+		{
+			name:     "negative exponent",
+			base:     2.0,
+			exponent: int64(-1),
+			expected: 0.5,
+		},
+		{
+			name:     "string",
+			base:     "3",
+			exponent: "2",
+			expected: 9.0,
+		},
+		{
+			name:     "not a number",
+			base:     -8.0,
+			exponent: 0.5,
+			errorStr: "not a finite number",
+		},
+		{
+			name:     "infinite",
+			base:     0.0,
+			exponent: int64(-1),
+			errorStr: "not a finite number",
+		},
+		{
+			name:     "nil",
+			base:     nil,
+			exponent: int64(1),
+			errorStr: "invalid input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := pow[any](
+				&ottl.StandardFloatLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.base, nil
+					},
+				},
+				&ottl.StandardFloatLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.exponent, nil
+					},
+				},
+			)
+			result, err := exprFunc(nil, nil)
+			if tt.errorStr != "" {
+				assert.ErrorContains(t, err, tt.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type RoundArguments[K any] struct {
+	Target ottl.FloatLikeGetter[K]
+	Places ottl.Optional[int64]
+}
+
+func NewRoundFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Round", &RoundArguments[K]{}, createRoundFunction[K])
+}
+
+func createRoundFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*RoundArguments[K])
+
+	if !ok {
+		return nil, errors.New("RoundFactory args must be of type *RoundArguments[K]")
+	}
+
+	var places int64
+	if !args.Places.IsEmpty() {
+		places = args.Places.Get()
+	}
+	if places < -15 || places > 15 {
+		return nil, fmt.Errorf("invalid places: expected a number between -15 and 15 but got %d", places)
+	}
+
+	return round(args.Target, places), nil
+}
+
+func round[K any](target ottl.FloatLikeGetter[K], places int64) ottl.ExprFunc[K] {
+	// Scaling by a power of ten greater than one keeps the computation exact for negative places.
+	scale := math.Pow10(int(max(places, -places)))
+	return func(ctx context.Context, tCtx K) (any, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, fmt.Errorf("invalid input: %v", value)
+		}
+		switch {
+		case places > 0:
+			return math.Round(*value*scale) / scale, nil
+		case places < 0:
+			return math.Round(*value/scale) * scale, nil
+		default:
+			return math.Round(*value), nil
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Round(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		places   ottl.Optional[int64]
+		expected float64
+	}{
+		{
+			name:     "no places",
+			value:    2.5,
+			expected: 3.0,
+		},
+		{
+			name:     "negative half rounds away from zero",
+			value:    -2.5,
+			expected: -3.0,
+		},
+		{
+			name:     "two places",
+			value:    3.14159,
+			places:   ottl.NewTestingOptional[int64](2),
+			expected: 3.14,
+		},
+		{
+			name:     "negative places",
+			value:    int64(1234),
+			places:   ottl.NewTestingOptional[int64](-2),
+			expected: 1200.0,
+		},
+		{
+			name:     "string",
+			value:    "1.005e2",
+			places:   ottl.NewTestingOptional[int64](1),
+			expected: 100.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := createRoundFunction[any](ottl.FunctionContext{}, &RoundArguments[any]{
+				Target: &ottl.StandardFloatLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+				Places: tt.places,
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Round_error(t *testing.T) {
+	_, err := createRoundFunction[any](ottl.FunctionContext{}, &RoundArguments[any]{
+		Places: ottl.NewTestingOptional[int64](16),
+	})
+	assert.ErrorContains(t, err, "invalid places")
+
+	exprFunc := round[any](&ottl.StandardFloatLikeGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "test", nil
+		},
+	}, 0)
+	result, err := exprFunc(nil, nil)
+	assert.ErrorContains(t, err, "invalid")
+	assert.Nil(t, result)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SqrtArguments[K any] struct {
+	Target ottl.FloatLikeGetter[K]
+}
+
+func NewSqrtFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Sqrt", &SqrtArguments[K]{}, createSqrtFunction[K])
+}
+
+func createSqrtFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SqrtArguments[K])
+
+	if !ok {
+		return nil, errors.New("SqrtFactory args must be of type *SqrtArguments[K]")
+	}
+
+	return sqrt(args.Target), nil
+}
+
+func sqrt[K any](target ottl.FloatLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, fmt.Errorf("invalid input: %v", value)
+		}
+		if *value < 0 {
+			return nil, fmt.Errorf("invalid input: expected a number greater than or equal to zero but got %v", *value)
+		}
+		return math.Sqrt(*value), nil
+	}
+}
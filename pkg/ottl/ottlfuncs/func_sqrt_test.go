@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Sqrt(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected float64
+	}{
+		{
+			name:     "float64",
+			value:    6.25,
+			expected: 2.5,
+		},
+		{
+			name:     "int64",
+			value:    int64(16),
+			expected: 4.0,
+		},
+		{
+			name:     "zero",
+			value:    0.0,
+			expected: 0.0,
+		},
+		{
+			name:     "string",
+			value:    "2",
+			expected: math.Sqrt(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := sqrt[any](&ottl.StandardFloatLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Sqrt_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		errorStr string
+	}{
+		{
+			name:     "not a number string",
+			value:    "test",
+			errorStr: "invalid",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			errorStr: "invalid",
+		},
+		{
+			name:     "negative",
+			value:    -4.0,
+			errorStr: "greater than or equal to zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := sqrt[any](&ottl.StandardFloatLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.ErrorContains(t, err, tt.errorStr)
+			assert.Nil(t, result)
+		})
+	}
+}
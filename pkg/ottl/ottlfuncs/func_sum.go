@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SumArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewSumFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Sum", &SumArguments[K]{}, createSumFunction[K])
+}
+
+func createSumFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SumArguments[K])
+
+	if !ok {
+		return nil, errors.New("SumFactory args must be of type *SumArguments[K]")
+	}
+
+	return sum(args.Target), nil
+}
+
+func sum[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		values, err := getNumericList(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		if values.ints != nil {
+			var result int64
+			for _, v := range values.ints {
+				result += v
+			}
+			return result, nil
+		}
+		var result float64
+		for _, v := range values.floats {
+			result += v
+		}
+		return result, nil
+	}
+}
+
+// numericList holds the values of a list of numbers.
+type numericList struct {
+	// ints holds the values when all of them are integers, it is nil otherwise.
+	ints []int64
+	// floats holds all the values converted to float64.
+	floats []float64
+}
+
+func (l numericList) len() int {
+	return len(l.floats)
+}
+
+// getNumericList returns the numbers of the list returned by target. Nested lists are flattened,
+// so values and lists can be combined, as in [attributes["a"], attributes["list"]].
+func getNumericList[K any](ctx context.Context, tCtx K, target ottl.Getter[K]) (numericList, error) {
+	val, err := target.Get(ctx, tCtx)
+	if err != nil {
+		return numericList{}, err
+	}
+	values := numericList{ints: []int64{}}
+	if err := values.append(val); err != nil {
+		return numericList{}, err
+	}
+	return values, nil
+}
+
+func (l *numericList) append(val any) error {
+	switch v := val.(type) {
+	case int64:
+		l.appendInt(v)
+	case float64:
+		l.appendFloat(v)
+	case []int64:
+		for _, e := range v {
+			l.appendInt(e)
+		}
+	case []float64:
+		for _, e := range v {
+			l.appendFloat(e)
+		}
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeInt:
+			l.appendInt(v.Int())
+		case pcommon.ValueTypeDouble:
+			l.appendFloat(v.Double())
+		case pcommon.ValueTypeSlice:
+			return l.append(v.Slice())
+		default:
+			return fmt.Errorf("unsupported type: %s, expected a number or a list of numbers", v.Type())
+		}
+	case pcommon.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := l.append(v.At(i)); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, e := range v {
+			if err := l.append(e); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type: %T, expected a number or a list of numbers", v)
+	}
+	return nil
+}
+
+func (l *numericList) appendInt(v int64) {
+	if l.ints != nil {
+		l.ints = append(l.ints, v)
+	}
+	l.floats = append(l.floats, float64(v))
+}
+
+func (l *numericList) appendFloat(v float64) {
+	l.ints = nil
+	l.floats = append(l.floats, v)
+}
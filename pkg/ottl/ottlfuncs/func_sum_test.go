@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Sum(t *testing.T) {
+	slice := pcommon.NewSlice()
+	_ = slice.FromRaw([]any{int64(1), 2.5, int64(3)})
+	sliceValue := pcommon.NewValueSlice()
+	_ = sliceValue.Slice().FromRaw([]any{int64(4), int64(5)})
+
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+		errorStr string
+	}{
+		{
+			name:     "list of int64",
+			value:    []any{int64(1), int64(2), int64(3)},
+			expected: int64(6),
+		},
+		{
+			name:     "list of int64 and float64",
+			value:    []any{int64(1), 2.5},
+			expected: 3.5,
+		},
+		{
+			name:     "typed list",
+			value:    []float64{1.5, 2.5},
+			expected: 4.0,
+		},
+		{
+			name:     "pcommon.Slice",
+			value:    slice,
+			expected: 6.5,
+		},
+		{
+			name:     "pcommon.Value",
+			value:    sliceValue,
+			expected: int64(9),
+		},
+		{
+			name:     "nested lists",
+			value:    []any{int64(1), sliceValue, []int64{10}},
+			expected: int64(20),
+		},
+		{
+			name:     "empty list",
+			value:    []any{},
+			expected: int64(0),
+		},
+		{
+			name:     "string in list",
+			value:    []any{int64(1), "2"},
+			errorStr: "unsupported type: string, expected a number or a list of numbers",
+		},
+		{
+			name:     "string",
+			value:    "1,2",
+			errorStr: "unsupported type: string, expected a number or a list of numbers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := sum[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			if tt.errorStr != "" {
+				assert.ErrorContains(t, err, tt.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ToJSONArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewToJSONFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ToJSON", &ToJSONArguments[K]{}, createToJSONFunction[K])
+}
+
+func createToJSONFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ToJSONArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ToJSONFactory args must be of type *ToJSONArguments[K]")
+	}
+
+	return toJSON(args.Target), nil
+}
+
+// toJSON returns the JSON encoding of the target. The keys of maps are sorted, so that
+// the same value is always encoded into the same string.
+func toJSON[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err = encoder.Encode(asRaw(val)); err != nil {
+			return nil, fmt.Errorf("could not encode value of type %T to JSON: %w", val, err)
+		}
+		return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+	}
+}
+
+// asRaw returns the Go representation of pdata values, and the value itself otherwise.
+func asRaw(val any) any {
+	switch v := val.(type) {
+	case pcommon.Map:
+		return v.AsRaw()
+	case pcommon.Slice:
+		return v.AsRaw()
+	case pcommon.Value:
+		return v.AsRaw()
+	default:
+		return val
+	}
+}
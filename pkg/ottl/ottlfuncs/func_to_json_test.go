@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ToJSON(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("z", "last")
+	m.PutInt("a", 1)
+	m.PutEmptyMap("m").PutStr("<html>", "a & b")
+	m.PutEmptySlice("s").FromRaw([]any{true, 1.5, nil})
+	m.PutEmptyBytes("b").FromRaw([]byte("hi"))
+
+	s := pcommon.NewSlice()
+	s.AppendEmpty().SetStr("a")
+	s.AppendEmpty().SetInt(2)
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "map with sorted keys",
+			value:    m,
+			expected: `{"a":1,"b":"aGk=","m":{"<html>":"a & b"},"s":[true,1.5,null],"z":"last"}`,
+		},
+		{
+			name:     "raw map",
+			value:    map[string]any{"b": "2", "a": int64(1)},
+			expected: `{"a":1,"b":"2"}`,
+		},
+		{
+			name:     "slice",
+			value:    s,
+			expected: `["a",2]`,
+		},
+		{
+			name:     "pcommon.Value",
+			value:    pcommon.NewValueStr("hello"),
+			expected: `"hello"`,
+		},
+		{
+			name:     "string",
+			value:    `quote " and newline` + "\n",
+			expected: `"quote \" and newline\n"`,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: `null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := toJSON[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_ToJSON_error(t *testing.T) {
+	exprFunc := toJSON[any](&ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return math.NaN(), nil
+		},
+	})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "could not encode value of type float64 to JSON")
+}
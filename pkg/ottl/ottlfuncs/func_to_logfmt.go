@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ToLogfmtArguments[K any] struct {
+	Target ottl.PMapGetter[K]
+}
+
+func NewToLogfmtFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ToLogfmt", &ToLogfmtArguments[K]{}, createToLogfmtFunction[K])
+}
+
+func createToLogfmtFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ToLogfmtArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ToLogfmtFactory args must be of type *ToLogfmtArguments[K]")
+	}
+
+	return toLogfmt(args.Target), nil
+}
+
+func toLogfmt[K any](target ottl.PMapGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return encodeLogfmt(source)
+	}
+}
+
+// encodeLogfmt encodes the entries of the map as logfmt pairs, in the order of the map.
+// Values are quoted when they contain spaces, quotes, equal signs or control characters,
+// and maps and slices are encoded as JSON.
+func encodeLogfmt(source pcommon.Map) (string, error) {
+	var b strings.Builder
+	var err error
+	source.Range(func(k string, v pcommon.Value) bool {
+		if k == "" || strings.IndexFunc(k, func(r rune) bool { return !isLogfmtKeyRune(r) }) >= 0 {
+			err = fmt.Errorf("key %q cannot be encoded as logfmt", k)
+			return false
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		if v.Type() == pcommon.ValueTypeEmpty {
+			return true
+		}
+		value := v.AsString()
+		if strings.IndexFunc(value, func(r rune) bool { return !isLogfmtKeyRune(r) }) >= 0 {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(value)
+		return true
+	})
+	return b.String(), err
+}
+
+func isLogfmtKeyRune(r rune) bool {
+	return r > ' ' && r != '=' && r != '"' && r != unicode.ReplacementChar && unicode.IsPrint(r)
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ToLogfmt(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   func() pcommon.Map
+		expected string
+	}{
+		{
+			name: "simple values in map order",
+			target: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("level", "info")
+				m.PutInt("port", 8080)
+				m.PutDouble("ratio", 0.5)
+				m.PutBool("debug", true)
+				return m
+			},
+			expected: `level=info port=8080 ratio=0.5 debug=true`,
+		},
+		{
+			name: "values that need quoting",
+			target: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("msg", `hello "world"`)
+				m.PutStr("expr", "a=b")
+				m.PutStr("multiline", "a\nb")
+				m.PutStr("empty", "")
+				return m
+			},
+			expected: `msg="hello \"world\"" expr="a=b" multiline="a\nb" empty=`,
+		},
+		{
+			name: "nil, maps and slices",
+			target: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutEmpty("nil")
+				m.PutEmptyMap("map").PutStr("k", "v")
+				m.PutEmptySlice("slice").FromRaw([]any{"a", "b"})
+				return m
+			},
+			expected: `nil= map="{\"k\":\"v\"}" slice="[\"a\",\"b\"]"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := toLogfmt[any](&ottl.StandardPMapGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target(), nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			parsed, err := decodeLogfmt(result.(string))
+			require.NoError(t, err)
+			assert.Equal(t, tt.target().Len(), parsed.Len())
+		})
+	}
+}
+
+func Test_ToLogfmt_error(t *testing.T) {
+	for _, key := range []string{"", "a b", "a=b", `a"b`} {
+		exprFunc := toLogfmt[any](&ottl.StandardPMapGetter[any]{
+			Getter: func(context.Context, any) (any, error) {
+				m := pcommon.NewMap()
+				m.PutStr(key, "value")
+				return m, nil
+			},
+		})
+		_, err := exprFunc(context.Background(), nil)
+		assert.ErrorContains(t, err, "cannot be encoded as logfmt")
+	}
+}
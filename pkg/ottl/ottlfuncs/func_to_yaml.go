@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ToYAMLArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewToYAMLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ToYAML", &ToYAMLArguments[K]{}, createToYAMLFunction[K])
+}
+
+func createToYAMLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ToYAMLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ToYAMLFactory args must be of type *ToYAMLArguments[K]")
+	}
+
+	return toYAML(args.Target), nil
+}
+
+// toYAML returns the YAML encoding of the target, with the keys of maps sorted and
+// byte slices encoded using base64, like the JSON encoding of ToJSON.
+func toYAML[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(encodeBytes(asRaw(val))); err != nil {
+			return nil, fmt.Errorf("could not encode value of type %T to YAML: %w", val, err)
+		}
+		if err = encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}
+}
+
+// encodeBytes replaces the byte slices of a raw value by their base64 encoding.
+func encodeBytes(val any) any {
+	switch v := val.(type) {
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = encodeBytes(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = encodeBytes(item)
+		}
+		return result
+	default:
+		return val
+	}
+}
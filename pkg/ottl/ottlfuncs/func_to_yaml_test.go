@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ToYAML(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("name", "checkout")
+	m.PutInt("count", 3)
+	m.PutEmptyMap("nested").PutBool("enabled", true)
+	m.PutEmptySlice("tags").FromRaw([]any{"a", "b"})
+	m.PutEmptyBytes("raw").FromRaw([]byte("hi"))
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:  "map with sorted keys",
+			value: m,
+			expected: `count: 3
+name: checkout
+nested:
+  enabled: true
+raw: aGk=
+tags:
+  - a
+  - b
+`,
+		},
+		{
+			name:     "slice",
+			value:    []any{"a", int64(1)},
+			expected: "- a\n- 1\n",
+		},
+		{
+			name:     "string that needs quoting",
+			value:    "true",
+			expected: "\"true\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := toYAML[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
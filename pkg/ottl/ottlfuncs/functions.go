@@ -36,9 +36,13 @@ func StandardConverters[K any]() map[string]ottl.Factory[K] {
 func converters[K any]() []ottl.Factory[K] {
 	return []ottl.Factory[K]{
 		// Converters
+		NewAbsFactory[K](),
 		NewBase64DecodeFactory[K](),
+		NewAvgFactory[K](),
+		NewCeilFactory[K](),
 		NewDecodeFactory[K](),
 		NewConcatFactory[K](),
+		NewCommunityIDFactory[K](),
 		NewConvertCaseFactory[K](),
 		NewConvertAttributesToElementsXMLFactory[K](),
 		NewConvertTextToElementsXMLFactory[K](),
@@ -48,23 +52,29 @@ func converters[K any]() []ottl.Factory[K] {
 		NewExtractPatternsFactory[K](),
 		NewExtractGrokPatternsFactory[K](),
 		NewFnvFactory[K](),
+		NewFloorFactory[K](),
 		NewGetXMLFactory[K](),
 		NewHourFactory[K](),
 		NewHoursFactory[K](),
 		NewInsertXMLFactory[K](),
 		NewIntFactory[K](),
+		NewIPVersionFactory[K](),
 		NewIsBoolFactory[K](),
 		NewIsDoubleFactory[K](),
+		NewIsInCIDRFactory[K](),
 		NewIsListFactory[K](),
 		NewIsIntFactory[K](),
 		NewIsMapFactory[K](),
 		NewIsMatchFactory[K](),
+		NewIsPrivateIPFactory[K](),
 		NewIsStringFactory[K](),
 		NewLenFactory[K](),
 		NewLogFactory[K](),
+		NewMaxFactory[K](),
 		NewMD5Factory[K](),
 		NewMicrosecondsFactory[K](),
 		NewMillisecondsFactory[K](),
+		NewMinFactory[K](),
 		NewMinuteFactory[K](),
 		NewMinutesFactory[K](),
 		NewMonthFactory[K](),
@@ -74,11 +84,17 @@ func converters[K any]() []ottl.Factory[K] {
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
 		NewParseCSVFactory[K](),
+		NewParseIPFactory[K](),
 		NewParseJSONFactory[K](),
 		NewParseKeyValueFactory[K](),
+		NewParseLogfmtFactory[K](),
 		NewParseSimplifiedXMLFactory[K](),
 		NewParseXMLFactory[K](),
+		NewParseYAMLFactory[K](),
+		NewPercentileFactory[K](),
+		NewPowFactory[K](),
 		NewRemoveXMLFactory[K](),
+		NewRoundFactory[K](),
 		NewSecondFactory[K](),
 		NewSecondsFactory[K](),
 		NewSHA1Factory[K](),
@@ -87,17 +103,22 @@ func converters[K any]() []ottl.Factory[K] {
 		NewSortFactory[K](),
 		NewSpanIDFactory[K](),
 		NewSplitFactory[K](),
+		NewSqrtFactory[K](),
 		NewFormatFactory[K](),
 		NewStringFactory[K](),
 		NewSubstringFactory[K](),
+		NewSumFactory[K](),
 		NewTimeFactory[K](),
 		NewFormatTimeFactory[K](),
 		NewTrimFactory[K](),
 		NewToKeyValueStringFactory[K](),
+		NewToJSONFactory[K](),
 		NewToCamelCaseFactory[K](),
 		NewToLowerCaseFactory[K](),
+		NewToLogfmtFactory[K](),
 		NewToSnakeCaseFactory[K](),
 		NewToUpperCaseFactory[K](),
+		NewToYAMLFactory[K](),
 		NewTruncateTimeFactory[K](),
 		NewTraceIDFactory[K](),
 		NewUnixFactory[K](),
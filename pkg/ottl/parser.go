@@ -19,22 +19,55 @@ import (
 // Statement holds a top level Statement for processing telemetry data. A Statement is a combination of a function
 // invocation and the boolean expression to match telemetry for invoking the function.
 type Statement[K any] struct {
+	loop              *loopExpr[K]
 	function          Expr[K]
 	condition         BoolExpr[K]
 	origText          string
 	telemetrySettings component.TelemetrySettings
+	// conditionKey identifies the where clause among the statements of a StatementSequence.
+	// It is empty if the statement has no where clause, its result is known at parse time,
+	// it invokes converters or it is evaluated for each entry of a loop.
+	conditionKey string
+	// conditionPaths are the data read by the where clause, nil if unknown.
+	conditionPaths []pathRef
+	// editorPaths are the data referenced by the editor and the collection of the loop,
+	// nil if the data the editor may modify is unknown.
+	editorPaths []pathRef
 }
 
 // Execute is a function that will execute the statement's function if the statement's condition is met.
 // Returns true if the function was run, returns false otherwise.
 // If the statement contains no condition, the function will run and true will be returned.
 // In addition, the functions return value is always returned.
+// If the statement iterates over a map or list, the condition and function are evaluated once per
+// entry, true is returned if the function was run for any of them, and the value returned by its
+// last run is returned.
 func (s *Statement[K]) Execute(ctx context.Context, tCtx K) (any, bool, error) {
+	if s.loop == nil {
+		return s.execute(ctx, tCtx)
+	}
+	var result any
+	var anyCondition bool
+	err := s.loop.forEach(ctx, tCtx, func(ctx context.Context) error {
+		r, condition, err := s.execute(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if condition {
+			result, anyCondition = r, true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, anyCondition, err
+	}
+	return result, anyCondition, nil
+}
+
+func (s *Statement[K]) execute(ctx context.Context, tCtx K) (any, bool, error) {
 	condition, err := s.condition.Eval(ctx, tCtx)
 	defer func() {
-		if s.telemetrySettings.Logger != nil {
-			s.telemetrySettings.Logger.Debug("TransformContext after statement execution", zap.String("statement", s.origText), zap.Bool("condition matched", condition), zap.Any("TransformContext", tCtx))
-		}
+		s.logExecution(condition, tCtx)
 	}()
 	if err != nil {
 		return nil, false, err
@@ -49,6 +82,16 @@ func (s *Statement[K]) Execute(ctx context.Context, tCtx K) (any, bool, error) {
 	return result, condition, nil
 }
 
+func (s *Statement[K]) logExecution(condition bool, tCtx K) {
+	if s.telemetrySettings.Logger == nil {
+		return
+	}
+	// Checking the level first avoids building the fields for each execution.
+	if ce := s.telemetrySettings.Logger.Check(zap.DebugLevel, "TransformContext after statement execution"); ce != nil {
+		ce.Write(zap.String("statement", s.origText), zap.Bool("condition matched", condition), zap.Any("TransformContext", tCtx))
+	}
+}
+
 // Condition holds a top level Condition. A Condition is a boolean expression to match telemetry.
 type Condition[K any] struct {
 	condition BoolExpr[K]
@@ -68,6 +111,10 @@ type Parser[K any] struct {
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
 	pathContextNames  map[string]struct{}
+	maxLoopIterations int
+	// loopVariables maps the variables of the loop of the statement being parsed to whether
+	// they hold the key of the entries.
+	loopVariables map[string]bool
 }
 
 func NewParser[K any](
@@ -148,20 +195,36 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	if err != nil {
 		return nil, err
 	}
-	function, err := p.newFunctionCall(parsed.Editor)
+	var l *loopExpr[K]
+	bodyParser := p
+	if parsed.Loop != nil {
+		l, err = p.newLoop(parsed.Loop)
+		if err != nil {
+			return nil, err
+		}
+		bodyParser = p.withLoopVariables(parsed.Loop)
+	}
+	function, err := bodyParser.newFunctionCall(parsed.Editor)
 	if err != nil {
 		return nil, err
 	}
-	expression, err := p.newBoolExpr(parsed.WhereClause)
+	expression, err := bodyParser.newBoolExpr(parsed.WhereClause)
 	if err != nil {
 		return nil, err
 	}
-	return &Statement[K]{
+	s := &Statement[K]{
+		loop:              l,
 		function:          function,
 		condition:         expression,
 		origText:          statement,
 		telemetrySettings: p.telemetrySettings,
-	}, nil
+		editorPaths:       p.newPathRefs(getEditorPaths(parsed)),
+	}
+	if _, constant := expression.isConstant(); !constant && parsed.Loop == nil && parsed.WhereClause != nil && !hasConverters(parsed.WhereClause) {
+		s.conditionKey = whereClauseKey(statement)
+		s.conditionPaths = p.newPathRefs(getBooleanExpressionPaths(parsed.WhereClause))
+	}
+	return s, nil
 }
 
 // ParseConditions parses string conditions into a Condition slice ready for execution.
@@ -322,7 +385,14 @@ func newParser[G any]() *participle.Parser[G] {
 type StatementSequence[K any] struct {
 	statements        []*Statement[K]
 	errorMode         ErrorMode
+	errorHandler      StatementErrorHandler[K]
 	telemetrySettings component.TelemetrySettings
+	// conditionSlots holds the bit of the where clause of each statement in the sets of
+	// where clauses known to be false, or -1 if its result is not tracked.
+	conditionSlots []int
+	// modifiedConditions holds for each statement the bits of the where clauses whose result
+	// may change when its function is run.
+	modifiedConditions []uint64
 }
 
 type StatementSequenceOption[K any] func(*StatementSequence[K])
@@ -334,6 +404,18 @@ func WithStatementSequenceErrorMode[K any](errorMode ErrorMode) StatementSequenc
 	}
 }
 
+// StatementErrorHandler is called for each statement of a StatementSequence failing to execute, with the
+// position of the statement in the sequence, its text and the error it returned.
+type StatementErrorHandler[K any] func(ctx context.Context, tCtx K, index int, statement string, err error)
+
+// WithStatementSequenceErrorHandler sets a StatementErrorHandler called for the statements of a
+// StatementSequence failing to execute, before the error is handled according to the ErrorMode.
+func WithStatementSequenceErrorHandler[K any](handler StatementErrorHandler[K]) StatementSequenceOption[K] {
+	return func(s *StatementSequence[K]) {
+		s.errorHandler = handler
+	}
+}
+
 // NewStatementSequence creates a new StatementSequence with the provided Statement slice and component.TelemetrySettings.
 // The default ErrorMode is `Propagate`.
 // You may also augment the StatementSequence with a slice of StatementSequenceOption.
@@ -342,7 +424,9 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 		statements:        statements,
 		errorMode:         PropagateError,
 		telemetrySettings: telemetrySettings,
+		conditionSlots:    newConditionSlots(statements),
 	}
+	s.modifiedConditions = newModifiedConditions(statements, s.conditionSlots)
 	for _, op := range options {
 		op(&s)
 	}
@@ -353,11 +437,61 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 // When the ErrorMode of the StatementSequence is `propagate`, errors cause the execution to halt and the error is returned.
 // When the ErrorMode of the StatementSequence is `ignore`, errors are logged and execution continues to the next statement.
 // When the ErrorMode of the StatementSequence is `silent`, errors are not logged and execution continues to the next statement.
+// Statements sharing a where clause with a previous statement are skipped when it was false and no function
+// run in between may have modified the data it reads, as are the ones whose where clause was false for a
+// previous record executed with the same context, see WithConditionCache. Where clauses invoking converters
+// are always evaluated.
 func (s *StatementSequence[K]) Execute(ctx context.Context, tCtx K) error {
-	s.telemetrySettings.Logger.Debug("initial TransformContext before executing StatementSequence", zap.Any("TransformContext", tCtx))
-	for _, statement := range s.statements {
-		_, _, err := statement.Execute(ctx, tCtx)
+	if ce := s.telemetrySettings.Logger.Check(zap.DebugLevel, "initial TransformContext before executing StatementSequence"); ce != nil {
+		ce.Write(zap.Any("TransformContext", tCtx))
+	}
+	var cache *conditionCache
+	var cached *cachedConditions
+	if s.conditionSlots != nil {
+		if cache = conditionCacheFromContext(ctx); cache != nil {
+			cached = forSequence(cache, s.statements, s.conditionSlots)
+		}
+	}
+	// falseConditions has the bits of the where clauses known to be false for tCtx set.
+	var falseConditions uint64
+	if cached != nil {
+		falseConditions = cached.falseConditions
+	}
+	for i, statement := range s.statements {
+		slot := -1
+		if s.conditionSlots != nil {
+			slot = s.conditionSlots[i]
+		}
+		if slot >= 0 && falseConditions&(1<<slot) != 0 {
+			statement.logExecution(false, tCtx)
+			continue
+		}
+		_, condition, err := statement.Execute(ctx, tCtx)
+		switch {
+		case condition:
+			// The function was run and may have modified the data read by the where clauses.
+			if s.modifiedConditions != nil {
+				falseConditions &^= s.modifiedConditions[i]
+			}
+			if cached != nil {
+				cache.invalidate(statement.editorPaths)
+			}
+		case err == nil && slot >= 0:
+			falseConditions |= 1 << slot
+			if cached != nil {
+				cached.falseConditions |= cached.covered & (1 << slot)
+			}
+		}
 		if err != nil {
+			if s.errorHandler != nil {
+				s.errorHandler(ctx, tCtx, i, statement.origText, err)
+				// The handler may have modified the data read by the where clauses, in any context.
+				falseConditions = 0
+				if cached != nil {
+					cache.invalidate(nil)
+					falseConditions = cached.falseConditions
+				}
+			}
 			if s.errorMode == PropagateError {
 				err = fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
 				return err
@@ -55,12 +55,16 @@ func NewStatementsGetter(statements []string) StatementsGetter {
 type ottlParserWrapper struct {
 	parser                         reflect.Value
 	prependContextToStatementPaths func(context string, statement string) (string, error)
+	isPathName                     func(name string) bool
+	isFunctionName                 func(name string) bool
 }
 
 func newParserWrapper[K any](parser *Parser[K]) *ottlParserWrapper {
 	return &ottlParserWrapper{
 		parser:                         reflect.ValueOf(parser),
 		prependContextToStatementPaths: parser.prependContextToStatementPaths,
+		isPathName:                     parser.isPathName,
+		isFunctionName:                 parser.isFunctionName,
 	}
 }
 
@@ -348,6 +352,32 @@ func (pc *ParserCollection[R]) ParseStatementsWithContext(context string, statem
 	return convertedStatements.Interface().(R), nil
 }
 
+// ValidateUserFunctions returns an error if a user function has the name of a function of one of
+// the contexts of the [ParserCollection], or if one of its parameters has the name of a Path of one
+// of these contexts. See [Parser.ValidateUserFunctions].
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) ValidateUserFunctions(u *UserFunctions) error {
+	return u.validate(
+		func(name string) bool {
+			for _, contextParser := range pc.contextParsers {
+				if contextParser.ottlParser.isFunctionName(name) {
+					return true
+				}
+			}
+			return false
+		},
+		func(name string) bool {
+			for _, contextParser := range pc.contextParsers {
+				if contextParser.ottlParser.isPathName(name) {
+					return true
+				}
+			}
+			return false
+		},
+	)
+}
+
 func (pc *ParserCollection[R]) logModifiedStatements(originalStatements, modifiedStatements []string) {
 	var fields []zap.Field
 	for i, original := range originalStatements {
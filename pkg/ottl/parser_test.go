@@ -1388,6 +1388,73 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "editor in loop",
+			statement: `for each k, v in attributes: set(k, v)`,
+			expected: &parsedStatement{
+				Loop: &loop{
+					Variables: []string{"k", "v"},
+					Collection: value{
+						Literal: &mathExprLiteral{
+							Path: &path{
+								Pos: lexer.Position{
+									Offset: 17,
+									Line:   1,
+									Column: 18,
+								},
+								Fields: []field{
+									{
+										Name: "attributes",
+									},
+								},
+							},
+						},
+					},
+				},
+				Editor: editor{
+					Function: "set",
+					Arguments: []argument{
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Path: &path{
+										Pos: lexer.Position{
+											Offset: 33,
+											Line:   1,
+											Column: 34,
+										},
+										Fields: []field{
+											{
+												Name: "k",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Path: &path{
+										Pos: lexer.Position{
+											Offset: 36,
+											Line:   1,
+											Column: 37,
+										},
+										Fields: []field{
+											{
+												Name: "v",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2381,6 +2448,20 @@ func Test_parseStatement(t *testing.T) {
 		{statement: `Test()`, wantErr: true},
 		{statement: `set() where test(foo)["key"] == "bar"`, wantErrContaining: converterNameErrorPrefix},
 		{statement: `set() where test(foo)["key"] == "bar"`, wantErrContaining: editorWithIndexErrorPrefix},
+		{statement: `for each v in attributes: set(name, v)`},
+		{statement: `for each k, v in attributes["foo"]: set(attributes[k], v) where v != nil`},
+		{statement: `for each i, v in Split(name, ","): set(attributes[i], v["key"])`},
+		{statement: `for each v in [1, 2, 3]: set(name, v)`},
+		{statement: `for each k, k in attributes: set(name, k)`, wantErrContaining: "loop variables must have different names"},
+		{statement: `for each k, v in Attributes(): set(name, v)`},
+		{statement: `for each k, v in attributes: Set(name, v)`, wantErr: true},
+		{statement: `for each k, v in int(): set(name, v)`, wantErrContaining: converterNameErrorPrefix},
+		{statement: `for each in attributes: set(name, v)`, wantErr: true},
+		{statement: `for each k v in attributes: set(name, v)`, wantErr: true},
+		{statement: `for each a, b, c in attributes: set(name, c)`, wantErr: true},
+		{statement: `for each k, v in attributes set(name, v)`, wantErr: true},
+		{statement: `for k, v in attributes: set(name, v)`, wantErr: true},
+		{statement: `for each k, v in attributes:`, wantErr: true},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -2546,7 +2627,7 @@ func Test_Statement_Execute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			statement := Statement[any]{
-				condition:         BoolExpr[any]{tt.condition},
+				condition:         BoolExpr[any]{boolExpressionEvaluator: tt.condition},
 				function:          Expr[any]{exprFunc: tt.function},
 				telemetrySettings: componenttest.NewNopTelemetrySettings(),
 			}
@@ -2579,7 +2660,7 @@ func Test_Condition_Eval(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			condition := Condition[any]{
-				condition: BoolExpr[any]{tt.condition},
+				condition: BoolExpr[any]{boolExpressionEvaluator: tt.condition},
 			}
 
 			result, err := condition.Eval(context.Background(), nil)
@@ -2662,7 +2743,7 @@ func Test_Statements_Execute_Error(t *testing.T) {
 			statements := StatementSequence[any]{
 				statements: []*Statement[any]{
 					{
-						condition:         BoolExpr[any]{tt.condition},
+						condition:         BoolExpr[any]{boolExpressionEvaluator: tt.condition},
 						function:          Expr[any]{exprFunc: tt.function},
 						telemetrySettings: componenttest.NewNopTelemetrySettings(),
 					},
@@ -2681,6 +2762,43 @@ func Test_Statements_Execute_Error(t *testing.T) {
 	}
 }
 
+func Test_Statements_Execute_ErrorHandler(t *testing.T) {
+	for _, errorMode := range []ErrorMode{IgnoreError, SilentError, PropagateError} {
+		t.Run(string(errorMode), func(t *testing.T) {
+			var handled []string
+			statements := NewStatementSequence(
+				[]*Statement[any]{
+					{
+						condition:         BoolExpr[any]{boolExpressionEvaluator: alwaysTrue[any]},
+						function:          Expr[any]{exprFunc: func(context.Context, any) (any, error) { return nil, nil }},
+						origText:          "first",
+						telemetrySettings: componenttest.NewNopTelemetrySettings(),
+					},
+					{
+						condition:         BoolExpr[any]{boolExpressionEvaluator: alwaysTrue[any]},
+						function:          Expr[any]{exprFunc: func(context.Context, any) (any, error) { return nil, fmt.Errorf("test") }},
+						origText:          "second",
+						telemetrySettings: componenttest.NewNopTelemetrySettings(),
+					},
+				},
+				componenttest.NewNopTelemetrySettings(),
+				WithStatementSequenceErrorMode[any](errorMode),
+				WithStatementSequenceErrorHandler(func(_ context.Context, _ any, index int, statement string, err error) {
+					handled = append(handled, fmt.Sprintf("%d %s %v", index, statement, err))
+				}),
+			)
+
+			err := statements.Execute(context.Background(), nil)
+			if errorMode == PropagateError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, []string{"1 second test"}, handled)
+		})
+	}
+}
+
 func Test_ConditionSequence_Eval(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -2802,7 +2920,7 @@ func Test_ConditionSequence_Eval(t *testing.T) {
 			var rawStatements []*Condition[any]
 			for _, condition := range tt.conditions {
 				rawStatements = append(rawStatements, &Condition[any]{
-					condition: BoolExpr[any]{condition},
+					condition: BoolExpr[any]{boolExpressionEvaluator: condition},
 				})
 			}
 
@@ -2860,7 +2978,7 @@ func Test_ConditionSequence_Eval_Error(t *testing.T) {
 			var rawConditions []*Condition[any]
 			for _, condition := range tt.conditions {
 				rawConditions = append(rawConditions, &Condition[any]{
-					condition: BoolExpr[any]{condition},
+					condition: BoolExpr[any]{boolExpressionEvaluator: condition},
 				})
 			}
 
@@ -2982,6 +3100,13 @@ func Test_prependContextToStatementPaths_Success(t *testing.T) {
 			pathContextNames: []string{"span"},
 			expected:         `set(span.value, 1) where span.name == span.attributes["foo.name"]`,
 		},
+		{
+			name:             "loop variables without context",
+			statement:        `for each k, v in attributes: set(attributes[k], v) where v != nil and name != k`,
+			context:          "span",
+			pathContextNames: []string{"span"},
+			expected:         `for each k, v in span.attributes: set(span.attributes[k], v) where v != nil and span.name != k`,
+		},
 		{
 			name:             "function path parameter without context",
 			statement:        `set(attributes["test"], "pass") where IsMatch(name, "operation[AC]")`,
@@ -23,6 +23,22 @@ func getParsedStatementPaths(ps *parsedStatement) []path {
 	if ps.WhereClause != nil {
 		ps.WhereClause.accept(visitor)
 	}
+	if ps.Loop != nil {
+		visitor.paths = ps.Loop.withoutVariables(visitor.paths)
+		ps.Loop.Collection.accept(visitor)
+	}
+	return visitor.paths
+}
+
+// getEditorPaths returns the paths referenced by the editor of a parsedStatement and by the
+// collection of its loop, excluding the loop variables.
+func getEditorPaths(ps *parsedStatement) []path {
+	visitor := &grammarPathVisitor{}
+	ps.Editor.accept(visitor)
+	if ps.Loop != nil {
+		visitor.paths = ps.Loop.withoutVariables(visitor.paths)
+		ps.Loop.Collection.accept(visitor)
+	}
 	return visitor.paths
 }
 
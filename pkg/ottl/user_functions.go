@@ -0,0 +1,584 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/alecthomas/participle/v2/lexer"
+	"go.opentelemetry.io/collector/component"
+)
+
+// UserFunction is an OTTL function defined in configuration. Its invocations are expanded
+// into OTTL text by UserFunctions before the statements or conditions are parsed, so the
+// expanded text goes through the same validation as the rest of the statements.
+//
+// A user function whose name starts with an uppercase letter is a converter and is
+// expanded into its Expression. A user function whose name starts with a lowercase
+// letter is used in place of an editor and is expanded into its Statements.
+type UserFunction struct {
+	// Name is the name the function is invoked with.
+	Name string `mapstructure:"name"`
+	// Params are the names of the parameters of the function. Within the body of the
+	// function, the parameters are used like paths and are replaced by the arguments
+	// of each invocation.
+	Params []string `mapstructure:"params"`
+	// Expression is the value or the condition a converter is expanded into. Converters
+	// expanded into conditions can only be used where a boolean value is expected.
+	Expression string `mapstructure:"expression"`
+	// Statements are the statements an editor-like function is expanded into.
+	Statements []string `mapstructure:"statements"`
+}
+
+// reservedUserFunctionParams are the words of the grammar that cannot be used as parameter names.
+var reservedUserFunctionParams = map[string]struct{}{
+	"nil":   {},
+	"where": {},
+	"for":   {},
+	"each":  {},
+	"in":    {},
+}
+
+type userFunction struct {
+	name   string
+	params []string
+	body   []string
+}
+
+// UserFunctions expands the invocations of user functions in OTTL statements and conditions.
+type UserFunctions struct {
+	converters map[string]*userFunction
+	editors    map[string]*userFunction
+	// functions are the user functions in the order they are defined.
+	functions []*userFunction
+}
+
+// NewUserFunctions validates the given definitions and returns the UserFunctions expanding them.
+// The body of a function can invoke the functions defined before it.
+func NewUserFunctions(definitions []UserFunction) (*UserFunctions, error) {
+	u := &UserFunctions{
+		converters: map[string]*userFunction{},
+		editors:    map[string]*userFunction{},
+	}
+	var errs []error
+	for _, definition := range definitions {
+		if err := u.add(definition); err != nil {
+			errs = append(errs, fmt.Errorf("invalid user function %q: %w", definition.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return u, nil
+}
+
+func (u *UserFunctions) add(definition UserFunction) error {
+	if definition.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if _, ok := u.converters[definition.Name]; ok {
+		return errors.New("function is defined more than once")
+	}
+	if _, ok := u.editors[definition.Name]; ok {
+		return errors.New("function is defined more than once")
+	}
+	seen := make(map[string]struct{}, len(definition.Params))
+	for _, param := range definition.Params {
+		if !isUserFunctionParam(param) {
+			return fmt.Errorf("parameter names must start with a lowercase letter and only contain lowercase letters, digits and underscores but got %q", param)
+		}
+		if _, ok := seen[param]; ok {
+			return fmt.Errorf("parameter %q is defined more than once", param)
+		}
+		seen[param] = struct{}{}
+	}
+
+	fn := &userFunction{name: definition.Name, params: definition.Params}
+	if unicode.IsUpper(rune(definition.Name[0])) {
+		parsed, err := parseValueExpression(definition.Name + "()")
+		if err != nil || parsed.Literal == nil || parsed.Literal.Converter == nil || parsed.Literal.Converter.Function != definition.Name {
+			return errors.New("name is not a valid converter name")
+		}
+		if len(definition.Statements) > 0 || definition.Expression == "" {
+			return errors.New("converters must define an expression and no statements")
+		}
+		expression, err := u.expandConverters(definition.Expression)
+		if err != nil {
+			return err
+		}
+		if _, err = parseValueExpression(expression); err != nil {
+			if _, conditionErr := parseCondition(expression); conditionErr != nil {
+				return err
+			}
+			// Conditions are wrapped in parentheses so that they can be used as boolean values
+			// in where clauses and other conditions.
+			expression = "(" + expression + ")"
+		}
+		fn.body = []string{expression}
+		u.converters[definition.Name] = fn
+		u.functions = append(u.functions, fn)
+		return nil
+	}
+
+	parsed, err := parseStatement(definition.Name + "()")
+	if err != nil || parsed.Editor.Function != definition.Name {
+		return errors.New("name is not a valid editor name")
+	}
+	if len(definition.Statements) == 0 || definition.Expression != "" {
+		return errors.New("editors must define statements and no expression")
+	}
+	statements, err := u.ExpandStatements(definition.Statements)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err = parseStatement(statement); err != nil {
+			return fmt.Errorf("unable to parse OTTL statement %q: %w", statement, err)
+		}
+	}
+	fn.body = statements
+	u.editors[definition.Name] = fn
+	u.functions = append(u.functions, fn)
+	return nil
+}
+
+// validate returns an error for each function that has the name of a function according to
+// isFunctionName, and for each parameter that has the name of a path according to isPathName.
+// User functions are expanded before the statements are parsed, so such a function would shadow
+// the function of the same name, and such a parameter would hide the path in the body of its
+// function.
+func (u *UserFunctions) validate(isFunctionName func(name string) bool, isPathName func(name string) bool) error {
+	if u == nil {
+		return nil
+	}
+	var errs []error
+	for _, fn := range u.functions {
+		if isFunctionName(fn.name) {
+			errs = append(errs, fmt.Errorf("invalid user function %q: a function with the same name already exists, which it would shadow", fn.name))
+		}
+		for _, param := range fn.params {
+			if isPathName(param) {
+				errs = append(errs, fmt.Errorf("invalid user function %q: parameter %q has the name of a path, which it would hide in the body of the function", fn.name, param))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateUserFunctions returns an error if a user function has the name of one of the Parser's
+// functions, such as `set` or `IsMatch`, or if one of its parameters has the name of a Path of the
+// Parser's context, such as `attributes` or `name`. User functions are expanded before the
+// statements are parsed, so such a function would shadow the function of the same name, and such
+// a parameter would make the Path unreachable from the body of the function.
+func (p *Parser[K]) ValidateUserFunctions(u *UserFunctions) error {
+	return u.validate(p.isFunctionName, p.isPathName)
+}
+
+// ValidateUserFunctionsForParser creates a Parser with newParser, such as the NewParser function of
+// a context, and the given functions, and validates the user functions against it with
+// [Parser.ValidateUserFunctions]. It lets components check their user functions against each of the
+// contexts they parse without keeping the parsers.
+func ValidateUserFunctionsForParser[K any](
+	u *UserFunctions,
+	newParser func(map[string]Factory[K], component.TelemetrySettings, ...Option[K]) (Parser[K], error),
+	functions map[string]Factory[K],
+	set component.TelemetrySettings,
+) error {
+	parser, err := newParser(functions, set)
+	if err != nil {
+		return err
+	}
+	return parser.ValidateUserFunctions(u)
+}
+
+func (p *Parser[K]) isFunctionName(name string) bool {
+	_, ok := p.functions[name]
+	return ok
+}
+
+// isPathName returns whether name, without any keys, is a Path of the Parser's context or of one
+// of the contexts it accepts as a Path prefix.
+func (p *Parser[K]) isPathName(name string) bool {
+	contexts := []string{""}
+	if len(p.pathContextNames) > 0 {
+		contexts = slices.Sorted(maps.Keys(p.pathContextNames))
+	}
+	for _, context := range contexts {
+		bp, err := p.newPath(&path{Context: context, Fields: []field{{Name: name}}})
+		if err != nil {
+			continue
+		}
+		if _, err = p.parsePath(bp); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isUserFunctionParam(name string) bool {
+	if _, ok := reservedUserFunctionParams[name]; ok {
+		return false
+	}
+	tokens, err := tokenize(name)
+	return err == nil && len(tokens) == 1 && tokens[0].typ == "Lowercase" && name == strings.ToLower(name)
+}
+
+// ExpandStatements returns the statements with the invocations of user functions expanded.
+// A statement whose editor is a user function is replaced by the statements of the function.
+// The loop and the where clause of the statement, if any, apply to each of them.
+func (u *UserFunctions) ExpandStatements(statements []string) ([]string, error) {
+	if u == nil {
+		return statements, nil
+	}
+	result := make([]string, 0, len(statements))
+	var errs []error
+	for _, statement := range statements {
+		expanded, err := u.expandStatement(statement)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to expand OTTL statement %q: %w", statement, err))
+			continue
+		}
+		result = append(result, expanded...)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// ExpandConditions returns the conditions with the invocations of user converters expanded.
+func (u *UserFunctions) ExpandConditions(conditions []string) ([]string, error) {
+	if u == nil {
+		return conditions, nil
+	}
+	result := make([]string, 0, len(conditions))
+	var errs []error
+	for _, condition := range conditions {
+		expanded, err := u.expandConverters(condition)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to expand OTTL condition %q: %w", condition, err))
+			continue
+		}
+		result = append(result, expanded)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+func (u *UserFunctions) expandStatement(statement string) ([]string, error) {
+	statement, err := u.expandConverters(statement)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenize(statement)
+	if err != nil {
+		return nil, err
+	}
+	parts, ok := splitStatement(statement, tokens)
+	if !ok {
+		return []string{statement}, nil
+	}
+	fn, ok := u.editors[parts.editor]
+	if !ok {
+		return []string{statement}, nil
+	}
+	args, err := fn.bindArguments(parts.editor, statement, tokens[parts.argsStart:parts.argsEnd])
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make([]string, 0, len(fn.body))
+	for _, body := range fn.body {
+		body, err = substituteParams(body, args)
+		if err != nil {
+			return nil, err
+		}
+		bodyTokens, err := tokenize(body)
+		if err != nil {
+			return nil, err
+		}
+		bodyParts, ok := splitStatement(body, bodyTokens)
+		if !ok {
+			return nil, fmt.Errorf("invalid statement %q in user function %q", body, parts.editor)
+		}
+		if parts.loop != "" && bodyParts.loop != "" {
+			return nil, fmt.Errorf("user function %q iterates over a collection and cannot be used in a loop", parts.editor)
+		}
+		var b strings.Builder
+		b.WriteString(parts.loop)
+		b.WriteString(bodyParts.loop)
+		b.WriteString(bodyParts.invocation)
+		switch {
+		case parts.where != "" && bodyParts.where != "":
+			fmt.Fprintf(&b, " where (%s) and (%s)", bodyParts.where, parts.where)
+		case parts.where != "":
+			fmt.Fprintf(&b, " where %s", parts.where)
+		case bodyParts.where != "":
+			fmt.Fprintf(&b, " where %s", bodyParts.where)
+		}
+		expanded = append(expanded, b.String())
+	}
+	return expanded, nil
+}
+
+// expandConverters returns the text with the invocations of user converters, including the
+// ones nested in their arguments, replaced by their expressions.
+func (u *UserFunctions) expandConverters(text string) (string, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(tokens); i++ {
+		fn, ok := u.converters[tokens[i].value]
+		if !ok || !tokens[i].isIdent() || i+1 >= len(tokens) || tokens[i+1].value != "(" || (i > 0 && tokens[i-1].value == ".") {
+			continue
+		}
+		end := matchingParen(tokens, i+1)
+		if end < 0 {
+			return "", fmt.Errorf("missing closing parenthesis in the invocation of %q", tokens[i].value)
+		}
+		args, err := fn.bindArguments(tokens[i].value, text, tokens[i+2:end])
+		if err != nil {
+			return "", err
+		}
+		for param, arg := range args {
+			if args[param], err = u.expandConverters(arg); err != nil {
+				return "", err
+			}
+		}
+		body, err := substituteParams(fn.body[0], args)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(text[last:tokens[i].start])
+		b.WriteString(parenthesizeMathExpression(body))
+		last = tokens[end].end
+		i = end
+	}
+	b.WriteString(text[last:])
+	return b.String(), nil
+}
+
+// bindArguments returns the text of the arguments of an invocation by parameter name.
+func (fn *userFunction) bindArguments(name string, text string, tokens []token) (map[string]string, error) {
+	args := make(map[string]string, len(fn.params))
+	positional := 0
+	for _, arg := range splitArguments(tokens) {
+		if len(arg) == 0 {
+			return nil, fmt.Errorf("empty argument in the invocation of user function %q", name)
+		}
+		if len(arg) >= 3 && arg[0].isIdent() && arg[1].value == "=" {
+			if !slices.Contains(fn.params, arg[0].value) {
+				return nil, fmt.Errorf("user function %q has no parameter %q", name, arg[0].value)
+			}
+			args[arg[0].value] = text[arg[2].start:arg[len(arg)-1].end]
+			continue
+		}
+		if positional >= len(fn.params) {
+			return nil, fmt.Errorf("user function %q expects %d arguments", name, len(fn.params))
+		}
+		args[fn.params[positional]] = text[arg[0].start:arg[len(arg)-1].end]
+		positional++
+	}
+	if len(args) != len(fn.params) {
+		return nil, fmt.Errorf("user function %q expects %d arguments but got %d", name, len(fn.params), len(args))
+	}
+	for _, param := range fn.params {
+		arg, ok := args[param]
+		if !ok {
+			return nil, fmt.Errorf("missing argument %q of user function %q", param, name)
+		}
+		args[param] = parenthesizeMathExpression(arg)
+	}
+	return args, nil
+}
+
+// substituteParams replaces the parameters used like paths in text by their argument.
+func substituteParams(text string, args map[string]string) (string, error) {
+	if len(args) == 0 {
+		return text, nil
+	}
+	tokens, err := tokenize(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	last := 0
+	for i, t := range tokens {
+		arg, ok := args[t.value]
+		if !ok || !t.isIdent() {
+			continue
+		}
+		if i > 0 && tokens[i-1].value == "." {
+			continue
+		}
+		if i+1 < len(tokens) && (tokens[i+1].value == "." || tokens[i+1].value == "(" || tokens[i+1].value == "=") {
+			continue
+		}
+		b.WriteString(text[last:t.start])
+		b.WriteString(arg)
+		last = t.end
+	}
+	b.WriteString(text[last:])
+	return b.String(), nil
+}
+
+// parenthesizeMathExpression wraps math expressions in parentheses, so that they keep their
+// precedence once they replace a parameter or an invocation.
+func parenthesizeMathExpression(text string) string {
+	parsed, err := parseValueExpression(text)
+	if err != nil || parsed.MathExpression == nil {
+		return text
+	}
+	return "(" + text + ")"
+}
+
+// statementParts are the parts of the text of a statement.
+type statementParts struct {
+	// loop is the `for each` clause of the statement, including the trailing colon.
+	loop string
+	// editor is the name of the editor.
+	editor string
+	// invocation is the editor invocation, from its name to its closing parenthesis.
+	invocation string
+	// argsStart and argsEnd are the indexes of the first and after the last tokens of the arguments.
+	argsStart int
+	argsEnd   int
+	// where is the boolean expression following the `where` keyword.
+	where string
+}
+
+func splitStatement(text string, tokens []token) (statementParts, bool) {
+	// The editor is the first function invoked in the statement with a lowercase name, as loops can
+	// only iterate over paths and converters.
+	start := -1
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].typ == "Lowercase" && tokens[i+1].value == "(" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return statementParts{}, false
+	}
+	end := matchingParen(tokens, start+1)
+	if end < 0 {
+		return statementParts{}, false
+	}
+	parts := statementParts{
+		loop:       text[:tokens[start].start],
+		editor:     tokens[start].value,
+		invocation: text[tokens[start].start:tokens[end].end],
+		argsStart:  start + 2,
+		argsEnd:    end,
+	}
+	if end+1 < len(tokens) {
+		if tokens[end+1].value != "where" || end+2 >= len(tokens) {
+			return statementParts{}, false
+		}
+		parts.where = strings.TrimSpace(text[tokens[end+2].start:])
+	}
+	return parts, true
+}
+
+func matchingParen(tokens []token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch tokens[i].value {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitArguments splits the tokens of the arguments of an invocation at the top level commas.
+func splitArguments(tokens []token) [][]token {
+	var args [][]token
+	depth := 0
+	start := 0
+	for i, t := range tokens {
+		switch t.value {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case ",":
+			if depth == 0 {
+				args = append(args, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(tokens) {
+		args = append(args, tokens[start:])
+	}
+	return args
+}
+
+var (
+	userFunctionsLexer  = buildLexer()
+	userFunctionsSymbol = func() map[lexer.TokenType]string {
+		symbols := map[lexer.TokenType]string{}
+		for name, t := range userFunctionsLexer.Symbols() {
+			symbols[t] = name
+		}
+		return symbols
+	}()
+)
+
+// token is a token of the OTTL grammar, where consecutive Uppercase and Lowercase tokens are
+// merged into a single identifier, such as the `NormalizeKey` function name.
+type token struct {
+	typ   string
+	value string
+	start int
+	end   int
+}
+
+func (t token) isIdent() bool {
+	return t.typ == "Uppercase" || t.typ == "Lowercase"
+}
+
+func tokenize(text string) ([]token, error) {
+	lex, err := userFunctionsLexer.LexString("", text)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []token
+	for {
+		t, err := lex.Next()
+		if err != nil {
+			return nil, err
+		}
+		if t.EOF() {
+			return tokens, nil
+		}
+		typ := userFunctionsSymbol[t.Type]
+		if typ == "whitespace" {
+			continue
+		}
+		current := token{typ: typ, value: t.Value, start: t.Pos.Offset, end: t.Pos.Offset + len(t.Value)}
+		if n := len(tokens); n > 0 && current.isIdent() && tokens[n-1].isIdent() && tokens[n-1].end == current.start {
+			tokens[n-1].value += current.value
+			tokens[n-1].end = current.end
+			continue
+		}
+		tokens = append(tokens, current)
+	}
+}
@@ -0,0 +1,391 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func Test_UserFunctions_ExpandStatements(t *testing.T) {
+	definitions := []UserFunction{
+		{
+			Name:       "Normalized",
+			Params:     []string{"value"},
+			Expression: `ToLowerCase(Trim(value))`,
+		},
+		{
+			Name:       "Double",
+			Params:     []string{"x"},
+			Expression: `x * 2`,
+		},
+		{
+			Name:       "AttrOrDefault",
+			Params:     []string{"key", "default"},
+			Expression: `Coalesce(attributes[key], default)`,
+		},
+		{
+			Name:       "NormalizedAttr",
+			Params:     []string{"key"},
+			Expression: `Normalized(attributes[key])`,
+		},
+		{
+			Name:   "normalize",
+			Params: []string{"target"},
+			Statements: []string{
+				`set(target, Normalized(target)) where target != nil`,
+			},
+		},
+		{
+			Name:   "rename",
+			Params: []string{"from", "to"},
+			Statements: []string{
+				`set(attributes[to], attributes[from])`,
+				`delete_key(attributes, from)`,
+			},
+		},
+		{
+			Name:   "normalize_all",
+			Params: []string{"map"},
+			Statements: []string{
+				`for each k, v in map: set(map[k], Normalized(v)) where IsString(v)`,
+			},
+		},
+		{
+			Name: "mark",
+			Statements: []string{
+				`set(attributes["marked"], true)`,
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		expected  []string
+	}{
+		{
+			name:      "no user function",
+			statement: `set(attributes["a"], Trim(attributes["b"]))`,
+			expected:  []string{`set(attributes["a"], Trim(attributes["b"]))`},
+		},
+		{
+			name:      "converter",
+			statement: `set(attributes["a"], Normalized(attributes["b"]))`,
+			expected:  []string{`set(attributes["a"], ToLowerCase(Trim(attributes["b"])))`},
+		},
+		{
+			name:      "nested converters",
+			statement: `set(attributes["a"], Normalized(Normalized(name)))`,
+			expected:  []string{`set(attributes["a"], ToLowerCase(Trim(ToLowerCase(Trim(name)))))`},
+		},
+		{
+			name:      "converter defined with another converter",
+			statement: `set(attributes["a"], NormalizedAttr("b"))`,
+			expected:  []string{`set(attributes["a"], ToLowerCase(Trim(attributes["b"])))`},
+		},
+		{
+			name:      "math expressions keep their precedence",
+			statement: `set(attributes["a"], Double(1 + 2) + 1)`,
+			expected:  []string{`set(attributes["a"], ((1 + 2) * 2) + 1)`},
+		},
+		{
+			name:      "named arguments",
+			statement: `set(attributes["a"], AttrOrDefault(default = "none", key = "b"))`,
+			expected:  []string{`set(attributes["a"], Coalesce(attributes["b"], "none"))`},
+		},
+		{
+			name:      "converter in the where clause",
+			statement: `set(attributes["a"], "b") where Normalized(name) == "foo"`,
+			expected:  []string{`set(attributes["a"], "b") where ToLowerCase(Trim(name)) == "foo"`},
+		},
+		{
+			name:      "statement macro",
+			statement: `normalize(attributes["a"])`,
+			expected:  []string{`set(attributes["a"], ToLowerCase(Trim(attributes["a"]))) where attributes["a"] != nil`},
+		},
+		{
+			name:      "statement macro with a where clause",
+			statement: `normalize(name) where kind == 1`,
+			expected:  []string{`set(name, ToLowerCase(Trim(name))) where (name != nil) and (kind == 1)`},
+		},
+		{
+			name:      "statement macro with several statements",
+			statement: `rename("old", to = "new") where attributes["old"] != nil`,
+			expected: []string{
+				`set(attributes["new"], attributes["old"]) where attributes["old"] != nil`,
+				`delete_key(attributes, "old") where attributes["old"] != nil`,
+			},
+		},
+		{
+			name:      "statement macro with a loop",
+			statement: `normalize_all(resource.attributes)`,
+			expected:  []string{`for each k, v in resource.attributes: set(resource.attributes[k], ToLowerCase(Trim(v))) where IsString(v)`},
+		},
+		{
+			name:      "statement macro in a loop",
+			statement: `for each k in ["a", "b"]: normalize(attributes[k])`,
+			expected:  []string{`for each k in ["a", "b"]: set(attributes[k], ToLowerCase(Trim(attributes[k]))) where attributes[k] != nil`},
+		},
+		{
+			name:      "statement macro without parameters",
+			statement: `mark()`,
+			expected:  []string{`set(attributes["marked"], true)`},
+		},
+	}
+	u, err := NewUserFunctions(definitions)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := u.ExpandStatements([]string{tt.statement})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expanded)
+			for _, statement := range expanded {
+				_, err = parseStatement(statement)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_UserFunctions_ExpandConditions(t *testing.T) {
+	u, err := NewUserFunctions([]UserFunction{
+		{
+			Name:       "IsHealthCheck",
+			Params:     []string{"route"},
+			Expression: `IsMatch(route, "^/(health|ready)z?$")`,
+		},
+		{
+			Name:       "IsServer",
+			Params:     []string{"k"},
+			Expression: `k == 2 or k == 5`,
+		},
+	})
+	require.NoError(t, err)
+
+	expanded, err := u.ExpandConditions([]string{
+		`IsHealthCheck(attributes["http.route"])`,
+		`not IsHealthCheck(name) and kind == 2`,
+		`IsServer(kind) and name == "foo"`,
+		`not IsServer(kind)`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`IsMatch(attributes["http.route"], "^/(health|ready)z?$")`,
+		`not IsMatch(name, "^/(health|ready)z?$") and kind == 2`,
+		`(kind == 2 or kind == 5) and name == "foo"`,
+		`not (kind == 2 or kind == 5)`,
+	}, expanded)
+	for _, condition := range expanded {
+		_, err = parseCondition(condition)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_UserFunctions_nil(t *testing.T) {
+	var u *UserFunctions
+	statements, err := u.ExpandStatements([]string{`set(name, "a")`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`set(name, "a")`}, statements)
+
+	conditions, err := u.ExpandConditions([]string{`name == "a"`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`name == "a"`}, conditions)
+}
+
+func Test_ValidateUserFunctions(t *testing.T) {
+	u, err := NewUserFunctions([]UserFunction{
+		{Name: "Lower", Params: []string{"value"}, Expression: "ToLowerCase(value)"},
+		{Name: "rename", Params: []string{"attributes", "from", "name"}, Statements: []string{"set(attributes[name], attributes[from])"}},
+	})
+	require.NoError(t, err)
+	expected := `invalid user function "rename": parameter "attributes" has the name of a path, which it would hide in the body of the function` + "\n" +
+		`invalid user function "rename": parameter "name" has the name of a path, which it would hide in the body of the function`
+
+	assert.EqualError(t, mockParser(t).ValidateUserFunctions(u), expected)
+	assert.EqualError(t, mockParser(t, WithPathContextNames[any]([]string{"foo"})).ValidateUserFunctions(u), expected)
+
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", mockParser(t, WithPathContextNames[any]([]string{"foo"})), newNopParsedStatementConverter[any]()),
+	)
+	require.NoError(t, err)
+	assert.EqualError(t, pc.ValidateUserFunctions(u), expected)
+
+	valid, err := NewUserFunctions([]UserFunction{{Name: "Lower", Params: []string{"value"}, Expression: "ToLowerCase(value)"}})
+	require.NoError(t, err)
+	assert.NoError(t, mockParser(t).ValidateUserFunctions(valid))
+	assert.NoError(t, pc.ValidateUserFunctions(valid))
+	assert.NoError(t, pc.ValidateUserFunctions(nil))
+
+	shadowing, err := NewUserFunctions([]UserFunction{{Name: "set", Params: []string{"target"}, Statements: []string{`replace_all(target, "a", "b")`}}})
+	require.NoError(t, err)
+	expected = `invalid user function "set": a function with the same name already exists, which it would shadow`
+	assert.EqualError(t, mockParser(t).ValidateUserFunctions(shadowing), expected)
+	assert.EqualError(t, pc.ValidateUserFunctions(shadowing), expected)
+}
+
+func Test_ValidateUserFunctionsForParser(t *testing.T) {
+	newParser := func(functions map[string]Factory[any], set component.TelemetrySettings, options ...Option[any]) (Parser[any], error) {
+		return NewParser(functions, testParsePath[any], set, options...)
+	}
+	functions := CreateFactoryMap[any](NewFactory("set", &mockSetArguments[any]{},
+		func(_ FunctionContext, _ Arguments) (ExprFunc[any], error) {
+			return func(_ context.Context, _ any) (any, error) {
+				return nil, nil
+			}, nil
+		}))
+
+	valid, err := NewUserFunctions([]UserFunction{{Name: "Lower", Params: []string{"value"}, Expression: "ToLowerCase(value)"}})
+	require.NoError(t, err)
+	assert.NoError(t, ValidateUserFunctionsForParser(valid, newParser, functions, componenttest.NewNopTelemetrySettings()))
+
+	shadowing, err := NewUserFunctions([]UserFunction{{Name: "set", Params: []string{"target"}, Statements: []string{`replace_all(target, "a", "b")`}}})
+	require.NoError(t, err)
+	assert.EqualError(t, ValidateUserFunctionsForParser(shadowing, newParser, functions, componenttest.NewNopTelemetrySettings()),
+		`invalid user function "set": a function with the same name already exists, which it would shadow`)
+
+	failing := func(map[string]Factory[any], component.TelemetrySettings, ...Option[any]) (Parser[any], error) {
+		return Parser[any]{}, errors.New("no parser")
+	}
+	assert.EqualError(t, ValidateUserFunctionsForParser(valid, failing, functions, componenttest.NewNopTelemetrySettings()), "no parser")
+}
+
+func Test_NewUserFunctions_error(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition []UserFunction
+		wantErr    string
+	}{
+		{
+			name:       "empty name",
+			definition: []UserFunction{{Expression: `1`}},
+			wantErr:    "name must not be empty",
+		},
+		{
+			name:       "invalid name",
+			definition: []UserFunction{{Name: "Not-Valid", Expression: `1`}},
+			wantErr:    "name is not a valid converter name",
+		},
+		{
+			name: "duplicate name",
+			definition: []UserFunction{
+				{Name: "One", Expression: `1`},
+				{Name: "One", Expression: `2`},
+			},
+			wantErr: "function is defined more than once",
+		},
+		{
+			name:       "invalid parameter",
+			definition: []UserFunction{{Name: "One", Params: []string{"Value"}, Expression: `1`}},
+			wantErr:    `parameter names must start with a lowercase letter and only contain lowercase letters, digits and underscores but got "Value"`,
+		},
+		{
+			name:       "reserved parameter",
+			definition: []UserFunction{{Name: "One", Params: []string{"where"}, Expression: `1`}},
+			wantErr:    `but got "where"`,
+		},
+		{
+			name:       "duplicate parameter",
+			definition: []UserFunction{{Name: "One", Params: []string{"a", "a"}, Expression: `1`}},
+			wantErr:    `parameter "a" is defined more than once`,
+		},
+		{
+			name:       "converter with statements",
+			definition: []UserFunction{{Name: "One", Statements: []string{`set(name, "a")`}}},
+			wantErr:    "converters must define an expression and no statements",
+		},
+		{
+			name:       "editor with an expression",
+			definition: []UserFunction{{Name: "one", Expression: `1`}},
+			wantErr:    "editors must define statements and no expression",
+		},
+		{
+			name:       "invalid expression",
+			definition: []UserFunction{{Name: "One", Expression: `1 +`}},
+			wantErr:    `invalid user function "One"`,
+		},
+		{
+			name:       "invalid statement",
+			definition: []UserFunction{{Name: "one", Statements: []string{`set(name, "a") where`}}},
+			wantErr:    `unable to parse OTTL statement "set(name, \"a\") where"`,
+		},
+		{
+			name: "invocation with the wrong number of arguments",
+			definition: []UserFunction{
+				{Name: "One", Params: []string{"a"}, Expression: `a`},
+				{Name: "Two", Expression: `One(1, 2)`},
+			},
+			wantErr: `user function "One" expects 1 arguments`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUserFunctions(tt.definition)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_UserFunctions_ExpandStatements_error(t *testing.T) {
+	u, err := NewUserFunctions([]UserFunction{
+		{
+			Name:       "Pair",
+			Params:     []string{"a", "b"},
+			Expression: `[a, b]`,
+		},
+		{
+			Name:   "clear",
+			Params: []string{"map"},
+			Statements: []string{
+				`for each k in map: delete_key(map, k)`,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "missing argument",
+			statement: `set(name, Pair(1))`,
+			wantErr:   `user function "Pair" expects 2 arguments but got 1`,
+		},
+		{
+			name:      "too many arguments",
+			statement: `set(name, Pair(1, 2, 3))`,
+			wantErr:   `user function "Pair" expects 2 arguments`,
+		},
+		{
+			name:      "unknown named argument",
+			statement: `set(name, Pair(1, c = 2))`,
+			wantErr:   `user function "Pair" has no parameter "c"`,
+		},
+		{
+			name:      "empty argument",
+			statement: `set(name, Pair(, 2))`,
+			wantErr:   `empty argument in the invocation of user function "Pair"`,
+		},
+		{
+			name:      "nested loops",
+			statement: `for each v in attributes: clear(v)`,
+			wantErr:   `user function "clear" iterates over a collection and cannot be used in a loop`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.ExpandStatements([]string{tt.statement})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -18,6 +18,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset/regexp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 )
 
 // Config defines configuration for Resource processor.
@@ -36,6 +43,50 @@ type Config struct {
 	Spans filterconfig.MatchConfig `mapstructure:"spans"`
 
 	Traces TraceFilters `mapstructure:"traces"`
+
+	Profiles ProfileFilters `mapstructure:"profiles"`
+
+	// UserFunctions are OTTL functions defined in the configuration, which can be used in the
+	// conditions of all signals. Their invocations are expanded before the conditions are parsed.
+	UserFunctions []ottl.UserFunction `mapstructure:"ottl_functions"`
+}
+
+// newUserFunctions returns the UserFunctions expanding the configured user functions, or nil if
+// there are none.
+func (cfg *Config) newUserFunctions() (*ottl.UserFunctions, error) {
+	if len(cfg.UserFunctions) == 0 {
+		return nil, nil
+	}
+	return ottl.NewUserFunctions(cfg.UserFunctions)
+}
+
+// validateUserFunctions returns an error if a user function has the name of a function, or one of
+// its parameters the name of a path, of one of the contexts with conditions.
+func (cfg *Config) validateUserFunctions(userFunctions *ottl.UserFunctions) error {
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	var err error
+	if err == nil && cfg.Traces.SpanConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlspan.NewParser, filterottl.StandardSpanFuncs(), set)
+	}
+	if err == nil && cfg.Traces.SpanEventConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlspanevent.NewParser, filterottl.StandardSpanEventFuncs(), set)
+	}
+	if err == nil && cfg.Traces.SpanLinkConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlspanlink.NewParser, filterottl.StandardSpanLinkFuncs(), set)
+	}
+	if err == nil && cfg.Metrics.MetricConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlmetric.NewParser, filterottl.StandardMetricFuncs(), set)
+	}
+	if err == nil && cfg.Metrics.DataPointConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottldatapoint.NewParser, filterottl.StandardDataPointFuncs(), set)
+	}
+	if err == nil && cfg.Logs.LogConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottllog.NewParser, filterottl.StandardLogFuncs(), set)
+	}
+	if err == nil && cfg.Profiles.ProfileConditions != nil {
+		err = ottl.ValidateUserFunctionsForParser(userFunctions, ottlprofile.NewParser, filterottl.StandardProfileFuncs(), set)
+	}
+	return err
 }
 
 // MetricFilters filters by Metric properties.
@@ -75,6 +126,19 @@ type TraceFilters struct {
 	// If any condition resolves to true, the span event will be dropped.
 	// Supports `and`, `or`, and `()`
 	SpanEventConditions []string `mapstructure:"spanevent"`
+
+	// SpanLinkConditions is a list of OTTL conditions for an ottlspanlink context.
+	// If any condition resolves to true, the span link will be dropped.
+	// Supports `and`, `or`, and `()`
+	SpanLinkConditions []string `mapstructure:"spanlink"`
+}
+
+// ProfileFilters filters by OTTL conditions
+type ProfileFilters struct {
+	// ProfileConditions is a list of OTTL conditions for an ottlprofile context.
+	// If any condition resolves to true, the profile will be dropped.
+	// Supports `and`, `or`, and `()`
+	ProfileConditions []string `mapstructure:"profile"`
 }
 
 // LogFilters filters by Log properties.
@@ -266,7 +330,7 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	if (cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil) && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
+	if (cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil || cfg.Traces.SpanLinkConditions != nil) && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for spans at the same time")
 	}
 	if (cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil) && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
@@ -276,30 +340,71 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for logs at the same time")
 	}
 
+	userFunctions, err := cfg.newUserFunctions()
+	if err != nil {
+		return err
+	}
+	if userFunctions != nil {
+		if err = cfg.validateUserFunctions(userFunctions); err != nil {
+			return err
+		}
+	}
+
 	var errors error
 
 	if cfg.Traces.SpanConditions != nil {
-		_, err := filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := userFunctions.ExpandConditions(cfg.Traces.SpanConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Traces.SpanEventConditions != nil {
-		_, err := filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := userFunctions.ExpandConditions(cfg.Traces.SpanEventConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForSpanEvent(conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
+		errors = multierr.Append(errors, err)
+	}
+
+	if cfg.Traces.SpanLinkConditions != nil {
+		conditions, err := userFunctions.ExpandConditions(cfg.Traces.SpanLinkConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForSpanLink(conditions, filterottl.StandardSpanLinkFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.MetricConditions != nil {
-		_, err := filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := userFunctions.ExpandConditions(cfg.Metrics.MetricConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForMetric(conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.DataPointConditions != nil {
-		_, err := filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := userFunctions.ExpandConditions(cfg.Metrics.DataPointConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Logs.LogConditions != nil {
-		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		conditions, err := userFunctions.ExpandConditions(cfg.Logs.LogConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
+		errors = multierr.Append(errors, err)
+	}
+
+	if cfg.Profiles.ProfileConditions != nil {
+		conditions, err := userFunctions.ExpandConditions(cfg.Profiles.ProfileConditions)
+		if err == nil {
+			_, err = filterottl.NewBoolExprForProfile(conditions, filterottl.StandardProfileFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		}
 		errors = multierr.Append(errors, err)
 	}
 
@@ -848,6 +848,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 					SpanEventConditions: []string{
 						`attributes["test"] == "pass"`,
 					},
+					SpanLinkConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
 				},
 				Metrics: MetricFilters{
 					MetricConditions: []string{
@@ -862,6 +865,11 @@ func TestLoadingConfigOTTL(t *testing.T) {
 						`attributes["test"] == "pass"`,
 					},
 				},
+				Profiles: ProfileFilters{
+					ProfileConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
+				},
 			},
 		},
 		{
@@ -894,6 +902,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_spanevent"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_spanlink"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_metric"),
 		},
@@ -903,6 +914,43 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_log"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_profile"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl_functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`IsHealthCheck(attributes["http.route"])`,
+					},
+				},
+				Logs: LogFilters{
+					LogConditions: []string{
+						`IsHealthCheck(attributes["http.route"]) and severity_number < SEVERITY_NUMBER_WARN`,
+					},
+				},
+				UserFunctions: []ottl.UserFunction{
+					{
+						Name:       "IsHealthCheck",
+						Params:     []string{"route"},
+						Expression: `IsMatch(route, "^/(health|ready)z?$")`,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "ottl_functions_invalid_definition"),
+			errorMessage: `invalid user function "IsHealthCheck": parameter names must start with a lowercase letter and only contain lowercase letters, digits and underscores but got "Route"`,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl_functions_wrong_arguments"),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "ottl_functions_param_path"),
+			errorMessage: `invalid user function "IsHealthCheck": parameter "attributes" has the name of a path, which it would hide in the body of the function`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -13,10 +13,14 @@ require (
 	go.opentelemetry.io/collector/confmap/xconfmap v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/consumer v1.27.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/consumer/consumertest v0.121.1-0.20250313100724-0885401136ff
+	go.opentelemetry.io/collector/consumer/xconsumer v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/pdata v1.27.1-0.20250313100724-0885401136ff
+	go.opentelemetry.io/collector/pdata/pprofile v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/pipeline v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/processor v0.121.1-0.20250313100724-0885401136ff
+	go.opentelemetry.io/collector/processor/processorhelper/xprocessorhelper v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/processor/processortest v0.121.1-0.20250313100724-0885401136ff
+	go.opentelemetry.io/collector/processor/xprocessor v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/otel v1.35.0
 	go.opentelemetry.io/otel/metric v1.35.0
 	go.opentelemetry.io/otel/sdk/metric v1.35.0
@@ -62,11 +66,8 @@ require (
 	github.com/ua-parser/uap-go v0.0.0-20240611065828-3a4781585db6 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/component/componentstatus v0.121.1-0.20250313100724-0885401136ff // indirect
-	go.opentelemetry.io/collector/consumer/xconsumer v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/featuregate v1.27.1-0.20250313100724-0885401136ff // indirect
-	go.opentelemetry.io/collector/pdata/pprofile v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.121.1-0.20250313100724-0885401136ff // indirect
-	go.opentelemetry.io/collector/processor/xprocessor v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/semconv v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/otel/sdk v1.35.0 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
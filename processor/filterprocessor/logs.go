@@ -39,7 +39,15 @@ func newFilterLogsProcessor(set processor.Settings, cfg *Config) (*filterLogProc
 	flp.telemetry = fpt
 
 	if cfg.Logs.LogConditions != nil {
-		skipExpr, errBoolExpr := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+		userFunctions, err := cfg.newUserFunctions()
+		if err != nil {
+			return nil, err
+		}
+		conditions, err := userFunctions.ExpandConditions(cfg.Logs.LogConditions)
+		if err != nil {
+			return nil, err
+		}
+		skipExpr, errBoolExpr := filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set.TelemetrySettings)
 		if errBoolExpr != nil {
 			return nil, errBoolExpr
 		}
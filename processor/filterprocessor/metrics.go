@@ -47,15 +47,27 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 	fsp.telemetry = fpt
 
 	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
+		userFunctions, err := cfg.newUserFunctions()
+		if err != nil {
+			return nil, err
+		}
 		if cfg.Metrics.MetricConditions != nil {
-			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			conditions, err := userFunctions.ExpandConditions(cfg.Metrics.MetricConditions)
+			if err != nil {
+				return nil, err
+			}
+			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(conditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Metrics.DataPointConditions != nil {
-			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			conditions, err := userFunctions.ExpandConditions(cfg.Metrics.DataPointConditions)
+			if err != nil {
+				return nil, err
+			}
+			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
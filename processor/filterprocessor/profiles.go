@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+)
+
+type filterProfileProcessor struct {
+	skipExpr  expr.BoolExpr[ottlprofile.TransformContext]
+	telemetry *filterTelemetry
+	logger    *zap.Logger
+}
+
+func newFilterProfilesProcessor(set processor.Settings, cfg *Config) (*filterProfileProcessor, error) {
+	fpp := &filterProfileProcessor{
+		logger: set.Logger,
+	}
+
+	fpt, err := newFilterProfilesTelemetry(set)
+	if err != nil {
+		return nil, fmt.Errorf("error creating filter processor telemetry: %w", err)
+	}
+	fpp.telemetry = fpt
+
+	if cfg.Profiles.ProfileConditions != nil {
+		userFunctions, err := cfg.newUserFunctions()
+		if err != nil {
+			return nil, err
+		}
+		conditions, err := userFunctions.ExpandConditions(cfg.Profiles.ProfileConditions)
+		if err != nil {
+			return nil, err
+		}
+		skipExpr, errBoolExpr := filterottl.NewBoolExprForProfile(conditions, filterottl.StandardProfileFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+		if errBoolExpr != nil {
+			return nil, errBoolExpr
+		}
+		fpp.skipExpr = skipExpr
+	}
+
+	return fpp, nil
+}
+
+func (fpp *filterProfileProcessor) processProfiles(ctx context.Context, pd pprofile.Profiles) (pprofile.Profiles, error) {
+	if fpp.skipExpr == nil {
+		return pd, nil
+	}
+
+	profileCountBeforeFilters := profileCount(pd)
+
+	var errors error
+	pd.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		resource := rp.Resource()
+		rp.ScopeProfiles().RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+			scope := sp.Scope()
+			sp.Profiles().RemoveIf(func(profile pprofile.Profile) bool {
+				skip, err := fpp.skipExpr.Eval(ctx, ottlprofile.NewTransformContext(profile, scope, resource, sp, rp))
+				if err != nil {
+					errors = multierr.Append(errors, err)
+					return false
+				}
+				return skip
+			})
+
+			return sp.Profiles().Len() == 0
+		})
+		return rp.ScopeProfiles().Len() == 0
+	})
+
+	profileCountAfterFilters := profileCount(pd)
+	fpp.telemetry.record(ctx, int64(profileCountBeforeFilters-profileCountAfterFilters))
+
+	if errors != nil {
+		fpp.logger.Error("failed processing profiles", zap.Error(errors))
+		return pd, errors
+	}
+	if pd.ResourceProfiles().Len() == 0 {
+		return pd, processorhelper.ErrSkipProcessingData
+	}
+	return pd, nil
+}
+
+func profileCount(pd pprofile.Profiles) int {
+	count := 0
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		sps := pd.ResourceProfiles().At(i).ScopeProfiles()
+		for j := 0; j < sps.Len(); j++ {
+			count += sps.At(j).Profiles().Len()
+		}
+	}
+	return count
+}
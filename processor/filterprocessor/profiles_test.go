@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadatatest"
+)
+
+var (
+	testProfileTime   = pcommon.NewTimestampFromTime(time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC))
+	testProfileOneID  = pprofile.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	testProfileTwoID  = pprofile.ProfileID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	testProfileOneDur = pcommon.Timestamp(500 * time.Millisecond)
+	testProfileTwoDur = pcommon.Timestamp(2 * time.Second)
+)
+
+func TestFilterProfileProcessorWithOTTL(t *testing.T) {
+	tests := []struct {
+		name             string
+		conditions       []string
+		filterEverything bool
+		want             func(pd pprofile.Profiles)
+		errorMode        ottl.ErrorMode
+	}{
+		{
+			name: "drop profiles",
+			conditions: []string{
+				`duration < Duration("1s")`,
+			},
+			want: func(pd pprofile.Profiles) {
+				sps := pd.ResourceProfiles().At(0).ScopeProfiles()
+				for i := 0; i < sps.Len(); i++ {
+					sps.At(i).Profiles().RemoveIf(func(p pprofile.Profile) bool {
+						return p.ProfileID() == testProfileOneID
+					})
+				}
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop everything by dropping all profiles",
+			conditions: []string{
+				`resource.attributes["host.name"] == "localhost"`,
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "multiple conditions",
+			conditions: []string{
+				`instrumentation_scope.name == "wrong name"`,
+				`IsMatch(instrumentation_scope.name, "scope.*")`,
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "with error conditions",
+			conditions: []string{
+				`Substring("", 0, 100) == "test"`,
+			},
+			want:      func(_ pprofile.Profiles) {},
+			errorMode: ottl.IgnoreError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := newFilterProfilesProcessor(processortest.NewNopSettings(metadata.Type), &Config{Profiles: ProfileFilters{ProfileConditions: tt.conditions}, ErrorMode: tt.errorMode})
+			assert.NoError(t, err)
+
+			got, err := processor.processProfiles(context.Background(), constructProfiles())
+
+			if tt.filterEverything {
+				assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+			} else {
+				exPd := constructProfiles()
+				tt.want(exPd)
+				assert.Equal(t, exPd, got)
+			}
+		})
+	}
+}
+
+func TestFilterProfileProcessorTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	processor, err := newFilterProfilesProcessor(metadatatest.NewSettings(tel), &Config{
+		Profiles: ProfileFilters{ProfileConditions: []string{`duration > Duration("1s")`}},
+	})
+	assert.NoError(t, err)
+
+	_, err = processor.processProfiles(context.Background(), constructProfiles())
+	assert.NoError(t, err)
+
+	metadatatest.AssertEqualProcessorFilterProfilesFiltered(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value:      2,
+			Attributes: attribute.NewSet(attribute.String("filter", "filter")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func constructProfiles() pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	rp0 := pd.ResourceProfiles().AppendEmpty()
+	rp0.Resource().Attributes().PutStr("host.name", "localhost")
+	rp0sp0 := rp0.ScopeProfiles().AppendEmpty()
+	rp0sp0.Scope().SetName("scope1")
+	fillProfile(rp0sp0.Profiles().AppendEmpty(), testProfileOneID, testProfileOneDur)
+	fillProfile(rp0sp0.Profiles().AppendEmpty(), testProfileTwoID, testProfileTwoDur)
+	rp0sp1 := rp0.ScopeProfiles().AppendEmpty()
+	rp0sp1.Scope().SetName("scope2")
+	fillProfile(rp0sp1.Profiles().AppendEmpty(), testProfileOneID, testProfileOneDur)
+	fillProfile(rp0sp1.Profiles().AppendEmpty(), testProfileTwoID, testProfileTwoDur)
+	return pd
+}
+
+func fillProfile(profile pprofile.Profile, id pprofile.ProfileID, duration pcommon.Timestamp) {
+	profile.SetProfileID(id)
+	profile.SetTime(testProfileTime)
+	profile.SetDuration(duration)
+}
@@ -38,10 +38,24 @@ func newFilterTelemetry(set processor.Settings, signal pipeline.Signal) (*filter
 		return nil, fmt.Errorf("unsupported signal type: %v", signal)
 	}
 
+	return newFilterTelemetryWithCounter(set, counter), nil
+}
+
+// newFilterProfilesTelemetry creates the telemetry of the profiles processor, whose signal is
+// not part of the stable pipeline signals handled by newFilterTelemetry.
+func newFilterProfilesTelemetry(set processor.Settings) (*filterTelemetry, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return newFilterTelemetryWithCounter(set, telemetryBuilder.ProcessorFilterProfilesFiltered), nil
+}
+
+func newFilterTelemetryWithCounter(set processor.Settings, counter metric.Int64Counter) *filterTelemetry {
 	return &filterTelemetry{
 		attr:    metric.WithAttributeSet(attribute.NewSet(attribute.String(metadata.Type.String(), set.ID.String()))),
 		counter: counter,
-	}, nil
+	}
 }
 
 func (fpt *filterTelemetry) record(ctx context.Context, dropped int64) {
@@ -19,11 +19,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 )
 
 type filterSpanProcessor struct {
 	skipSpanExpr      expr.BoolExpr[ottlspan.TransformContext]
 	skipSpanEventExpr expr.BoolExpr[ottlspanevent.TransformContext]
+	skipSpanLinkExpr  expr.BoolExpr[ottlspanlink.TransformContext]
 	telemetry         *filterTelemetry
 	logger            *zap.Logger
 }
@@ -40,15 +42,37 @@ func newFilterSpansProcessor(set processor.Settings, cfg *Config) (*filterSpanPr
 	}
 	fsp.telemetry = fpt
 
-	if cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil {
+	if cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil || cfg.Traces.SpanLinkConditions != nil {
+		userFunctions, err := cfg.newUserFunctions()
+		if err != nil {
+			return nil, err
+		}
 		if cfg.Traces.SpanConditions != nil {
-			fsp.skipSpanExpr, err = filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			conditions, err := userFunctions.ExpandConditions(cfg.Traces.SpanConditions)
+			if err != nil {
+				return nil, err
+			}
+			fsp.skipSpanExpr, err = filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 		if cfg.Traces.SpanEventConditions != nil {
-			fsp.skipSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			conditions, err := userFunctions.ExpandConditions(cfg.Traces.SpanEventConditions)
+			if err != nil {
+				return nil, err
+			}
+			fsp.skipSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(conditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Traces.SpanLinkConditions != nil {
+			conditions, err := userFunctions.ExpandConditions(cfg.Traces.SpanLinkConditions)
+			if err != nil {
+				return nil, err
+			}
+			fsp.skipSpanLinkExpr, err = filterottl.NewBoolExprForSpanLink(conditions, filterottl.StandardSpanLinkFuncs(), cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
@@ -81,7 +105,7 @@ func newFilterSpansProcessor(set processor.Settings, cfg *Config) (*filterSpanPr
 
 // processTraces filters the given spans of a traces based off the filterSpanProcessor's filters.
 func (fsp *filterSpanProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	if fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil {
+	if fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil && fsp.skipSpanLinkExpr == nil {
 		return td, nil
 	}
 
@@ -113,6 +137,16 @@ func (fsp *filterSpanProcessor) processTraces(ctx context.Context, td ptrace.Tra
 						return skip
 					})
 				}
+				if fsp.skipSpanLinkExpr != nil {
+					span.Links().RemoveIf(func(spanLink ptrace.SpanLink) bool {
+						skip, err := fsp.skipSpanLinkExpr.Eval(ctx, ottlspanlink.NewTransformContext(spanLink, span, scope, resource, ss, rs))
+						if err != nil {
+							errors = multierr.Append(errors, err)
+							return false
+						}
+						return skip
+					})
+				}
 				return false
 			})
 			return ss.Spans().Len() == 0
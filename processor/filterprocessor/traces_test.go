@@ -245,6 +245,23 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 			},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "drop span links",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`dropped_attributes_count == 4 and span.name == "operationB"`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().RemoveIf(func(_ ptrace.SpanLink) bool {
+					return true
+				})
+				td.ResourceSpans().At(0).ScopeSpans().At(1).Spans().At(1).Links().RemoveIf(func(_ ptrace.SpanLink) bool {
+					return true
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "multiple conditions",
 			conditions: TraceFilters{
@@ -285,6 +302,37 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 	}
 }
 
+func TestFilterTraceProcessorWithOTTLUserFunctions(t *testing.T) {
+	cfg := &Config{
+		ErrorMode: ottl.IgnoreError,
+		Traces: TraceFilters{
+			SpanConditions: []string{
+				`IsOperation(name, "A")`,
+			},
+		},
+		UserFunctions: []ottl.UserFunction{
+			{
+				Name:       "IsOperation",
+				Params:     []string{"value", "suffix"},
+				Expression: `value == Concat(["operation", suffix], "")`,
+			},
+		},
+	}
+	processor, err := newFilterSpansProcessor(processortest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+
+	got, err := processor.processTraces(context.Background(), constructTraces())
+	assert.NoError(t, err)
+
+	exTd := constructTraces()
+	for i := 0; i < exTd.ResourceSpans().At(0).ScopeSpans().Len(); i++ {
+		exTd.ResourceSpans().At(0).ScopeSpans().At(i).Spans().RemoveIf(func(span ptrace.Span) bool {
+			return span.Name() == "operationA"
+		})
+	}
+	assert.Equal(t, exTd, got)
+}
+
 func TestFilterTraceProcessorTelemetry(t *testing.T) {
 	tel := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
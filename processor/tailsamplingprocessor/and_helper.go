@@ -9,11 +9,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
 
-func getNewAndPolicy(settings component.TelemetrySettings, config *AndCfg) (sampling.PolicyEvaluator, error) {
+func getNewAndPolicy(settings component.TelemetrySettings, policyName string, config *AndCfg) (sampling.PolicyEvaluator, error) {
 	subPolicyEvaluators := make([]sampling.PolicyEvaluator, len(config.SubPolicyCfg))
 	for i := range config.SubPolicyCfg {
 		policyCfg := &config.SubPolicyCfg[i]
-		policy, err := getAndSubPolicyEvaluator(settings, policyCfg)
+		policy, err := getAndSubPolicyEvaluator(settings, subPolicyName(policyName, policyCfg.Name), policyCfg)
 		if err != nil {
 			return nil, err
 		}
@@ -22,7 +22,12 @@ func getNewAndPolicy(settings component.TelemetrySettings, config *AndCfg) (samp
 	return sampling.NewAnd(settings.Logger, subPolicyEvaluators), nil
 }
 
+// subPolicyName returns the name a sub-policy is reported with in the metrics of the processor.
+func subPolicyName(policyName, name string) string {
+	return policyName + "." + name
+}
+
 // Return instance of and sub-policy
-func getAndSubPolicyEvaluator(settings component.TelemetrySettings, cfg *AndSubPolicyCfg) (sampling.PolicyEvaluator, error) {
-	return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
+func getAndSubPolicyEvaluator(settings component.TelemetrySettings, policyName string, cfg *AndSubPolicyCfg) (sampling.PolicyEvaluator, error) {
+	return getSharedPolicyEvaluator(settings, policyName, &cfg.sharedPolicyCfg)
 }
@@ -16,7 +16,7 @@ import (
 
 func TestAndHelper(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
-		actual, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), &AndCfg{
+		actual, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), "test-policy", &AndCfg{
 			SubPolicyCfg: []AndSubPolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -36,7 +36,7 @@ func TestAndHelper(t *testing.T) {
 	})
 
 	t.Run("unsupported sampling policy type", func(t *testing.T) {
-		_, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), &AndCfg{
+		_, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), "test-policy", &AndCfg{
 			SubPolicyCfg: []AndSubPolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
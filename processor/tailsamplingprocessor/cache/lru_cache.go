@@ -19,7 +19,7 @@ type lruDecisionCache[V any] struct {
 	cache *lru.Cache[uint64, V]
 }
 
-var _ Cache[any] = (*lruDecisionCache[any])(nil)
+var _ KeysCache[any] = (*lruDecisionCache[any])(nil)
 
 // NewLRUDecisionCache returns a new lruDecisionCache.
 // The size parameter indicates the amount of keys the cache will hold before it
@@ -43,6 +43,17 @@ func (c *lruDecisionCache[V]) Put(id pcommon.TraceID, v V) {
 // Delete is no-op since LRU relies on least recently used key being evicting automatically
 func (c *lruDecisionCache[V]) Delete(_ pcommon.TraceID) {}
 
+// Keys returns the ids held by the cache. As the cache is keyed by the right half of the trace IDs,
+// the left half of the returned ids is zero.
+func (c *lruDecisionCache[V]) Keys() []pcommon.TraceID {
+	keys := c.cache.Keys()
+	ids := make([]pcommon.TraceID, len(keys))
+	for i, key := range keys {
+		binary.LittleEndian.PutUint64(ids[i][8:], key)
+	}
+	return ids
+}
+
 func rightHalfTraceID(id pcommon.TraceID) uint64 {
 	return binary.LittleEndian.Uint64(id[8:])
 }
@@ -81,3 +81,28 @@ func traceIDFromHex(idStr string) (pcommon.TraceID, error) {
 	_, err := hex.Decode(id[:], []byte(idStr))
 	return id, err
 }
+
+func TestKeys(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+	id1, err := traceIDFromHex("12341234123412341234123412341231")
+	require.NoError(t, err)
+	id2, err := traceIDFromHex("12341234123412341234123412341232")
+	require.NoError(t, err)
+
+	c.Put(id1, true)
+	c.Put(id2, true)
+
+	keys := c.(KeysCache[bool]).Keys()
+	require.Len(t, keys, 2)
+
+	restored, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+	for _, key := range keys {
+		restored.Put(key, true)
+	}
+	_, ok := restored.Get(id1)
+	assert.True(t, ok)
+	_, ok = restored.Get(id2)
+	assert.True(t, ok)
+}
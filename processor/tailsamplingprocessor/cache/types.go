@@ -15,3 +15,12 @@ type Cache[V any] interface {
 	// Delete deletes the value for the given id
 	Delete(id pcommon.TraceID)
 }
+
+// KeysCache is a Cache able to list its keys. The decision caches implementing it are saved to the
+// storage extension of the processor on shutdown, and restored on start.
+type KeysCache[V any] interface {
+	Cache[V]
+	// Keys returns the ids held by the cache. The ids only need to be equal to the ids put in
+	// the cache for the bytes used as key by the cache.
+	Keys() []pcommon.TraceID
+}
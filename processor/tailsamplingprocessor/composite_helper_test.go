@@ -16,7 +16,7 @@ import (
 
 func TestCompositeHelper(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
-		actual, err := getNewCompositePolicy(componenttest.NewNopTelemetrySettings(), &CompositeCfg{
+		actual, err := getNewCompositePolicy(componenttest.NewNopTelemetrySettings(), "test-policy", &CompositeCfg{
 			MaxTotalSpansPerSecond: 1000,
 			PolicyOrder:            []string{"test-composite-policy-1"},
 			SubPolicyCfg: []CompositeSubPolicyCfg{
@@ -64,7 +64,7 @@ func TestCompositeHelper(t *testing.T) {
 	})
 
 	t.Run("unsupported sampling policy type", func(t *testing.T) {
-		_, err := getNewCompositePolicy(componenttest.NewNopTelemetrySettings(), &CompositeCfg{
+		_, err := getNewCompositePolicy(componenttest.NewNopTelemetrySettings(), "test-policy", &CompositeCfg{
 			SubPolicyCfg: []CompositeSubPolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -6,6 +6,8 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -43,6 +45,12 @@ const (
 	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
 	// conditions.
 	OTTLCondition PolicyType = "ottl_condition"
+	// Adaptive samples a target number of traces per second for each key, adjusting the
+	// sampling probability of each key from its observed volume.
+	Adaptive PolicyType = "adaptive"
+	// Drop drops the traces matching all its sub-policies, evaluated before the other policies.
+	// A match overrides the decisions of all the other policies.
+	Drop PolicyType = "drop"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -72,6 +80,8 @@ type sharedPolicyCfg struct {
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for OTTL condition filter sampling policy evaluator
 	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// Configs for adaptive sampling policy evaluator.
+	AdaptiveCfg AdaptiveCfg `mapstructure:"adaptive"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -100,6 +110,11 @@ type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
 
+// DropCfg holds the common configuration to all drop policies.
+type DropCfg struct {
+	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"drop_sub_policy"`
+}
+
 // CompositeCfg holds the configurable settings to create a composite
 // sampling policy evaluator.
 type CompositeCfg struct {
@@ -123,6 +138,8 @@ type PolicyCfg struct {
 	CompositeCfg CompositeCfg `mapstructure:"composite"`
 	// Configs for defining and policy
 	AndCfg AndCfg `mapstructure:"and"`
+	// Configs for defining drop policy
+	DropCfg DropCfg `mapstructure:"drop"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -154,7 +171,9 @@ type NumericAttributeCfg struct {
 type ProbabilisticCfg struct {
 	// HashSalt allows one to configure the hashing salts. This is important in scenarios where multiple layers of collectors
 	// have different sampling rates: if they use the same salt all passing one layer may pass the other even if they have
-	// different sampling rates, configuring different salts avoids that.
+	// different sampling rates, configuring different salts avoids that. With the consistentsampling feature gate, the trace
+	// ID is only hashed when a salt is set, instead of using the randomness of the trace, which is not consistent with the
+	// other OpenTelemetry samplers.
 	HashSalt string `mapstructure:"hash_salt"`
 	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
 	// Values greater or equal 100 are treated as "sample all traces".
@@ -224,6 +243,25 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// AdaptiveCfg holds the configurable settings to create an adaptive sampling policy evaluator.
+type AdaptiveCfg struct {
+	// KeyAttributes are the attributes whose values identify the key of a trace. They are read from
+	// the root span of the trace, or from its resource.
+	KeyAttributes []string `mapstructure:"key_attributes"`
+	// IncludeRootSpanName adds the name of the root span of the trace to its key.
+	IncludeRootSpanName bool `mapstructure:"include_root_span_name"`
+	// TargetTracesPerSecond is the number of traces per second to sample for each key.
+	TargetTracesPerSecond float64 `mapstructure:"target_traces_per_second"`
+	// MinTracesPerSecond is the number of traces per second always sampled for each key, whatever its
+	// sampling probability. Defaults to 0, i.e.: no floor.
+	MinTracesPerSecond float64 `mapstructure:"min_traces_per_second"`
+	// AdjustmentInterval is the interval at which the sampling probabilities are adjusted. Defaults to 10s.
+	AdjustmentInterval time.Duration `mapstructure:"adjustment_interval"`
+	// MaxKeys is the maximum number of keys tracked, the traces of additional keys share the same
+	// sampling probability. Defaults to 1000.
+	MaxKeys int `mapstructure:"max_keys"`
+}
+
 type DecisionCacheConfig struct {
 	// SampledCacheSize specifies the size of the cache that holds the sampled trace IDs.
 	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
@@ -237,6 +275,20 @@ type DecisionCacheConfig struct {
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
 }
 
+// PolicySourceConfig holds the configuration of the sources of the sampling policies loaded at runtime.
+// Both sources can be used at once, in which case the last policies received are applied. The policies
+// are described with the same fields as in the configuration, under a `policies` key, along with an
+// optional `version` recorded in the telemetry of the decisions made with them.
+type PolicySourceConfig struct {
+	// File is the path to a YAML file with the sampling policies, reloaded whenever its content changes.
+	File string `mapstructure:"file"`
+	// WatchInterval is the interval at which File is checked for changes. Defaults to 10s.
+	WatchInterval time.Duration `mapstructure:"watch_interval"`
+	// OpAMP is the ID of the OpAMP extension the sampling policies are pushed through, as custom
+	// messages of the org.opentelemetry.collector.processor.tailsampling capability.
+	OpAMP *component.ID `mapstructure:"opamp"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -253,6 +305,17 @@ type Config struct {
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
 	// DecisionCache holds configuration for the decision cache(s)
 	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+	// Storage is the ID of the storage extension the span batches of pending traces are spilled to
+	// beyond MaxSpansInMemory. The pending traces and the decision caches are saved to it on shutdown
+	// and restored on start. If left empty, all the spans are kept in memory.
+	Storage *component.ID `mapstructure:"storage"`
+	// MaxSpansInMemory is the number of spans of pending traces kept in memory before new batches are
+	// spilled to the storage extension. It is only used when Storage is set, in which case the default
+	// 0 spills every batch.
+	MaxSpansInMemory uint64 `mapstructure:"max_spans_in_memory"`
+	// PolicySource configures where the sampling policies are loaded from at runtime, replacing
+	// PolicyCfgs without restarting the collector. If left empty, the policies never change.
+	PolicySource *PolicySourceConfig `mapstructure:"policy_source"`
 	// Options allows for additional configuration of the tail-based sampling processor in code.
 	Options []Option `mapstructure:"-"`
 }
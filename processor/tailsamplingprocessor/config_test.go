@@ -117,6 +117,20 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-12",
+						Type: Adaptive,
+						AdaptiveCfg: AdaptiveCfg{
+							KeyAttributes:         []string{"service.name"},
+							IncludeRootSpanName:   true,
+							TargetTracesPerSecond: 10,
+							MinTracesPerSecond:    0.1,
+							AdjustmentInterval:    30 * time.Second,
+							MaxKeys:               500,
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -183,6 +197,88 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "drop-policy-1",
+						Type: Drop,
+					},
+					DropCfg: DropCfg{
+						SubPolicyCfg: []AndSubPolicyCfg{
+							{
+								sharedPolicyCfg: sharedPolicyCfg{
+									Name:               "test-drop-policy-1",
+									Type:               StringAttribute,
+									StringAttributeCfg: StringAttributeCfg{Key: "url.path", Values: []string{"/health", "/ready"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, cfg)
+}
+
+func TestLoadStorageConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "tail_sampling_storage_config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	storageID := component.MustNewID("file_storage")
+	assert.Equal(t,
+		&Config{
+			DecisionWait:     10 * time.Second,
+			NumTraces:        100,
+			Storage:          &storageID,
+			MaxSpansInMemory: 100_000,
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-1",
+						Type: AlwaysSample,
+					},
+				},
+			},
+		}, cfg)
+}
+
+func TestLoadPolicySourceConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "tail_sampling_policy_source_config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	opampID := component.MustNewID("opamp")
+	assert.Equal(t,
+		&Config{
+			DecisionWait: 10 * time.Second,
+			NumTraces:    100,
+			PolicySource: &PolicySourceConfig{
+				File:          "/etc/otelcol/sampling_policies.yaml",
+				WatchInterval: 30 * time.Second,
+				OpAMP:         &opampID,
+			},
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-1",
+						Type: AlwaysSample,
+					},
+				},
 			},
 		}, cfg)
 }
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func getNewDropPolicy(settings component.TelemetrySettings, policyName string, config *DropCfg) (sampling.PolicyEvaluator, error) {
+	// A drop policy without sub-policies would drop all the traces.
+	if len(config.SubPolicyCfg) == 0 {
+		return nil, errors.New("drop policy must have at least one sub-policy")
+	}
+	subPolicyEvaluators := make([]sampling.PolicyEvaluator, len(config.SubPolicyCfg))
+	for i := range config.SubPolicyCfg {
+		policyCfg := &config.SubPolicyCfg[i]
+		// The traces an adaptive policy would sample are dropped, so the probabilities it computes
+		// would be meaningless.
+		if policyCfg.Type == Adaptive {
+			return nil, fmt.Errorf("drop sub-policy %q cannot be an adaptive policy", policyCfg.Name)
+		}
+		policy, err := getAndSubPolicyEvaluator(settings, subPolicyName(policyName, policyCfg.Name), policyCfg)
+		if err != nil {
+			return nil, err
+		}
+		subPolicyEvaluators[i] = policy
+	}
+	return sampling.NewDrop(settings.Logger, subPolicyEvaluators), nil
+}
@@ -6,13 +6,18 @@ require (
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da
 	github.com/google/uuid v1.6.0
 	github.com/hashicorp/golang-lru/v2 v2.0.7
+	github.com/open-telemetry/opamp-go v0.19.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages v0.121.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.121.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.121.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.121.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl v0.121.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling v0.121.0
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/collector/component v1.27.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/confmap v1.27.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/consumer v1.27.1-0.20250313100724-0885401136ff
+	go.opentelemetry.io/collector/extension/xextension v0.121.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/featuregate v1.27.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/pdata v1.27.1-0.20250313100724-0885401136ff
 	go.opentelemetry.io/collector/processor v0.121.1-0.20250313100724-0885401136ff
@@ -62,6 +67,7 @@ require (
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/component/componentstatus v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/consumer/xconsumer v0.121.1-0.20250313100724-0885401136ff // indirect
+	go.opentelemetry.io/collector/extension v1.27.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.121.1-0.20250313100724-0885401136ff // indirect
 	go.opentelemetry.io/collector/pipeline v0.121.1-0.20250313100724-0885401136ff // indirect
@@ -96,3 +102,9 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/filte
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal => ../../internal/coreinternal
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden => ../../pkg/golden
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../../extension/storage
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling => ../../pkg/sampling
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages => ../../extension/opampcustommessages
@@ -3,10 +3,12 @@
 package metadata
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
@@ -26,7 +28,9 @@ type TelemetryBuilder struct {
 	meter                                               metric.Meter
 	mu                                                  sync.Mutex
 	registrations                                       []metric.Registration
+	ProcessorTailSamplingAdaptiveSamplingProbability    metric.Float64ObservableGauge
 	ProcessorTailSamplingCountSpansSampled              metric.Int64Counter
+	ProcessorTailSamplingCountTracesDropped             metric.Int64Counter
 	ProcessorTailSamplingCountTracesSampled             metric.Int64Counter
 	ProcessorTailSamplingEarlyReleasesFromCacheDecision metric.Int64Counter
 	ProcessorTailSamplingGlobalCountTracesSampled       metric.Int64Counter
@@ -51,6 +55,31 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterProcessorTailSamplingAdaptiveSamplingProbabilityCallback sets callback for observable ProcessorTailSamplingAdaptiveSamplingProbability metric.
+func (builder *TelemetryBuilder) RegisterProcessorTailSamplingAdaptiveSamplingProbabilityCallback(cb metric.Float64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerFloat64{inst: builder.ProcessorTailSamplingAdaptiveSamplingProbability, obs: o})
+		return nil
+	}, builder.ProcessorTailSamplingAdaptiveSamplingProbability)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+type observerFloat64 struct {
+	embedded.Float64Observer
+	inst metric.Float64Observable
+	obs  metric.Observer
+}
+
+func (oi *observerFloat64) Observe(value float64, opts ...metric.ObserveOption) {
+	oi.obs.ObserveFloat64(oi.inst, value, opts...)
+}
+
 // Shutdown unregister all registered callbacks for async instruments.
 func (builder *TelemetryBuilder) Shutdown() {
 	builder.mu.Lock()
@@ -69,12 +98,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ProcessorTailSamplingAdaptiveSamplingProbability, err = builder.meter.Float64ObservableGauge(
+		"otelcol_processor_tail_sampling_adaptive_sampling_probability",
+		metric.WithDescription("Sampling probability computed by an adaptive policy for a key"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorTailSamplingCountSpansSampled, err = builder.meter.Int64Counter(
 		"otelcol_processor_tail_sampling_count_spans_sampled",
 		metric.WithDescription("Count of spans that were sampled or not per sampling policy"),
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingCountTracesDropped, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_count_traces_dropped",
+		metric.WithDescription("Count of traces that were dropped per drop policy"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorTailSamplingCountTracesSampled, err = builder.meter.Int64Counter(
 		"otelcol_processor_tail_sampling_count_traces_sampled",
 		metric.WithDescription("Count of traces that were sampled or not per sampling policy"),
@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+const (
+	defaultAdjustmentInterval = 10 * time.Second
+	defaultMaxKeys            = 1000
+	// adaptiveSmoothing is the weight of the volume observed in the last interval in the
+	// smoothed volume of a key.
+	adaptiveSmoothing = 0.5
+	// minAdaptiveRate is the smoothed volume, in traces per second, under which an idle key is
+	// forgotten.
+	minAdaptiveRate = 0.001
+	// adaptiveThresholdPrecision is the number of hex digits of the thresholds of the adaptive
+	// policy.
+	adaptiveThresholdPrecision = 4
+	// overflowKey is the key of the traces once the maximum number of keys is tracked. It counts
+	// against the maximum number of keys.
+	overflowKey     = "overflow"
+	rootSpanNameKey = "root_span.name"
+)
+
+// adaptiveKey is the state of the traces of a key.
+type adaptiveKey struct {
+	// count is the number of traces evaluated in the current interval.
+	count int64
+	// rate is the smoothed number of traces per second, or negative until the first adjustment.
+	rate        float64
+	threshold   pkgsampling.Threshold
+	lastSampled time.Time
+}
+
+// ProbabilityObserver is implemented by the policy evaluators computing a sampling probability for
+// each key of the traces they track.
+type ProbabilityObserver interface {
+	// ObserveProbabilities reports the probability of each tracked key, as of the last adjustment.
+	ObserveProbabilities(observer metric.Float64Observer)
+}
+
+type adaptive struct {
+	logger              *zap.Logger
+	policyAttribute     attribute.KeyValue
+	keyAttributes       []string
+	includeRootSpanName bool
+	targetRate          float64
+	// minInterval is the longest time between two traces sampled for a key, 0 when there is no floor.
+	minInterval        time.Duration
+	adjustmentInterval time.Duration
+	maxKeys            int
+	keys               map[string]*adaptiveKey
+	lastAdjustment     time.Time
+	now                func() time.Time
+
+	// probabilitiesMux guards probabilities, observed outside of the evaluation of the traces.
+	probabilitiesMux sync.Mutex
+	// probabilities is the probability of each tracked key as of the last adjustment.
+	probabilities map[string]float64
+}
+
+var (
+	_ ThresholdPolicyEvaluator = (*adaptive)(nil)
+	_ ProbabilityObserver      = (*adaptive)(nil)
+)
+
+// NewAdaptive creates a policy evaluator sampling about targetTracesPerSecond traces per second
+// for each key, made of the values of the given resource or span attributes and optionally of the
+// name of the root span. The probability of each key is adjusted every adjustmentInterval from the
+// volume observed for the key, and at least minTracesPerSecond traces are sampled for each key. At
+// most maxKeys keys are tracked, including the overflow key shared by the traces of the other keys.
+func NewAdaptive(
+	settings component.TelemetrySettings,
+	policyName string,
+	keyAttributes []string,
+	includeRootSpanName bool,
+	targetTracesPerSecond float64,
+	minTracesPerSecond float64,
+	adjustmentInterval time.Duration,
+	maxKeys int,
+) (PolicyEvaluator, error) {
+	if targetTracesPerSecond <= 0 {
+		return nil, errors.New("target_traces_per_second must be greater than 0")
+	}
+	if minTracesPerSecond < 0 {
+		return nil, errors.New("min_traces_per_second must not be negative")
+	}
+	if len(keyAttributes) == 0 && !includeRootSpanName {
+		return nil, errors.New("expected at least one key attribute or the root span name")
+	}
+	if adjustmentInterval <= 0 {
+		adjustmentInterval = defaultAdjustmentInterval
+	}
+	if maxKeys <= 0 {
+		maxKeys = defaultMaxKeys
+	}
+	var minInterval time.Duration
+	if minTracesPerSecond > 0 {
+		minInterval = time.Duration(float64(time.Second) / minTracesPerSecond)
+	}
+
+	return &adaptive{
+		logger:              settings.Logger,
+		policyAttribute:     attribute.String("policy", policyName),
+		keyAttributes:       keyAttributes,
+		includeRootSpanName: includeRootSpanName,
+		targetRate:          targetTracesPerSecond,
+		minInterval:         minInterval,
+		adjustmentInterval:  adjustmentInterval,
+		maxKeys:             maxKeys,
+		keys:                make(map[string]*adaptiveKey),
+		lastAdjustment:      time.Now(),
+		now:                 time.Now,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (a *adaptive) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := a.EvaluateThreshold(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateThreshold looks at the trace data and returns a corresponding SamplingDecision, along
+// with the threshold of the probability of the key of the trace. Once no trace of a key was sampled
+// for the interval of its floor, the next trace of the key is sampled whatever its randomness, with
+// AlwaysSampleThreshold: its adjusted count is 1, as it was certain to be sampled.
+func (a *adaptive) EvaluateThreshold(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error) {
+	a.logger.Debug("Evaluating spans in adaptive filter")
+
+	now := a.now()
+	if now.Sub(a.lastAdjustment) >= a.adjustmentInterval {
+		a.adjust(now)
+	}
+
+	key := a.traceKey(trace)
+	entry, ok := a.keys[key]
+	if !ok {
+		limit := a.maxKeys
+		if _, tracked := a.keys[overflowKey]; !tracked {
+			// Room is kept for the overflow key.
+			limit--
+		}
+		if len(a.keys) >= limit {
+			key = overflowKey
+			entry, ok = a.keys[key]
+		}
+		if !ok {
+			// Until the volume of a new key is known, all its traces are sampled.
+			entry = &adaptiveKey{rate: -1}
+			a.keys[key] = entry
+		}
+	}
+	entry.count++
+
+	// The floor is checked first, so that a trace whose randomness passes the threshold isn't given
+	// the adjusted count of the threshold while it would have been sampled anyway.
+	if a.minInterval > 0 && now.Sub(entry.lastSampled) >= a.minInterval {
+		entry.lastSampled = now
+		return Sampled, pkgsampling.AlwaysSampleThreshold, nil
+	}
+	if entry.threshold.ShouldSample(traceRandomness(traceID, trace)) {
+		entry.lastSampled = now
+		return Sampled, entry.threshold, nil
+	}
+	return NotSampled, pkgsampling.NeverSampleThreshold, nil
+}
+
+// adjust computes the probability of each key from the volume observed since the last adjustment.
+// The idle keys are forgotten, and no longer reported.
+func (a *adaptive) adjust(now time.Time) {
+	elapsed := now.Sub(a.lastAdjustment).Seconds()
+	a.lastAdjustment = now
+
+	probabilities := make(map[string]float64, len(a.keys))
+	for key, entry := range a.keys {
+		observed := float64(entry.count) / elapsed
+		entry.count = 0
+		if entry.rate < 0 {
+			entry.rate = observed
+		} else {
+			entry.rate = adaptiveSmoothing*observed + (1-adaptiveSmoothing)*entry.rate
+		}
+		if entry.rate < minAdaptiveRate {
+			delete(a.keys, key)
+			continue
+		}
+
+		probability := 1.0
+		if entry.rate > a.targetRate {
+			probability = a.targetRate / entry.rate
+		}
+		entry.threshold = probabilityToThreshold(probability, adaptiveThresholdPrecision)
+		probabilities[key] = probability
+	}
+
+	a.probabilitiesMux.Lock()
+	a.probabilities = probabilities
+	a.probabilitiesMux.Unlock()
+}
+
+// ObserveProbabilities reports the probability of each key tracked at the last adjustment, so at
+// most maxKeys series per policy.
+func (a *adaptive) ObserveProbabilities(observer metric.Float64Observer) {
+	a.probabilitiesMux.Lock()
+	defer a.probabilitiesMux.Unlock()
+	for key, probability := range a.probabilities {
+		observer.Observe(probability, metric.WithAttributes(a.policyAttribute, attribute.String("key", key)))
+	}
+}
+
+// traceKey returns the key of the trace, made of the values of the key attributes and of the name of
+// its root span. The attributes are read from the root span and its resource, or from the first span
+// when the root span wasn't received.
+func (a *adaptive) traceKey(trace *TraceData) string {
+	trace.Lock()
+	defer trace.Unlock()
+
+	span, resource, ok := rootSpan(trace.ReceivedBatches)
+	var parts []string
+	for _, attr := range a.keyAttributes {
+		var value string
+		if ok {
+			if v, found := span.Attributes().Get(attr); found {
+				value = v.AsString()
+			} else if v, found := resource.Attributes().Get(attr); found {
+				value = v.AsString()
+			}
+		}
+		parts = append(parts, attr+"="+value)
+	}
+	if a.includeRootSpanName {
+		var name string
+		if ok && span.ParentSpanID().IsEmpty() {
+			name = span.Name()
+		}
+		parts = append(parts, rootSpanNameKey+"="+name)
+	}
+	return strings.Join(parts, ",")
+}
+
+// rootSpan returns the root span of the trace and its resource, or the first span if the root span
+// wasn't received.
+func rootSpan(td ptrace.Traces) (ptrace.Span, pcommon.Resource, bool) {
+	var first ptrace.Span
+	var firstResource pcommon.Resource
+	found := false
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.ParentSpanID().IsEmpty() {
+					return span, rs.Resource(), true
+				}
+				if !found {
+					first, firstResource, found = span, rs.Resource(), true
+				}
+			}
+		}
+	}
+	return first, firstResource, found
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadatatest"
+)
+
+func newAdaptiveTrace(service string, rootSpanName string) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", service)
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	child := spans.AppendEmpty()
+	child.SetName("child")
+	child.SetParentSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	root := spans.AppendEmpty()
+	root.SetName(rootSpanName)
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
+
+func newTestAdaptive(t *testing.T, settings componenttest.Telemetry, minTracesPerSecond float64) (*adaptive, *time.Time) {
+	evaluator, err := NewAdaptive(settings.NewTelemetrySettings(), "adaptive", []string{"service.name"}, true, 1, minTracesPerSecond, 10*time.Second, 0)
+	require.NoError(t, err)
+	a := evaluator.(*adaptive)
+	telemetry, err := metadata.NewTelemetryBuilder(settings.NewTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(telemetry.Shutdown)
+	require.NoError(t, telemetry.RegisterProcessorTailSamplingAdaptiveSamplingProbabilityCallback(func(_ context.Context, observer metric.Float64Observer) error {
+		a.ObserveProbabilities(observer)
+		return nil
+	}))
+	now := a.lastAdjustment
+	a.now = func() time.Time {
+		return now
+	}
+	return a, &now
+}
+
+func TestAdaptiveProbabilities(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	a, now := newTestAdaptive(t, *tel, 0)
+
+	// Until the first adjustment, all the traces are sampled.
+	frequent := newAdaptiveTrace("checkout", "GET /cart")
+	rare := newAdaptiveTrace("checkout", "POST /order")
+	for _, traceID := range genRandomTraceIDs(100) {
+		decision, err := a.Evaluate(context.Background(), traceID, frequent)
+		require.NoError(t, err)
+		assert.Equal(t, Sampled, decision)
+	}
+	decision, err := a.Evaluate(context.Background(), genRandomTraceIDs(1)[0], rare)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// 10 traces per second were observed for the frequent key, to sample 1 per second.
+	*now = now.Add(10 * time.Second)
+	sampled := 0
+	traceIDs := genRandomTraceIDs(10_000)
+	for _, traceID := range traceIDs {
+		decision, threshold, err := a.EvaluateThreshold(context.Background(), traceID, frequent)
+		require.NoError(t, err)
+		if decision == Sampled {
+			sampled++
+			assert.InDelta(t, 10, threshold.AdjustedCount(), 0.01)
+		}
+	}
+	assert.InDelta(t, 0.1, float64(sampled)/float64(len(traceIDs)), 0.01)
+
+	decision, threshold, err := a.EvaluateThreshold(context.Background(), traceIDs[0], rare)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, pkgsampling.AlwaysSampleThreshold, threshold)
+
+	metadatatest.AssertEqualProcessorTailSamplingAdaptiveSamplingProbability(t, tel,
+		[]metricdata.DataPoint[float64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("policy", "adaptive"), attribute.String("key", "service.name=checkout,root_span.name=GET /cart")),
+				Value:      0.1,
+			},
+			{
+				Attributes: attribute.NewSet(attribute.String("policy", "adaptive"), attribute.String("key", "service.name=checkout,root_span.name=POST /order")),
+				Value:      1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestAdaptiveIdleKeysNotReported(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	a, now := newTestAdaptive(t, *tel, 0)
+
+	frequent := newAdaptiveTrace("checkout", "GET /cart")
+	idle := newAdaptiveTrace("checkout", "POST /order")
+	_, err := a.Evaluate(context.Background(), genRandomTraceIDs(1)[0], idle)
+	require.NoError(t, err)
+
+	// The idle key is forgotten once no trace of it is evaluated for a while, and its probability
+	// is no longer reported.
+	for i := 0; i < 20; i++ {
+		*now = now.Add(10 * time.Second)
+		_, err = a.Evaluate(context.Background(), genRandomTraceIDs(1)[0], frequent)
+		require.NoError(t, err)
+	}
+	assert.NotContains(t, a.keys, "service.name=checkout,root_span.name=POST /order")
+
+	metadatatest.AssertEqualProcessorTailSamplingAdaptiveSamplingProbability(t, tel,
+		[]metricdata.DataPoint[float64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("policy", "adaptive"), attribute.String("key", "service.name=checkout,root_span.name=GET /cart")),
+				Value:      1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestAdaptiveFloor(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	a, now := newTestAdaptive(t, *tel, 0.5)
+
+	trace := newAdaptiveTrace("checkout", "GET /cart")
+	for _, traceID := range genRandomTraceIDs(1000) {
+		_, err := a.Evaluate(context.Background(), traceID, trace)
+		require.NoError(t, err)
+	}
+	*now = now.Add(10 * time.Second)
+
+	// A trace with the lowest randomness is only sampled to keep the floor of the key.
+	traceID := pcommon.TraceID{}
+	decision, threshold, err := a.EvaluateThreshold(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, pkgsampling.AlwaysSampleThreshold, threshold)
+
+	decision, err = a.Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	*now = now.Add(2 * time.Second)
+	decision, err = a.Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestAdaptiveFloorAdjustedCount(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	a, now := newTestAdaptive(t, *tel, 2)
+
+	// 10 traces per second are evaluated, to sample 1 per second with a floor of 2 per second. After
+	// the first adjustment, the adjusted counts of the sampled traces add up to the number of
+	// evaluated traces.
+	trace := newAdaptiveTrace("checkout", "GET /cart")
+	seen := 0
+	adjustedCount := 0.0
+	for i, traceID := range genRandomTraceIDs(10_000) {
+		*now = now.Add(100 * time.Millisecond)
+		decision, threshold, err := a.EvaluateThreshold(context.Background(), traceID, trace)
+		require.NoError(t, err)
+		if i < 100 {
+			continue
+		}
+		seen++
+		if decision == Sampled {
+			adjustedCount += threshold.AdjustedCount()
+		}
+	}
+	assert.InEpsilon(t, seen, adjustedCount, 0.05)
+}
+
+func TestAdaptiveMaxKeys(t *testing.T) {
+	evaluator, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "adaptive", []string{"service.name"}, false, 1, 0, 0, 3)
+	require.NoError(t, err)
+	a := evaluator.(*adaptive)
+
+	// The overflow key counts against the maximum number of keys.
+	for _, service := range []string{"a", "b", "c", "d"} {
+		_, err = a.Evaluate(context.Background(), pcommon.TraceID{}, newAdaptiveTrace(service, "root"))
+		require.NoError(t, err)
+	}
+	assert.Len(t, a.keys, 3)
+	assert.EqualValues(t, 2, a.keys[overflowKey].count)
+
+	// Once the overflow key is tracked, new keys keep going to it.
+	_, err = a.Evaluate(context.Background(), pcommon.TraceID{}, newAdaptiveTrace("e", "root"))
+	require.NoError(t, err)
+	assert.Len(t, a.keys, 3)
+	assert.EqualValues(t, 3, a.keys[overflowKey].count)
+}
+
+func TestNewAdaptiveErrors(t *testing.T) {
+	settings := componenttest.NewNopTelemetrySettings()
+	_, err := NewAdaptive(settings, "adaptive", []string{"service.name"}, false, 0, 0, 0, 0)
+	assert.EqualError(t, err, "target_traces_per_second must be greater than 0")
+	_, err = NewAdaptive(settings, "adaptive", []string{"service.name"}, false, 1, -1, 0, 0)
+	assert.EqualError(t, err, "min_traces_per_second must not be negative")
+	_, err = NewAdaptive(settings, "adaptive", nil, false, 1, 0, 0, 0)
+	assert.EqualError(t, err, "expected at least one key attribute or the root span name")
+}
+
+func TestAdaptiveSubPolicyProbabilitiesReported(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	sub, err := NewAdaptive(tel.NewTelemetrySettings(), "composite.and.adaptive", []string{"service.name"}, false, 1, 0, 10*time.Second, 0)
+	require.NoError(t, err)
+	composite := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{
+		{Evaluator: NewAnd(zap.NewNop(), []PolicyEvaluator{sub}), MaxSpansPerSecond: 1000, Name: "and"},
+	}, MonotonicClock{}, false)
+	telemetry, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	defer telemetry.Shutdown()
+	require.NoError(t, telemetry.RegisterProcessorTailSamplingAdaptiveSamplingProbabilityCallback(func(_ context.Context, observer metric.Float64Observer) error {
+		composite.(ProbabilityObserver).ObserveProbabilities(observer)
+		return nil
+	}))
+
+	a := sub.(*adaptive)
+	now := a.lastAdjustment
+	a.now = func() time.Time {
+		return now
+	}
+	trace := newAdaptiveTrace("checkout", "GET /cart")
+	for _, traceID := range genRandomTraceIDs(100) {
+		_, err = a.Evaluate(context.Background(), traceID, trace)
+		require.NoError(t, err)
+	}
+	now = now.Add(10 * time.Second)
+	_, err = a.Evaluate(context.Background(), genRandomTraceIDs(1)[0], trace)
+	require.NoError(t, err)
+
+	// The probabilities of the adaptive policy are reported through the policies it is a sub-policy of.
+	metadatatest.AssertEqualProcessorTailSamplingAdaptiveSamplingProbability(t, tel,
+		[]metricdata.DataPoint[float64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("policy", "composite.and.adaptive"), attribute.String("key", "service.name=checkout")),
+				Value:      0.1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
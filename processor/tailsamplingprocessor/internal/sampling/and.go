@@ -7,7 +7,10 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 type And struct {
@@ -26,20 +29,36 @@ func NewAnd(
 	}
 }
 
+var (
+	_ ThresholdPolicyEvaluator = (*And)(nil)
+	_ ProbabilityObserver      = (*And)(nil)
+)
+
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
 func (c *And) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := c.EvaluateThreshold(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateThreshold looks at the trace data and returns a corresponding SamplingDecision. A sampled
+// trace is sampled with the greatest threshold of the sub-policies.
+func (c *And) EvaluateThreshold(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error) {
 	// The policy iterates over all sub-policies and returns Sampled if all sub-policies returned a Sampled Decision.
 	// If any subpolicy returns NotSampled or InvertNotSampled, it returns NotSampled Decision.
+	threshold := pkgsampling.AlwaysSampleThreshold
 	for _, sub := range c.subpolicies {
-		decision, err := sub.Evaluate(ctx, traceID, trace)
+		decision, subThreshold, err := EvaluateWithThreshold(ctx, sub, traceID, trace)
 		if err != nil {
-			return Unspecified, err
+			return Unspecified, pkgsampling.NeverSampleThreshold, err
 		}
 		if decision == NotSampled || decision == InvertNotSampled {
-			return NotSampled, nil
+			return NotSampled, pkgsampling.NeverSampleThreshold, nil
+		}
+		if pkgsampling.ThresholdGreater(subThreshold, threshold) {
+			threshold = subThreshold
 		}
 	}
-	return Sampled, nil
+	return Sampled, threshold, nil
 }
 
 // OnDroppedSpans is called when the trace needs to be dropped, due to memory
@@ -47,3 +66,12 @@ func (c *And) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *Trac
 func (c *And) OnDroppedSpans(pcommon.TraceID, *TraceData) (Decision, error) {
 	return Sampled, nil
 }
+
+// ObserveProbabilities reports the probabilities of the sub-policies computing one.
+func (c *And) ObserveProbabilities(observer metric.Float64Observer) {
+	for _, sub := range c.subpolicies {
+		if o, ok := sub.(ProbabilityObserver); ok {
+			o.ObserveProbabilities(observer)
+		}
+	}
+}
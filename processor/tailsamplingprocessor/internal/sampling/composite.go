@@ -7,7 +7,10 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 type subpolicy struct {
@@ -80,8 +83,20 @@ func NewComposite(
 	}
 }
 
+var (
+	_ ThresholdPolicyEvaluator = (*Composite)(nil)
+	_ ProbabilityObserver      = (*Composite)(nil)
+)
+
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
 func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := c.EvaluateThreshold(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateThreshold looks at the trace data and returns a corresponding SamplingDecision. A sampled
+// trace is sampled with the threshold of the sub-policy sampling it.
+func (c *Composite) EvaluateThreshold(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error) {
 	// Rate limiting works by counting spans that are sampled during each 1 second
 	// time period. Until the total number of spans during a particular second
 	// exceeds the allocated number of spans-per-second the traces are sampled,
@@ -100,9 +115,9 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 	}
 
 	for _, sub := range c.subpolicies {
-		decision, err := sub.evaluator.Evaluate(ctx, traceID, trace)
+		decision, threshold, err := EvaluateWithThreshold(ctx, sub.evaluator, traceID, trace)
 		if err != nil {
-			return Unspecified, err
+			return Unspecified, pkgsampling.NeverSampleThreshold, err
 		}
 
 		if decision == Sampled || decision == InvertSampled {
@@ -119,18 +134,18 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 				if c.recordSubPolicy {
 					SetAttrOnScopeSpans(trace, "tailsampling.composite_policy", sub.name)
 				}
-				return Sampled, nil
+				return Sampled, threshold, nil
 			}
 
 			// We exceeded the rate limit. Don't sample this trace.
 			// Note that we will continue evaluating new incoming traces against
 			// allocated SPS, we do not update sub.sampledSPS here in order to give
 			// chance to another smaller trace to be accepted later.
-			return NotSampled, nil
+			return NotSampled, pkgsampling.NeverSampleThreshold, nil
 		}
 	}
 
-	return NotSampled, nil
+	return NotSampled, pkgsampling.NeverSampleThreshold, nil
 }
 
 // OnDroppedSpans is called when the trace needs to be dropped, due to memory
@@ -150,3 +165,12 @@ func (c *Composite) OnDroppedSpans(pcommon.TraceID, *TraceData) (Decision, error
 	// should be a future task.
 	return Sampled, nil
 }
+
+// ObserveProbabilities reports the probabilities of the sub-policies computing one.
+func (c *Composite) ObserveProbabilities(observer metric.Float64Observer) {
+	for _, sub := range c.subpolicies {
+		if o, ok := sub.evaluator.(ProbabilityObserver); ok {
+			o.ObserveProbabilities(observer)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// Drop is an And policy deciding to drop the traces it would sample.
+type Drop struct {
+	and PolicyEvaluator
+}
+
+func NewDrop(
+	logger *zap.Logger,
+	subpolicies []PolicyEvaluator,
+) PolicyEvaluator {
+	return &Drop{
+		and: NewAnd(logger, subpolicies),
+	}
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (d *Drop) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	// The trace is dropped if all sub-policies returned a Sampled Decision.
+	decision, err := d.and.Evaluate(ctx, traceID, trace)
+	if err != nil {
+		return Unspecified, err
+	}
+	if decision == Sampled {
+		return Dropped, nil
+	}
+	return decision, nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newDropTestTrace(path string) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	ils := rs.ScopeSpans().AppendEmpty()
+
+	span := ils.Spans().AppendEmpty()
+	span.Attributes().PutStr("url.path", path)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
+
+func TestDropEvaluatorNotSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "url.path", []string{"/health"}, false, 0, false)
+	n2, err := NewStatusCodeFilter(componenttest.NewNopTelemetrySettings(), []string{"ERROR"})
+	require.NoError(t, err)
+
+	drop := NewDrop(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	decision, err := drop.Evaluate(context.Background(), traceID, newDropTestTrace("/checkout"))
+	require.NoError(t, err, "Failed to evaluate drop policy: %v", err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestDropEvaluatorDropped(t *testing.T) {
+	n1 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "url.path", []string{"/health"}, false, 0, false)
+	n2, err := NewStatusCodeFilter(componenttest.NewNopTelemetrySettings(), []string{"ERROR"})
+	require.NoError(t, err)
+
+	drop := NewDrop(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	decision, err := drop.Evaluate(context.Background(), traceID, newDropTestTrace("/health"))
+	require.NoError(t, err, "Failed to evaluate drop policy: %v", err)
+	assert.Equal(t, Dropped, decision)
+}
+
+func TestDropEvaluatorInvertNotSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "url.path", []string{"/health"}, false, 0, true)
+
+	drop := NewDrop(zap.NewNop(), []PolicyEvaluator{n1})
+
+	decision, err := drop.Evaluate(context.Background(), traceID, newDropTestTrace("/health"))
+	require.NoError(t, err, "Failed to evaluate drop policy: %v", err)
+	assert.Equal(t, NotSampled, decision)
+
+	decision, err = drop.Evaluate(context.Background(), traceID, newDropTestTrace("/checkout"))
+	require.NoError(t, err, "Failed to evaluate drop policy: %v", err)
+	assert.Equal(t, Dropped, decision)
+}
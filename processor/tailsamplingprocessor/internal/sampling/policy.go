@@ -11,6 +11,8 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // TraceData stores the sampling related trace data.
@@ -24,6 +26,11 @@ type TraceData struct {
 	SpanCount *atomic.Int64
 	// ReceivedBatches stores all the batches received for the trace.
 	ReceivedBatches ptrace.Traces
+	// SpilledBatches is the number of batches of the trace written to the storage extension, to be
+	// loaded back into ReceivedBatches when the sampling decision is made.
+	SpilledBatches int
+	// SampledThreshold is the threshold the trace was sampled with, set along with FinalDecision.
+	SampledThreshold pkgsampling.Threshold
 	// FinalDecision.
 	FinalDecision Decision
 }
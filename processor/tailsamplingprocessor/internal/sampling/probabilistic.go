@@ -12,44 +12,69 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 const (
 	defaultHashSalt = "default-hash-seed"
 )
 
+// probabilisticThresholdPrecision is the number of hex digits of the thresholds of the
+// probabilistic policy.
+const probabilisticThresholdPrecision = 4
+
 type probabilisticSampler struct {
-	logger    *zap.Logger
-	threshold uint64
-	hashSalt  string
+	logger            *zap.Logger
+	threshold         uint64
+	samplingThreshold pkgsampling.Threshold
+	hashSalt          string
 }
 
-var _ PolicyEvaluator = (*probabilisticSampler)(nil)
+var _ ThresholdPolicyEvaluator = (*probabilisticSampler)(nil)
 
 // NewProbabilisticSampler creates a policy evaluator that samples a percentage of
-// traces.
-func NewProbabilisticSampler(settings component.TelemetrySettings, hashSalt string, samplingPercentage float64) PolicyEvaluator {
-	if hashSalt == "" {
+// traces. When consistent is set and no hash salt is given, the decision is consistent
+// with the other OpenTelemetry samplers: it is based on the randomness of the tracestate
+// or of the trace ID, and the traces are sampled with the threshold of the percentage.
+func NewProbabilisticSampler(settings component.TelemetrySettings, hashSalt string, samplingPercentage float64, consistent bool) PolicyEvaluator {
+	if hashSalt == "" && !consistent {
 		hashSalt = defaultHashSalt
 	}
 
 	return &probabilisticSampler{
 		logger: settings.Logger,
-		// calculate threshold once
-		threshold: calculateThreshold(samplingPercentage / 100),
-		hashSalt:  hashSalt,
+		// calculate thresholds once
+		threshold:         calculateThreshold(samplingPercentage / 100),
+		samplingThreshold: probabilityToThreshold(samplingPercentage/100, probabilisticThresholdPrecision),
+		hashSalt:          hashSalt,
 	}
 }
 
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
-func (s *probabilisticSampler) Evaluate(_ context.Context, traceID pcommon.TraceID, _ *TraceData) (Decision, error) {
+func (s *probabilisticSampler) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := s.EvaluateThreshold(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateThreshold looks at the trace data and returns a corresponding SamplingDecision, along
+// with the threshold of the sampling percentage. The traces sampled from the hash of their ID are
+// sampled with AlwaysSampleThreshold, as their randomness may not pass the threshold.
+func (s *probabilisticSampler) EvaluateThreshold(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error) {
 	s.logger.Debug("Evaluating spans in probabilistic filter")
 
-	if hashTraceID(s.hashSalt, traceID[:]) <= s.threshold {
-		return Sampled, nil
+	if s.hashSalt != "" {
+		if hashTraceID(s.hashSalt, traceID[:]) <= s.threshold {
+			return Sampled, pkgsampling.AlwaysSampleThreshold, nil
+		}
+		return NotSampled, pkgsampling.NeverSampleThreshold, nil
+	}
+
+	if s.samplingThreshold.ShouldSample(traceRandomness(traceID, trace)) {
+		return Sampled, s.samplingThreshold, nil
 	}
 
-	return NotSampled, nil
+	return NotSampled, pkgsampling.NeverSampleThreshold, nil
 }
 
 // calculateThreshold converts a ratio into a value between 0 and MaxUint64
@@ -5,44 +5,82 @@ package sampling // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"context"
-	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
+// rateLimitingThresholdPrecision is the number of hex digits of the thresholds estimated by the
+// rate limiting policy.
+const rateLimitingThresholdPrecision = 4
+
 type rateLimiting struct {
 	currentSecond        int64
 	spansInCurrentSecond int64
 	spansPerSecond       int64
-	logger               *zap.Logger
+	// seenSpansInCurrentSecond counts the spans of all the evaluated traces, sampled or not.
+	seenSpansInCurrentSecond int64
+	// previousThreshold is the threshold estimated from the spans evaluated in the previous second.
+	previousThreshold pkgsampling.Threshold
+	consistent        bool
+	timeProvider      TimeProvider
+	logger            *zap.Logger
 }
 
-var _ PolicyEvaluator = (*rateLimiting)(nil)
+var _ ThresholdPolicyEvaluator = (*rateLimiting)(nil)
 
-// NewRateLimiting creates a policy evaluator the samples all traces.
-func NewRateLimiting(settings component.TelemetrySettings, spansPerSecond int64) PolicyEvaluator {
+// NewRateLimiting creates a policy evaluator the samples all traces. When consistent is set, the
+// traces are sampled from their randomness, with a threshold estimated from the previous second.
+func NewRateLimiting(settings component.TelemetrySettings, spansPerSecond int64, consistent bool) PolicyEvaluator {
 	return &rateLimiting{
 		spansPerSecond: spansPerSecond,
+		consistent:     consistent,
+		timeProvider:   MonotonicClock{},
 		logger:         settings.Logger,
 	}
 }
 
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
-func (r *rateLimiting) Evaluate(_ context.Context, _ pcommon.TraceID, trace *TraceData) (Decision, error) {
+func (r *rateLimiting) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := r.EvaluateThreshold(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateThreshold looks at the trace data and returns a corresponding SamplingDecision. When
+// consistent, the threshold is estimated from the spans evaluated in the previous second, as the
+// probability of a span to fit in the limit. Only the traces whose randomness passes it are
+// sampled, within the limit, so that their adjusted count is given by the threshold. Until a
+// second was observed, the traces are sampled in arrival order with AlwaysSampleThreshold. The
+// threshold is only an estimate: when the current second has more spans than the previous one,
+// traces passing it are not sampled once the limit is reached, and the adjusted counts of the
+// sampled traces are underestimated.
+func (r *rateLimiting) EvaluateThreshold(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error) {
 	r.logger.Debug("Evaluating spans in rate-limiting filter")
-	currSecond := time.Now().Unix()
+	currSecond := r.timeProvider.getCurSecond()
 	if r.currentSecond != currSecond {
+		r.previousThreshold = pkgsampling.AlwaysSampleThreshold
+		if r.consistent && currSecond == r.currentSecond+1 && r.seenSpansInCurrentSecond > 0 {
+			r.previousThreshold = probabilityToThreshold(float64(r.spansPerSecond)/float64(r.seenSpansInCurrentSecond), rateLimitingThresholdPrecision)
+		}
 		r.currentSecond = currSecond
 		r.spansInCurrentSecond = 0
+		r.seenSpansInCurrentSecond = 0
+	}
+
+	spanCount := trace.SpanCount.Load()
+	r.seenSpansInCurrentSecond += spanCount
+	if r.previousThreshold != pkgsampling.AlwaysSampleThreshold && !r.previousThreshold.ShouldSample(traceRandomness(traceID, trace)) {
+		return NotSampled, pkgsampling.NeverSampleThreshold, nil
 	}
 
-	spansInSecondIfSampled := r.spansInCurrentSecond + trace.SpanCount.Load()
+	spansInSecondIfSampled := r.spansInCurrentSecond + spanCount
 	if spansInSecondIfSampled < r.spansPerSecond {
 		r.spansInCurrentSecond = spansInSecondIfSampled
-		return Sampled, nil
+		return Sampled, r.previousThreshold, nil
 	}
 
-	return NotSampled, nil
+	return NotSampled, pkgsampling.NeverSampleThreshold, nil
 }
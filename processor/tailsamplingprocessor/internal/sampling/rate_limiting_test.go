@@ -16,7 +16,7 @@ import (
 func TestRateLimiter(t *testing.T) {
 	trace := newTraceStringAttrs(nil, "example", "value")
 	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
-	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 3)
+	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 3, false)
 
 	// Trace span count greater than spans per second
 	traceSpanCount := &atomic.Int64{}
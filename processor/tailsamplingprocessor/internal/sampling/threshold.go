@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+// ThresholdPolicyEvaluator is implemented by the policy evaluators sampling traces with a known
+// probability, expressed as an OpenTelemetry sampling threshold (OTEP 235).
+type ThresholdPolicyEvaluator interface {
+	PolicyEvaluator
+	// EvaluateThreshold evaluates the trace like Evaluate, additionally returning the threshold the
+	// trace was sampled with. The threshold of a NotSampled decision is NeverSampleThreshold.
+	EvaluateThreshold(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error)
+}
+
+// EvaluateWithThreshold evaluates the trace with the given policy evaluator, returning the decision
+// along with the threshold the trace was sampled with. The traces sampled by the evaluators not
+// implementing ThresholdPolicyEvaluator are sampled with AlwaysSampleThreshold.
+func EvaluateWithThreshold(ctx context.Context, evaluator PolicyEvaluator, traceID pcommon.TraceID, trace *TraceData) (Decision, pkgsampling.Threshold, error) {
+	if te, ok := evaluator.(ThresholdPolicyEvaluator); ok {
+		return te.EvaluateThreshold(ctx, traceID, trace)
+	}
+	decision, err := evaluator.Evaluate(ctx, traceID, trace)
+	return decision, decisionThreshold(decision, pkgsampling.AlwaysSampleThreshold), err
+}
+
+// decisionThreshold returns the given threshold for sampled decisions, and NeverSampleThreshold
+// otherwise.
+func decisionThreshold(decision Decision, threshold pkgsampling.Threshold) pkgsampling.Threshold {
+	if decision == Sampled || decision == InvertSampled {
+		return threshold
+	}
+	return pkgsampling.NeverSampleThreshold
+}
+
+// probabilityToThreshold converts a sampling probability to a threshold, clamping it to the range
+// of valid probabilities.
+func probabilityToThreshold(probability float64, precision int) pkgsampling.Threshold {
+	switch {
+	case probability <= 0:
+		return pkgsampling.NeverSampleThreshold
+	case probability >= 1:
+		return pkgsampling.AlwaysSampleThreshold
+	case probability < pkgsampling.MinSamplingProbability:
+		probability = pkgsampling.MinSamplingProbability
+	}
+	threshold, err := pkgsampling.ProbabilityToThresholdWithPrecision(probability, precision)
+	if err != nil {
+		return pkgsampling.AlwaysSampleThreshold
+	}
+	return threshold
+}
+
+// traceRandomness returns the randomness of the trace: the explicit randomness (rv) of the
+// tracestate of its first span if any, else the randomness of its trace ID.
+func traceRandomness(traceID pcommon.TraceID, trace *TraceData) pkgsampling.Randomness {
+	trace.Lock()
+	defer trace.Unlock()
+	if span, ok := firstSpan(trace.ReceivedBatches); ok {
+		if w3c, err := pkgsampling.NewW3CTraceState(span.TraceState().AsRaw()); err == nil {
+			if rnd, has := w3c.OTelValue().RValueRandomness(); has {
+				return rnd
+			}
+		}
+	}
+	return pkgsampling.TraceIDToRandomness(traceID)
+}
+
+func firstSpan(td ptrace.Traces) (ptrace.Span, bool) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		ilss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			if spans := ilss.At(j).Spans(); spans.Len() > 0 {
+				return spans.At(0), true
+			}
+		}
+	}
+	return ptrace.Span{}, false
+}
+
+// UpdateTraceState sets the threshold a trace was sampled with in the tracestate of its spans,
+// so that their adjusted count can be computed downstream. The spans sampled upstream with a
+// greater threshold keep it, as sampling can only lower their probability. Nothing is done for
+// AlwaysSampleThreshold, which doesn't change the adjusted count of the spans.
+func UpdateTraceState(td ptrace.Traces, threshold pkgsampling.Threshold) {
+	if threshold == pkgsampling.AlwaysSampleThreshold {
+		return
+	}
+	// The spans of a trace usually share their tracestate, update each distinct one once.
+	updated := make(map[string]string)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				traceState := spans.At(k).TraceState()
+				raw := traceState.AsRaw()
+				newRaw, ok := updated[raw]
+				if !ok {
+					newRaw = updateTraceStateThreshold(raw, threshold)
+					updated[raw] = newRaw
+				}
+				traceState.FromRaw(newRaw)
+			}
+		}
+	}
+}
+
+func updateTraceStateThreshold(raw string, threshold pkgsampling.Threshold) string {
+	w3c, err := pkgsampling.NewW3CTraceState(raw)
+	if err != nil {
+		// Leave invalid tracestates untouched.
+		return raw
+	}
+	if err = w3c.OTelValue().UpdateTValueWithSampling(threshold); err != nil {
+		// The span was sampled upstream with a greater threshold.
+		return raw
+	}
+	var w strings.Builder
+	if err = w3c.Serialize(&w); err != nil {
+		return raw
+	}
+	return w.String()
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+func TestUpdateTraceState(t *testing.T) {
+	half, err := pkgsampling.ProbabilityToThreshold(0.5)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		threshold pkgsampling.Threshold
+		input     string
+		expected  string
+	}{
+		{
+			name:      "empty tracestate",
+			threshold: half,
+			input:     "",
+			expected:  "ot=th:8",
+		},
+		{
+			name:      "other vendors are kept",
+			threshold: half,
+			input:     "ot=rv:abcdef01234567,vendor=value",
+			expected:  "ot=rv:abcdef01234567;th:8,vendor=value",
+		},
+		{
+			name:      "lower upstream threshold is raised",
+			threshold: half,
+			input:     "ot=th:4",
+			expected:  "ot=th:8",
+		},
+		{
+			name:      "greater upstream threshold is kept",
+			threshold: half,
+			input:     "ot=th:c",
+			expected:  "ot=th:c",
+		},
+		{
+			name:      "always sample threshold leaves the tracestate untouched",
+			threshold: pkgsampling.AlwaysSampleThreshold,
+			input:     "vendor=value",
+			expected:  "vendor=value",
+		},
+		{
+			name:      "invalid tracestate is left untouched",
+			threshold: half,
+			input:     "ot=th:invalid",
+			expected:  "ot=th:invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := ptrace.NewTraces()
+			spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			spans.AppendEmpty().TraceState().FromRaw(tt.input)
+			spans.AppendEmpty().TraceState().FromRaw(tt.input)
+
+			UpdateTraceState(td, tt.threshold)
+
+			for i := 0; i < spans.Len(); i++ {
+				assert.Equal(t, tt.expected, spans.At(i).TraceState().AsRaw())
+			}
+		})
+	}
+}
+
+func TestProbabilisticSamplingThreshold(t *testing.T) {
+	sampler := NewProbabilisticSampler(componenttest.NewNopTelemetrySettings(), "", 25, true)
+	// The randomness of the trace ID is lower than the threshold, but the explicit randomness
+	// of the tracestate is greater.
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 0, 0, 0, 0, 0, 0})
+	trace := newTraceStringAttrs(nil, "example", "value")
+
+	decision, threshold, err := EvaluateWithThreshold(context.Background(), sampler, traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+	assert.Equal(t, pkgsampling.NeverSampleThreshold, threshold)
+
+	trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().FromRaw("ot=rv:ffffffffffffff")
+	decision, threshold, err = EvaluateWithThreshold(context.Background(), sampler, traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, "c", threshold.TValue())
+
+	// The traces sampled from the hash of their ID don't get the threshold of the percentage.
+	for _, consistent := range []bool{false, true} {
+		sampler = NewProbabilisticSampler(componenttest.NewNopTelemetrySettings(), "salt", 100, consistent)
+		decision, threshold, err = EvaluateWithThreshold(context.Background(), sampler, traceID, trace)
+		require.NoError(t, err)
+		assert.Equal(t, Sampled, decision)
+		assert.Equal(t, pkgsampling.AlwaysSampleThreshold, threshold)
+	}
+}
+
+func TestAndThreshold(t *testing.T) {
+	quarter := NewProbabilisticSampler(componenttest.NewNopTelemetrySettings(), "", 25, true)
+	half := NewProbabilisticSampler(componenttest.NewNopTelemetrySettings(), "", 50, true)
+	and := NewAnd(componenttest.NewNopTelemetrySettings().Logger, []PolicyEvaluator{
+		half,
+		quarter,
+		NewAlwaysSample(componenttest.NewNopTelemetrySettings()),
+	})
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	decision, threshold, err := EvaluateWithThreshold(context.Background(), and, traceID, newTraceStringAttrs(nil, "example", "value"))
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, "c", threshold.TValue())
+}
+
+func TestRateLimitingThreshold(t *testing.T) {
+	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 3, true).(*rateLimiting)
+	timeProvider := &FakeTimeProvider{second: 1}
+	rateLimiter.timeProvider = timeProvider
+
+	trace := newTraceStringAttrs(nil, "example", "value")
+	spanCount := &atomic.Int64{}
+	spanCount.Store(1)
+	trace.SpanCount = spanCount
+
+	// Within the first second, nothing is known about the rate.
+	expected := []Decision{Sampled, Sampled, NotSampled, NotSampled}
+	for _, e := range expected {
+		decision, threshold, err := EvaluateWithThreshold(context.Background(), rateLimiter, pcommon.TraceID{}, trace)
+		require.NoError(t, err)
+		assert.Equal(t, e, decision)
+		if e == Sampled {
+			assert.Equal(t, pkgsampling.AlwaysSampleThreshold, threshold)
+		}
+	}
+
+	// Three of the four spans of the previous second fit in the limit.
+	timeProvider.second = 2
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	decision, threshold, err := EvaluateWithThreshold(context.Background(), rateLimiter, traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.InDelta(t, 4.0/3, threshold.AdjustedCount(), 0.01)
+
+	// The randomness of the trace doesn't pass the estimated threshold.
+	decision, _, err = EvaluateWithThreshold(context.Background(), rateLimiter, pcommon.TraceID{}, trace)
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	// Nothing is known about the rate when a second is skipped.
+	timeProvider.second = 4
+	decision, threshold, err = EvaluateWithThreshold(context.Background(), rateLimiter, pcommon.TraceID{}, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, pkgsampling.AlwaysSampleThreshold, threshold)
+}
+
+func TestRateLimitingAdjustedCount(t *testing.T) {
+	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 1000, true).(*rateLimiting)
+	timeProvider := &FakeTimeProvider{second: 1}
+	rateLimiter.timeProvider = timeProvider
+
+	trace := newTraceStringAttrs(nil, "example", "value")
+	spanCount := &atomic.Int64{}
+	spanCount.Store(1)
+	trace.SpanCount = spanCount
+
+	// 10 times as many traces as the limit are evaluated each second. From the second second on,
+	// the adjusted counts of the sampled traces add up to the number of evaluated traces.
+	traceIDs := genRandomTraceIDs(100_000)
+	seen := 0
+	adjustedCount := 0.0
+	for i, traceID := range traceIDs {
+		timeProvider.second = int64(1 + i/10_000)
+		decision, threshold, err := EvaluateWithThreshold(context.Background(), rateLimiter, traceID, trace)
+		require.NoError(t, err)
+		if timeProvider.second == 1 {
+			continue
+		}
+		seen++
+		if decision == Sampled {
+			adjustedCount += threshold.AdjustedCount()
+		}
+	}
+	assert.InEpsilon(t, seen, adjustedCount, 0.05)
+}
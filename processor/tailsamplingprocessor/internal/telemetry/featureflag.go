@@ -24,3 +24,13 @@ var recordPolicyFeatureGate = featuregate.GlobalRegistry().MustRegister(
 func IsRecordPolicyEnabled() bool {
 	return recordPolicyFeatureGate.IsEnabled()
 }
+
+var consistentSamplingFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"processor.tailsamplingprocessor.consistentsampling",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the 'probabilistic' policy without a hash salt and the 'rate_limiting' policy sample traces from their randomness, consistently with the OpenTelemetry probability sampling, and record the threshold they were sampled with in the tracestate of their spans."),
+)
+
+func IsConsistentSamplingEnabled() bool {
+	return consistentSamplingFeatureGate.IsEnabled()
+}
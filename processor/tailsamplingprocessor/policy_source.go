@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages"
+)
+
+const (
+	// PolicyCustomCapability is the OpAMP custom capability the sampling policies are pushed through.
+	PolicyCustomCapability = "org.opentelemetry.collector.processor.tailsampling"
+	// PolicyMessageType is the type of the custom messages carrying the sampling policies.
+	PolicyMessageType = "policies"
+
+	defaultWatchInterval = 10 * time.Second
+)
+
+// policySet is a set of sampling policies loaded at runtime.
+type policySet struct {
+	// Version identifies the set in the telemetry of the decisions made with its policies.
+	Version  string      `mapstructure:"version"`
+	Policies []PolicyCfg `mapstructure:"policies"`
+}
+
+// unmarshalPolicySet parses a YAML, or JSON, document describing a set of sampling policies.
+func unmarshalPolicySet(data []byte) (*policySet, error) {
+	retrieved, err := confmap.NewRetrievedFromYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := retrieved.AsConf()
+	if err != nil {
+		return nil, err
+	}
+	set := &policySet{}
+	if err = conf.Unmarshal(set); err != nil {
+		return nil, err
+	}
+	if len(set.Policies) == 0 {
+		return nil, errors.New("no sampling policies found")
+	}
+	return set, nil
+}
+
+// policySource loads the sampling policies from the file and the OpAMP extension of a
+// PolicySourceConfig, and hands them to the processor.
+type policySource struct {
+	cfg       PolicySourceConfig
+	logger    *zap.Logger
+	validate  func(cfgs []PolicyCfg) error
+	setPolicy func(version string, cfgs []PolicyCfg)
+	handler   opampcustommessages.CustomCapabilityHandler
+	// lastFileContent is the content of the file the policies were last successfully loaded from.
+	lastFileContent []byte
+	done            chan struct{}
+	wg              sync.WaitGroup
+}
+
+func newPolicySource(cfg PolicySourceConfig, logger *zap.Logger, validate func(cfgs []PolicyCfg) error, setPolicy func(version string, cfgs []PolicyCfg)) *policySource {
+	if cfg.WatchInterval <= 0 {
+		cfg.WatchInterval = defaultWatchInterval
+	}
+	return &policySource{
+		cfg:       cfg,
+		logger:    logger,
+		validate:  validate,
+		setPolicy: setPolicy,
+		done:      make(chan struct{}),
+	}
+}
+
+// start registers the policy capability to the OpAMP extension and starts watching the file. The
+// policies of the file are loaded right away, so that they are used for the first decisions.
+func (s *policySource) start(host component.Host) error {
+	if s.cfg.OpAMP != nil {
+		ext, ok := host.GetExtensions()[*s.cfg.OpAMP]
+		if !ok {
+			return fmt.Errorf("extension %q does not exist", s.cfg.OpAMP)
+		}
+		registry, ok := ext.(opampcustommessages.CustomCapabilityRegistry)
+		if !ok {
+			return fmt.Errorf("extension %q is not a custom message registry", s.cfg.OpAMP)
+		}
+		handler, err := registry.Register(PolicyCustomCapability)
+		if err != nil {
+			return fmt.Errorf("failed to register custom capability: %w", err)
+		}
+		if handler == nil {
+			return errors.New("custom capability handler is nil")
+		}
+		s.handler = handler
+		s.wg.Add(1)
+		go s.receiveMessages()
+	}
+	if s.cfg.File != "" {
+		s.loadFile()
+		s.wg.Add(1)
+		go s.watchFile()
+	}
+	return nil
+}
+
+func (s *policySource) shutdown() {
+	close(s.done)
+	if s.handler != nil {
+		s.handler.Unregister()
+	}
+	s.wg.Wait()
+}
+
+func (s *policySource) receiveMessages() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.done:
+			return
+		case msg, ok := <-s.handler.Message():
+			if !ok {
+				return
+			}
+			if msg.Type != PolicyMessageType {
+				s.logger.Debug("Ignoring custom message", zap.String("type", msg.Type))
+				continue
+			}
+			s.load(msg.Data, zap.String("source", "opamp"))
+		}
+	}
+}
+
+func (s *policySource) watchFile() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.cfg.WatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.loadFile()
+		}
+	}
+}
+
+// loadFile loads the policies of the file if its content changed since it was last loaded. A file
+// whose policies are invalid is loaded again on the next check, until it is fixed.
+func (s *policySource) loadFile() {
+	content, err := os.ReadFile(s.cfg.File)
+	if err != nil {
+		s.logger.Warn("Failed to read the sampling policies file", zap.String("file", s.cfg.File), zap.Error(err))
+		return
+	}
+	if s.lastFileContent != nil && bytes.Equal(content, s.lastFileContent) {
+		return
+	}
+	if s.load(content, zap.String("source", s.cfg.File)) {
+		s.lastFileContent = content
+	}
+}
+
+// load hands the policies of data to the processor, and reports whether they were valid. Invalid
+// policies are rejected before reaching the processor, which keeps using the previous ones.
+func (s *policySource) load(data []byte, source zap.Field) bool {
+	set, err := unmarshalPolicySet(data)
+	if err != nil {
+		s.logger.Error("Failed to unmarshal the sampling policies, continuing to use the previous ones", source, zap.Error(err))
+		return false
+	}
+	if err = s.validate(set.Policies); err != nil {
+		s.logger.Error("Invalid sampling policies, continuing to use the previous ones", source, zap.String("version", set.Version), zap.Error(err))
+		return false
+	}
+	s.logger.Info("Received sampling policies", source, zap.String("version", set.Version), zap.Int("policies.len", len(set.Policies)))
+	s.setPolicy(set.Version, set.Policies)
+	return true
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadatatest"
+)
+
+const testPolicySet = `
+version: v2
+policies:
+  - name: always
+    type: always_sample
+  - name: errors
+    type: status_code
+    status_code: {status_codes: [ERROR]}
+`
+
+type mockCustomCapabilityRegistry struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	capability   string
+	messages     chan *protobufs.CustomMessage
+	unregistered bool
+}
+
+func (m *mockCustomCapabilityRegistry) Register(capability string, _ ...opampcustommessages.CustomCapabilityRegisterOption) (opampcustommessages.CustomCapabilityHandler, error) {
+	m.capability = capability
+	return m, nil
+}
+
+func (m *mockCustomCapabilityRegistry) Message() <-chan *protobufs.CustomMessage {
+	return m.messages
+}
+
+func (m *mockCustomCapabilityRegistry) SendMessage(string, []byte) (chan struct{}, error) {
+	return nil, nil
+}
+
+func (m *mockCustomCapabilityRegistry) Unregister() {
+	m.unregistered = true
+}
+
+func newPolicySourceTestProcessor(t *testing.T, set processor.Settings, sink *consumertest.TracesSink, source PolicySourceConfig) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs:   testPolicy,
+		PolicySource: &source,
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), set, sink, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestUnmarshalPolicySet(t *testing.T) {
+	set, err := unmarshalPolicySet([]byte(testPolicySet))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", set.Version)
+	require.Len(t, set.Policies, 2)
+	assert.Equal(t, AlwaysSample, set.Policies[0].Type)
+	assert.Equal(t, []string{"ERROR"}, set.Policies[1].StatusCodeCfg.StatusCodes)
+
+	_, err = unmarshalPolicySet([]byte(`{"version": "v3", "policies": [{"name": "always", "type": "always_sample"}]}`))
+	require.NoError(t, err)
+
+	_, err = unmarshalPolicySet([]byte("version: v2"))
+	require.EqualError(t, err, "no sampling policies found")
+
+	_, err = unmarshalPolicySet([]byte("policies: [{name: always, type: always_sample, unknown: true}]"))
+	require.ErrorContains(t, err, "unknown")
+}
+
+func TestPolicySourceFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(testPolicySet), 0o600))
+
+	tsp := newPolicySourceTestProcessor(t, processortest.NewNopSettings(metadata.Type), new(consumertest.TracesSink),
+		PolicySourceConfig{File: file, WatchInterval: 10 * time.Millisecond})
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	// The policies of the file are applied on the first tick.
+	assert.Len(t, tsp.policies, 1)
+	tsp.policyTicker.OnTick()
+	require.Len(t, tsp.policies, 2)
+	assert.Equal(t, "errors", tsp.policies[1].name)
+
+	require.NoError(t, os.WriteFile(file, []byte("policies: [{name: latency, type: latency, latency: {threshold_ms: 100}}]"), 0o600))
+	assert.Eventually(t, func() bool {
+		tsp.policyTicker.OnTick()
+		return len(tsp.policies) == 1 && tsp.policies[0].name == "latency"
+	}, time.Second, 10*time.Millisecond)
+
+	// Invalid policies are rejected before reaching the processor, and not recorded as loaded.
+	require.NoError(t, os.WriteFile(file, []byte("policies: [{name: invalid, type: unknown}]"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	tsp.setPolicyMux.Lock()
+	assert.Nil(t, tsp.pendingPolicy)
+	tsp.setPolicyMux.Unlock()
+	tsp.policyTicker.OnTick()
+	require.Len(t, tsp.policies, 1)
+	assert.Equal(t, "latency", tsp.policies[0].name)
+}
+
+func TestPolicySourceOpAMP(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	set := processortest.NewNopSettings(metadata.Type)
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	sink := new(consumertest.TracesSink)
+
+	opampID := component.MustNewID("opamp")
+	registry := &mockCustomCapabilityRegistry{messages: make(chan *protobufs.CustomMessage, 1)}
+	host := storagetest.NewStorageHost().WithExtension(opampID, registry)
+
+	tsp := newPolicySourceTestProcessor(t, set, sink, PolicySourceConfig{OpAMP: &opampID})
+	require.NoError(t, tsp.Start(context.Background(), host))
+	assert.Equal(t, PolicyCustomCapability, registry.capability)
+
+	registry.messages <- &protobufs.CustomMessage{
+		Capability: PolicyCustomCapability,
+		Type:       PolicyMessageType,
+		Data:       []byte(testPolicySet),
+	}
+	assert.Eventually(t, func() bool {
+		tsp.policyTicker.OnTick()
+		return len(tsp.policies) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The version of the policies is recorded in the telemetry of the decisions.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTraces()))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Len(t, sink.AllTraces(), 1)
+
+	metadatatest.AssertEqualProcessorTailSamplingGlobalCountTracesSampled(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("sampled", "true"), attribute.String("policy_version", "v2")),
+				Value:      1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.True(t, registry.unregistered)
+}
+
+func TestPolicySourceInvalidOpAMP(t *testing.T) {
+	opampID := component.MustNewID("opamp")
+	tsp := newPolicySourceTestProcessor(t, processortest.NewNopSettings(metadata.Type), new(consumertest.TracesSink), PolicySourceConfig{OpAMP: &opampID})
+	require.ErrorContains(t, tsp.Start(context.Background(), componenttest.NewNopHost()), `extension "opamp" does not exist`)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	nonRegistryID := storagetest.NewNonStorageID("opamp")
+	tsp = newPolicySourceTestProcessor(t, processortest.NewNopSettings(metadata.Type), new(consumertest.TracesSink), PolicySourceConfig{OpAMP: &nonRegistryID})
+	host := storagetest.NewStorageHost().WithNonStorageExtension("opamp")
+	require.ErrorContains(t, tsp.Start(context.Background(), host), `extension "non_storage/opamp" is not a custom message registry`)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
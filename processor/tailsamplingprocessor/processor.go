@@ -5,6 +5,7 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -22,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
@@ -52,17 +54,37 @@ type tailSamplingSpanProcessor struct {
 	nextConsumer      consumer.Traces
 	maxNumTraces      uint64
 	policies          []*policy
+	dropPolicies      []*policy
 	idToTrace         sync.Map
 	policyTicker      timeutils.TTicker
 	tickerFrequency   time.Duration
 	decisionBatcher   idbatcher.Batcher
-	sampledIDCache    cache.Cache[bool]
+	sampledIDCache    cache.Cache[pkgsampling.Threshold]
 	nonSampledIDCache cache.Cache[bool]
 	deleteChan        chan pcommon.TraceID
 	numTracesOnMap    *atomic.Uint64
 	recordPolicy      bool
 	setPolicyMux      sync.Mutex
 	pendingPolicy     []PolicyCfg
+	// pendingVersion is the version of the pending sampling policy.
+	pendingVersion string
+	// policyVersion is the attribute recording the version of the loaded sampling policy in the
+	// telemetry of the decisions. Like policies, it is only replaced on a tick, under tickMux, and
+	// read by the decisions made on the same tick.
+	policyVersion    metric.MeasurementOption
+	policySource     *policySource
+	storageID        *component.ID
+	storage          *traceStorage
+	maxSpansInMemory int64
+	numSpansInMemory *atomic.Int64
+	// tickMux is held while the policies are evaluated, so that Shutdown waits for an evaluation
+	// in flight before saving the state. stopped is set under it on shutdown.
+	tickMux sync.Mutex
+	stopped bool
+	// probabilityMux guards probabilityObservers, the policies of the loaded sampling policy
+	// reporting the probabilities of their keys.
+	probabilityMux       sync.Mutex
+	probabilityObservers []sampling.ProbabilityObserver
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -94,11 +116,10 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 	if err != nil {
 		return nil, err
 	}
-	nopCache := cache.NewNopDecisionCache[bool]()
-	sampledDecisions := nopCache
-	nonSampledDecisions := nopCache
+	sampledDecisions := cache.NewNopDecisionCache[pkgsampling.Threshold]()
+	nonSampledDecisions := cache.NewNopDecisionCache[bool]()
 	if cfg.DecisionCache.SampledCacheSize > 0 {
-		sampledDecisions, err = cache.NewLRUDecisionCache[bool](cfg.DecisionCache.SampledCacheSize)
+		sampledDecisions, err = cache.NewLRUDecisionCache[pkgsampling.Threshold](cfg.DecisionCache.SampledCacheSize)
 		if err != nil {
 			return nil, err
 		}
@@ -121,8 +142,15 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		logger:            telemetrySettings.Logger,
 		numTracesOnMap:    &atomic.Uint64{},
 		deleteChan:        make(chan pcommon.TraceID, cfg.NumTraces),
+		storageID:         cfg.Storage,
+		maxSpansInMemory:  int64(cfg.MaxSpansInMemory),
+		numSpansInMemory:  &atomic.Int64{},
+		policyVersion:     metric.WithAttributes(),
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
+	if err = telemetry.RegisterProcessorTailSamplingAdaptiveSamplingProbabilityCallback(tsp.observeProbabilities); err != nil {
+		return nil, err
+	}
 
 	for _, opt := range cfg.Options {
 		opt(tsp)
@@ -133,12 +161,16 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 	}
 
 	if tsp.policies == nil {
-		err := tsp.loadSamplingPolicy(cfg.PolicyCfgs)
+		err := tsp.loadSamplingPolicy("", cfg.PolicyCfgs)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if cfg.PolicySource != nil {
+		tsp.policySource = newPolicySource(*cfg.PolicySource, tsp.logger, tsp.validateSamplingPolicy, tsp.setSamplingPolicy)
+	}
+
 	if tsp.decisionBatcher == nil {
 		// this will start a goroutine in the background, so we run it only if everything went
 		// well in creating the policies
@@ -175,7 +207,17 @@ func withTickerFrequency(frequency time.Duration) Option {
 }
 
 // WithSampledDecisionCache sets the cache which the processor uses to store recently sampled trace IDs.
+// The threshold the traces were sampled with isn't stored, so their late spans are released without it.
+// Use WithSampledThresholdCache to keep it.
 func WithSampledDecisionCache(c cache.Cache[bool]) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.sampledIDCache = newSampledIDCache(c)
+	}
+}
+
+// WithSampledThresholdCache sets the cache which the processor uses to store recently sampled trace IDs,
+// along with the threshold they were sampled with.
+func WithSampledThresholdCache(c cache.Cache[pkgsampling.Threshold]) Option {
 	return func(tsp *tailSamplingSpanProcessor) {
 		tsp.sampledIDCache = c
 	}
@@ -194,18 +236,22 @@ func withRecordPolicy() Option {
 	}
 }
 
-func getPolicyEvaluator(settings component.TelemetrySettings, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
+// getPolicyEvaluator returns the evaluator of the policy, policyName being the unique name the policy
+// is reported with in the metrics of the processor.
+func getPolicyEvaluator(settings component.TelemetrySettings, policyName string, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case Composite:
-		return getNewCompositePolicy(settings, &cfg.CompositeCfg)
+		return getNewCompositePolicy(settings, policyName, &cfg.CompositeCfg)
 	case And:
-		return getNewAndPolicy(settings, &cfg.AndCfg)
+		return getNewAndPolicy(settings, policyName, &cfg.AndCfg)
+	case Drop:
+		return getNewDropPolicy(settings, policyName, &cfg.DropCfg)
 	default:
-		return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
+		return getSharedPolicyEvaluator(settings, policyName, &cfg.sharedPolicyCfg)
 	}
 }
 
-func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedPolicyCfg) (sampling.PolicyEvaluator, error) {
+func getSharedPolicyEvaluator(settings component.TelemetrySettings, policyName string, cfg *sharedPolicyCfg) (sampling.PolicyEvaluator, error) {
 	settings.Logger = settings.Logger.With(zap.Any("policy", cfg.Type))
 
 	switch cfg.Type {
@@ -221,7 +267,7 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 		return sampling.NewNumericAttributeFilter(settings, nafCfg.Key, &minValue, &maxValue, nafCfg.InvertMatch), nil
 	case Probabilistic:
 		pCfg := cfg.ProbabilisticCfg
-		return sampling.NewProbabilisticSampler(settings, pCfg.HashSalt, pCfg.SamplingPercentage), nil
+		return sampling.NewProbabilisticSampler(settings, pCfg.HashSalt, pCfg.SamplingPercentage, telemetry.IsConsistentSamplingEnabled()), nil
 	case StringAttribute:
 		safCfg := cfg.StringAttributeCfg
 		return sampling.NewStringAttributeFilter(settings, safCfg.Key, safCfg.Values, safCfg.EnabledRegexMatching, safCfg.CacheMaxSize, safCfg.InvertMatch), nil
@@ -230,7 +276,7 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 		return sampling.NewStatusCodeFilter(settings, scfCfg.StatusCodes)
 	case RateLimiting:
 		rlfCfg := cfg.RateLimitingCfg
-		return sampling.NewRateLimiting(settings, rlfCfg.SpansPerSecond), nil
+		return sampling.NewRateLimiting(settings, rlfCfg.SpansPerSecond, telemetry.IsConsistentSamplingEnabled()), nil
 	case SpanCount:
 		spCfg := cfg.SpanCountCfg
 		return sampling.NewSpanCount(settings, spCfg.MinSpans, spCfg.MaxSpans), nil
@@ -243,6 +289,10 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
 		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+	case Adaptive:
+		aCfg := cfg.AdaptiveCfg
+		return sampling.NewAdaptive(settings, policyName, aCfg.KeyAttributes, aCfg.IncludeRootSpanName, aCfg.TargetTracesPerSecond,
+			aCfg.MinTracesPerSecond, aCfg.AdjustmentInterval, aCfg.MaxKeys)
 
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
@@ -250,58 +300,121 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 }
 
 type policyMetrics struct {
-	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
+	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled, decisionDropped int64
 }
 
-func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error {
+// loadSamplingPolicy replaces the sampling policy with the given one. A non-empty version is
+// recorded as the policy_version attribute of the telemetry of the decisions.
+func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(version string, cfgs []PolicyCfg) error {
+	policies, dropPolicies, probabilityObservers, err := tsp.newPolicies(version, cfgs)
+	if err != nil {
+		return err
+	}
+
+	tsp.policies = policies
+	tsp.dropPolicies = dropPolicies
+	tsp.probabilityMux.Lock()
+	tsp.probabilityObservers = probabilityObservers
+	tsp.probabilityMux.Unlock()
+	tsp.policyVersion = metric.WithAttributes(policyVersionAttributes(version)...)
+
+	tsp.logger.Debug("Loaded sampling policy", zap.Int("policies.len", len(policies)), zap.Int("drop_policies.len", len(dropPolicies)), zap.String("version", version))
+
+	return nil
+}
+
+// validateSamplingPolicy returns an error if the evaluators of the given sampling policy cannot be
+// created, in which case loading it would fail.
+func (tsp *tailSamplingSpanProcessor) validateSamplingPolicy(cfgs []PolicyCfg) error {
+	_, _, _, err := tsp.newPolicies("", cfgs)
+	return err
+}
+
+// policyVersionAttributes returns the policy_version attribute of a non-empty version.
+func policyVersionAttributes(version string) []attribute.KeyValue {
+	if version == "" {
+		return nil
+	}
+	return []attribute.KeyValue{attribute.String("policy_version", version)}
+}
+
+// newPolicies creates the policies, the drop policies and the probability observers of the given
+// sampling policy.
+func (tsp *tailSamplingSpanProcessor) newPolicies(version string, cfgs []PolicyCfg) ([]*policy, []*policy, []sampling.ProbabilityObserver, error) {
 	telemetrySettings := tsp.set.TelemetrySettings
 	componentID := tsp.set.ID.Name()
 
+	versionAttributes := policyVersionAttributes(version)
+
 	cLen := len(cfgs)
 	policies := make([]*policy, 0, cLen)
+	var dropPolicies []*policy
+	var probabilityObservers []sampling.ProbabilityObserver
 	policyNames := make(map[string]struct{}, cLen)
 
 	for _, cfg := range cfgs {
 		if cfg.Name == "" {
-			return fmt.Errorf("policy name cannot be empty")
+			return nil, nil, nil, fmt.Errorf("policy name cannot be empty")
 		}
 
 		if _, exists := policyNames[cfg.Name]; exists {
-			return fmt.Errorf("duplicate policy name %q", cfg.Name)
+			return nil, nil, nil, fmt.Errorf("duplicate policy name %q", cfg.Name)
 		}
 		policyNames[cfg.Name] = struct{}{}
 
-		eval, err := getPolicyEvaluator(telemetrySettings, &cfg)
-		if err != nil {
-			return fmt.Errorf("failed to create policy evaluator for %q: %w", cfg.Name, err)
-		}
-
 		uniquePolicyName := cfg.Name
 		if componentID != "" {
 			uniquePolicyName = fmt.Sprintf("%s.%s", componentID, cfg.Name)
 		}
 
-		policies = append(policies, &policy{
+		eval, err := getPolicyEvaluator(telemetrySettings, uniquePolicyName, &cfg)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create policy evaluator for %q: %w", cfg.Name, err)
+		}
+
+		p := &policy{
 			name:      cfg.Name,
 			evaluator: eval,
-			attribute: metric.WithAttributes(attribute.String("policy", uniquePolicyName)),
-		})
+			attribute: metric.WithAttributes(append([]attribute.KeyValue{attribute.String("policy", uniquePolicyName)}, versionAttributes...)...),
+		}
+		if cfg.Type == Drop {
+			dropPolicies = append(dropPolicies, p)
+			continue
+		}
+		policies = append(policies, p)
+		if observer, ok := eval.(sampling.ProbabilityObserver); ok {
+			probabilityObservers = append(probabilityObservers, observer)
+		}
 	}
 
-	tsp.policies = policies
-
-	tsp.logger.Debug("Loaded sampling policy", zap.Int("policies.len", len(policies)))
+	return policies, dropPolicies, probabilityObservers, nil
+}
 
+// observeProbabilities reports the probabilities of the keys tracked by the policies of the loaded
+// sampling policy. The keys of a replaced sampling policy are no longer reported.
+func (tsp *tailSamplingSpanProcessor) observeProbabilities(_ context.Context, observer metric.Float64Observer) error {
+	tsp.probabilityMux.Lock()
+	defer tsp.probabilityMux.Unlock()
+	for _, o := range tsp.probabilityObservers {
+		o.ObserveProbabilities(observer)
+	}
 	return nil
 }
 
 func (tsp *tailSamplingSpanProcessor) SetSamplingPolicy(cfgs []PolicyCfg) {
-	tsp.logger.Debug("Setting pending sampling policy", zap.Int("pending.len", len(cfgs)))
+	tsp.setSamplingPolicy("", cfgs)
+}
+
+// setSamplingPolicy sets the pending sampling policy along with its version. It is loaded on the
+// next tick, so that all the traces of a batch are decided with the same policy.
+func (tsp *tailSamplingSpanProcessor) setSamplingPolicy(version string, cfgs []PolicyCfg) {
+	tsp.logger.Debug("Setting pending sampling policy", zap.Int("pending.len", len(cfgs)), zap.String("version", version))
 
 	tsp.setPolicyMux.Lock()
 	defer tsp.setPolicyMux.Unlock()
 
 	tsp.pendingPolicy = cfgs
+	tsp.pendingVersion = version
 }
 
 func (tsp *tailSamplingSpanProcessor) loadPendingSamplingPolicy() {
@@ -316,11 +429,12 @@ func (tsp *tailSamplingSpanProcessor) loadPendingSamplingPolicy() {
 
 	tsp.logger.Debug("Loading pending sampling policy", zap.Int("pending.len", pLen))
 
-	err := tsp.loadSamplingPolicy(tsp.pendingPolicy)
+	err := tsp.loadSamplingPolicy(tsp.pendingVersion, tsp.pendingPolicy)
 
 	// Empty pending regardless of error. If policy is invalid, it will fail on
 	// every tick, no need to do extra work and flood the log with errors.
 	tsp.pendingPolicy = nil
+	tsp.pendingVersion = ""
 
 	if err != nil {
 		tsp.logger.Error("Failed to load pending sampling policy", zap.Error(err))
@@ -329,6 +443,11 @@ func (tsp *tailSamplingSpanProcessor) loadPendingSamplingPolicy() {
 }
 
 func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
+	tsp.tickMux.Lock()
+	defer tsp.tickMux.Unlock()
+	if tsp.stopped {
+		return
+	}
 	tsp.logger.Debug("Sampling Policy Evaluation ticked")
 
 	tsp.loadPendingSamplingPolicy()
@@ -348,22 +467,29 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		}
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
+		trace.Lock()
+		tsp.loadSpilledBatches(ctx, id, trace)
+		trace.Unlock()
 
 		decision := tsp.makeDecision(id, trace, &metrics)
 
 		tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
-		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttribute[decision])
+		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttribute[decision], tsp.policyVersion)
 
-		// Sampled or not, remove the batches
+		// Sampled or not, remove the batches. The spans spilled while the policies were evaluated
+		// are loaded under the same lock as the decision is set, so that none are left behind.
 		trace.Lock()
+		tsp.loadSpilledBatches(ctx, id, trace)
 		allSpans := trace.ReceivedBatches
 		trace.FinalDecision = decision
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
+		tsp.numSpansInMemory.Add(-int64(allSpans.SpanCount()))
 
 		switch decision {
 		case sampling.Sampled:
-			tsp.releaseSampledTrace(ctx, id, allSpans)
+			sampling.UpdateTraceState(allSpans, trace.SampledThreshold)
+			tsp.releaseSampledTrace(ctx, id, allSpans, trace.SampledThreshold)
 		case sampling.NotSampled:
 			tsp.releaseNotSampledTrace(id)
 		}
@@ -377,6 +503,7 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int("batch.len", batchLen),
 		zap.Int64("sampled", metrics.decisionSampled),
 		zap.Int64("notSampled", metrics.decisionNotSampled),
+		zap.Int64("dropped", metrics.decisionDropped),
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
 	)
@@ -391,13 +518,26 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampling.InvertSampled:    nil,
 		sampling.InvertNotSampled: nil,
 	}
+	// The lowest threshold of the policies per decision, as a trace is sampled with the
+	// probability of the most permissive policy sampling it.
+	thresholds := map[sampling.Decision]pkgsampling.Threshold{}
 
 	ctx := context.Background()
+
+	// A trace matching a drop policy is not sampled, whatever the decisions of the other policies.
+	if tsp.matchDropPolicies(ctx, id, trace, metrics) {
+		trace.Lock()
+		trace.SampledThreshold = pkgsampling.NeverSampleThreshold
+		trace.Unlock()
+		metrics.decisionDropped++
+		return sampling.NotSampled
+	}
+
 	startTime := time.Now()
 
 	// Check all policies before making a final decision.
 	for _, p := range tsp.policies {
-		decision, err := p.evaluator.Evaluate(ctx, id, trace)
+		decision, threshold, err := sampling.EvaluateWithThreshold(ctx, p.evaluator, id, trace)
 		latency := time.Since(startTime)
 		tsp.telemetry.ProcessorTailSamplingSamplingDecisionLatency.Record(ctx, int64(latency/time.Microsecond), p.attribute)
 
@@ -420,9 +560,13 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		if samplingDecisions[decision] == nil {
 			samplingDecisions[decision] = p
 		}
+		if current, ok := thresholds[decision]; !ok || pkgsampling.ThresholdLessThan(threshold, current) {
+			thresholds[decision] = threshold
+		}
 	}
 
 	var sampledPolicy *policy
+	sampledThreshold := pkgsampling.NeverSampleThreshold
 
 	// InvertNotSampled takes precedence over any other decision
 	switch {
@@ -431,11 +575,21 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	case samplingDecisions[sampling.Sampled] != nil:
 		finalDecision = sampling.Sampled
 		sampledPolicy = samplingDecisions[sampling.Sampled]
+		sampledThreshold = thresholds[sampling.Sampled]
+		if invertThreshold, ok := thresholds[sampling.InvertSampled]; ok && samplingDecisions[sampling.NotSampled] == nil &&
+			pkgsampling.ThresholdLessThan(invertThreshold, sampledThreshold) {
+			sampledThreshold = invertThreshold
+		}
 	case samplingDecisions[sampling.InvertSampled] != nil && samplingDecisions[sampling.NotSampled] == nil:
 		finalDecision = sampling.Sampled
 		sampledPolicy = samplingDecisions[sampling.InvertSampled]
+		sampledThreshold = thresholds[sampling.InvertSampled]
 	}
 
+	trace.Lock()
+	trace.SampledThreshold = sampledThreshold
+	trace.Unlock()
+
 	if tsp.recordPolicy && sampledPolicy != nil {
 		sampling.SetAttrOnScopeSpans(trace, "tailsampling.policy", sampledPolicy.name)
 	}
@@ -450,6 +604,27 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	return finalDecision
 }
 
+// matchDropPolicies evaluates the drop policies, returning whether one of them matched the trace.
+func (tsp *tailSamplingSpanProcessor) matchDropPolicies(ctx context.Context, id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) bool {
+	for _, p := range tsp.dropPolicies {
+		startTime := time.Now()
+		decision, err := p.evaluator.Evaluate(ctx, id, trace)
+		tsp.telemetry.ProcessorTailSamplingSamplingDecisionLatency.Record(ctx, int64(time.Since(startTime)/time.Microsecond), p.attribute)
+
+		if err != nil {
+			metrics.evaluateErrorCount++
+			tsp.logger.Debug("Drop policy error", zap.Error(err))
+			continue
+		}
+
+		if decision == sampling.Dropped {
+			tsp.telemetry.ProcessorTailSamplingCountTracesDropped.Add(ctx, 1, p.attribute)
+			return true
+		}
+	}
+	return false
+}
+
 // ConsumeTraces is required by the processor.Traces interface.
 func (tsp *tailSamplingSpanProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
 	resourceSpans := td.ResourceSpans()
@@ -487,11 +662,12 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	var newTraceIDs int64
 	for id, spans := range idToSpansAndScope {
 		// If the trace ID is in the sampled cache, short circuit the decision
-		if _, ok := tsp.sampledIDCache.Get(id); ok {
+		if sampledThreshold, ok := tsp.sampledIDCache.Get(id); ok {
 			tsp.logger.Debug("Trace ID is in the sampled cache", zap.Stringer("id", id))
 			traceTd := ptrace.NewTraces()
 			appendToTraces(traceTd, resourceSpans, spans)
-			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+			sampling.UpdateTraceState(traceTd, sampledThreshold)
+			tsp.releaseSampledTrace(tsp.ctx, id, traceTd, sampledThreshold)
 			metric.WithAttributeSet(attribute.NewSet())
 			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
 				Add(tsp.ctx, int64(len(spans)), attrSampledTrue)
@@ -520,18 +696,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 			if d, loaded = tsp.idToTrace.LoadOrStore(id, td); !loaded {
 				newTraceIDs++
-				tsp.decisionBatcher.AddToCurrentBatch(id)
-				tsp.numTracesOnMap.Add(1)
-				postDeletion := false
-				for !postDeletion {
-					select {
-					case tsp.deleteChan <- id:
-						postDeletion = true
-					default:
-						traceKeyToDrop := <-tsp.deleteChan
-						tsp.dropTrace(traceKeyToDrop, currTime)
-					}
-				}
+				tsp.trackNewTrace(id, currTime)
 			}
 		}
 
@@ -545,18 +710,20 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		if finalDecision == sampling.Unspecified {
 			// If the final decision hasn't been made, add the new spans under the lock.
-			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
+			tsp.bufferSpans(id, actualData, resourceSpans, spans)
 			actualData.Unlock()
 			continue
 		}
 
+		sampledThreshold := actualData.SampledThreshold
 		actualData.Unlock()
 
 		switch finalDecision {
 		case sampling.Sampled:
 			traceTd := ptrace.NewTraces()
 			appendToTraces(traceTd, resourceSpans, spans)
-			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+			sampling.UpdateTraceState(traceTd, sampledThreshold)
+			tsp.releaseSampledTrace(tsp.ctx, id, traceTd, sampledThreshold)
 		case sampling.NotSampled:
 			tsp.releaseNotSampledTrace(id)
 		default:
@@ -571,20 +738,203 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	tsp.telemetry.ProcessorTailSamplingNewTraceIDReceived.Add(tsp.ctx, newTraceIDs)
 }
 
+// trackNewTrace schedules the sampling decision of a trace just added to the map, and drops the
+// oldest trace if the map is full.
+func (tsp *tailSamplingSpanProcessor) trackNewTrace(id pcommon.TraceID, currTime time.Time) {
+	tsp.decisionBatcher.AddToCurrentBatch(id)
+	tsp.numTracesOnMap.Add(1)
+	postDeletion := false
+	for !postDeletion {
+		select {
+		case tsp.deleteChan <- id:
+			postDeletion = true
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
+// bufferSpans adds the spans to the batches of a pending trace, which must be locked. Once the
+// spans kept in memory reach max_spans_in_memory, the spans are spilled to the storage extension
+// instead, to be loaded back when the sampling decision is made.
+func (tsp *tailSamplingSpanProcessor) bufferSpans(id pcommon.TraceID, trace *sampling.TraceData, resourceSpans ptrace.ResourceSpans, spans []spanAndScope) {
+	lenSpans := int64(len(spans))
+	if tsp.storage != nil && tsp.numSpansInMemory.Load()+lenSpans > tsp.maxSpansInMemory {
+		batch := ptrace.NewTraces()
+		appendToTraces(batch, resourceSpans, spans)
+		err := tsp.storage.spill(tsp.ctx, id, trace.SpilledBatches, batch)
+		if err == nil {
+			trace.SpilledBatches++
+			return
+		}
+		tsp.logger.Warn("Failed to spill spans to storage, keeping them in memory", zap.Stringer("id", id), zap.Error(err))
+	}
+	appendToTraces(trace.ReceivedBatches, resourceSpans, spans)
+	tsp.numSpansInMemory.Add(lenSpans)
+}
+
+// loadSpilledBatches moves the batches of a trace spilled to the storage extension back into
+// memory, so that the policies evaluate all the spans of the trace. The trace must be locked.
+func (tsp *tailSamplingSpanProcessor) loadSpilledBatches(ctx context.Context, id pcommon.TraceID, trace *sampling.TraceData) {
+	if trace.SpilledBatches == 0 {
+		return
+	}
+	loaded := ptrace.NewTraces()
+	err := tsp.storage.load(ctx, id, trace.SpilledBatches, loaded)
+	if err != nil {
+		tsp.logger.Warn("Failed to load spilled spans from storage, deciding on the spans in memory", zap.Stringer("id", id), zap.Error(err))
+		if err = tsp.storage.remove(ctx, id, trace.SpilledBatches); err != nil {
+			tsp.logger.Warn("Failed to remove spilled spans from storage", zap.Stringer("id", id), zap.Error(err))
+		}
+	}
+	trace.SpilledBatches = 0
+	tsp.numSpansInMemory.Add(int64(loaded.SpanCount()))
+	loaded.ResourceSpans().MoveAndAppendTo(trace.ReceivedBatches.ResourceSpans())
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.storageID, tsp.set.ID)
+		if err != nil {
+			return err
+		}
+		tsp.storage = newTraceStorage(client)
+		if err = tsp.restoreState(ctx); err != nil {
+			return err
+		}
+	}
+	if tsp.policySource != nil {
+		if err := tsp.policySource.start(host); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
+	if tsp.policySource != nil {
+		tsp.policySource.shutdown()
+	}
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	tsp.tickMux.Lock()
+	tsp.stopped = true
+	tsp.tickMux.Unlock()
+	tsp.telemetry.Shutdown()
+	if tsp.storage == nil {
+		return nil
+	}
+	err := tsp.saveState(ctx)
+	return errors.Join(err, tsp.storage.client.Close(ctx))
+}
+
+// saveState writes the pending traces, along with their spans, and the decision caches to the
+// storage extension, so that they survive a restart.
+func (tsp *tailSamplingSpanProcessor) saveState(ctx context.Context) error {
+	var errs error
+	var pending []pendingTrace
+	tsp.idToTrace.Range(func(key, value any) bool {
+		id := key.(pcommon.TraceID)
+		trace := value.(*sampling.TraceData)
+		trace.Lock()
+		defer trace.Unlock()
+		if trace.FinalDecision != sampling.Unspecified {
+			return true
+		}
+		if trace.ReceivedBatches.ResourceSpans().Len() > 0 {
+			if err := tsp.storage.spill(ctx, id, trace.SpilledBatches, trace.ReceivedBatches); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to save trace %s: %w", id, err))
+				return true
+			}
+			trace.SpilledBatches++
+		}
+		pending = append(pending, pendingTrace{
+			TraceID:     id,
+			ArrivalTime: trace.ArrivalTime,
+			SpanCount:   trace.SpanCount.Load(),
+			Batches:     trace.SpilledBatches,
+		})
+		return true
+	})
+	errs = errors.Join(errs, tsp.storage.savePendingTraces(ctx, pending))
+
+	if c, ok := tsp.sampledIDCache.(cache.KeysCache[pkgsampling.Threshold]); ok {
+		ids := c.Keys()
+		decisions := make([]sampledDecision, 0, len(ids))
+		for _, id := range ids {
+			if threshold, ok := c.Get(id); ok {
+				decisions = append(decisions, sampledDecision{TraceID: id, Threshold: threshold})
+			}
+		}
+		errs = errors.Join(errs, tsp.storage.saveSampledDecisions(ctx, decisions))
+	}
+	if c, ok := tsp.nonSampledIDCache.(cache.KeysCache[bool]); ok {
+		errs = errors.Join(errs, tsp.storage.saveDecisions(ctx, nonSampledDecisionsKey, c.Keys()))
+	}
+	return errs
+}
+
+// restoreState reads the state saved by saveState. The restored pending traces are decided
+// after decision_wait, counted from the start of the processor. The spilled batches of the
+// other traces, left behind by a crash, are removed.
+func (tsp *tailSamplingSpanProcessor) restoreState(ctx context.Context) error {
+	sampled, err := tsp.storage.loadSampledDecisions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range sampled {
+		tsp.sampledIDCache.Put(d.TraceID, d.Threshold)
+	}
+	nonSampled, err := tsp.storage.loadDecisions(ctx, nonSampledDecisionsKey)
+	if err != nil {
+		return err
+	}
+	for _, id := range nonSampled {
+		tsp.nonSampledIDCache.Put(id, true)
+	}
+
+	pending, err := tsp.storage.loadPendingTraces(ctx)
+	if err != nil {
+		return err
+	}
+	spilled := make(map[pcommon.TraceID]bool, len(pending))
+	for _, p := range pending {
+		if p.Batches > 0 {
+			spilled[p.TraceID] = true
+		}
+	}
+	orphaned, err := tsp.storage.sweep(ctx, spilled)
+	if err != nil {
+		return err
+	}
+	currTime := time.Now()
+	for _, p := range pending {
+		spanCount := &atomic.Int64{}
+		spanCount.Store(p.SpanCount)
+		td := &sampling.TraceData{
+			ArrivalTime:     p.ArrivalTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: ptrace.NewTraces(),
+			SpilledBatches:  p.Batches,
+		}
+		if _, loaded := tsp.idToTrace.LoadOrStore(p.TraceID, td); !loaded {
+			tsp.trackNewTrace(p.TraceID, currTime)
+		}
+	}
+	tsp.logger.Debug("Restored state from storage",
+		zap.Int("pending", len(pending)),
+		zap.Int("sampled", len(sampled)),
+		zap.Int("notSampled", len(nonSampled)),
+		zap.Int("orphaned", orphaned),
+	)
 	return nil
 }
 
@@ -601,14 +951,27 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		return
 	}
 
+	// Release the spans of a trace dropped before its sampling decision.
+	trace.Lock()
+	tsp.numSpansInMemory.Add(-int64(trace.ReceivedBatches.SpanCount()))
+	spilledBatches := trace.SpilledBatches
+	trace.SpilledBatches = 0
+	trace.Unlock()
+	if spilledBatches > 0 {
+		if err := tsp.storage.remove(tsp.ctx, traceID, spilledBatches); err != nil {
+			tsp.logger.Warn("Failed to remove spilled spans from storage", zap.Stringer("id", traceID), zap.Error(err))
+		}
+	}
+
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.ArrivalTime)/time.Second))
 }
 
 // releaseSampledTrace sends the trace data to the next consumer. It
-// additionally adds the trace ID to the cache of sampled trace IDs. If the
-// trace ID is cached, it deletes the spans from the internal map.
-func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, id pcommon.TraceID, td ptrace.Traces) {
-	tsp.sampledIDCache.Put(id, true)
+// additionally adds the trace ID to the cache of sampled trace IDs, along with
+// the threshold it was sampled with. If the trace ID is cached, it deletes the
+// spans from the internal map.
+func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, id pcommon.TraceID, td ptrace.Traces, threshold pkgsampling.Threshold) {
+	tsp.sampledIDCache.Put(id, threshold)
 	if err := tsp.nextConsumer.ConsumeTraces(ctx, td); err != nil {
 		tsp.logger.Warn(
 			"Error sending spans to destination",
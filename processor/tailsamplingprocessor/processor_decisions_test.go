@@ -11,13 +11,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadatatest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
 
@@ -636,3 +640,105 @@ func TestLateSpanUsesNonSampledDecisionCache(t *testing.T) {
 	require.EqualValues(t, 1, mpe.EvaluationCount)
 	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
+
+func TestDropPolicyOverridesSampled(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	}()
+	set := processortest.NewNopSettings(metadata.Type)
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	nextConsumer := new(consumertest.TracesSink)
+
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name:          "errors",
+					Type:          StatusCode,
+					StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"ERROR"}},
+				},
+			},
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "health-checks",
+					Type: Drop,
+				},
+				DropCfg: DropCfg{
+					SubPolicyCfg: []AndSubPolicyCfg{
+						{
+							sharedPolicyCfg: sharedPolicyCfg{
+								Name:               "health-check-path",
+								Type:               StringAttribute,
+								StringAttributeCfg: StringAttributeCfg{Key: "url.path", Values: []string{"/health"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), set, nextConsumer, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	require.Len(t, tsp.policies, 1)
+	require.Len(t, tsp.dropPolicies, 1)
+
+	// Both traces have an error, only the one of the health check is dropped.
+	healthCheckID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	checkoutID := pcommon.TraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	for id, path := range map[pcommon.TraceID]string{healthCheckID: "/health", checkoutID: "/checkout"} {
+		traces := simpleTracesWithID(id)
+		span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		span.Attributes().PutStr("url.path", path)
+		span.Status().SetCode(ptrace.StatusCodeError)
+		require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	}
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	require.Len(t, nextConsumer.AllTraces(), 1)
+	assert.Equal(t, checkoutID, nextConsumer.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	metadatatest.AssertEqualProcessorTailSamplingCountTracesDropped(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("policy", "health-checks")),
+				Value:      1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+	// The sampling policies aren't evaluated for the dropped traces.
+	metadatatest.AssertEqualProcessorTailSamplingCountTracesSampled(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("policy", "errors"), attribute.String("sampled", "true")),
+				Value:      1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorTailSamplingGlobalCountTracesSampled(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("sampled", "false")),
+				Value:      1,
+			},
+			{
+				Attributes: attribute.NewSet(attribute.String("sampled", "true")),
+				Value:      1,
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
@@ -595,7 +596,7 @@ func TestPolicyLoggerAddsPolicyName(t *testing.T) {
 		Type: AlwaysSample, // we test only one evaluator
 	}
 
-	evaluator, err := getSharedPolicyEvaluator(set, cfg)
+	evaluator, err := getSharedPolicyEvaluator(set, "test-policy", cfg)
 	require.NoError(t, err)
 
 	// test
@@ -669,6 +670,65 @@ func TestDecisionPolicyMetrics(t *testing.T) {
 	assert.EqualValues(t, 0, metrics.evaluateErrorCount)
 }
 
+func TestSampledThresholdInTraceState(t *testing.T) {
+	err := featuregate.GlobalRegistry().Set("processor.tailsamplingprocessor.consistentsampling", true)
+	require.NoError(t, err)
+	defer func() {
+		err = featuregate.GlobalRegistry().Set("processor.tailsamplingprocessor.consistentsampling", false)
+		require.NoError(t, err)
+	}()
+
+	// Late spans are released from the trace kept in memory without a decision cache, and from the
+	// decision cache otherwise.
+	for name, sampledCacheSize := range map[string]int{"without decision cache": 0, "with decision cache": 100} {
+		t.Run(name, func(t *testing.T) {
+			idb := newSyncIDBatcher()
+			msp := new(consumertest.TracesSink)
+
+			cfg := Config{
+				DecisionWait:  defaultTestDecisionWait,
+				NumTraces:     defaultNumTraces,
+				DecisionCache: DecisionCacheConfig{SampledCacheSize: sampledCacheSize},
+				PolicyCfgs: []PolicyCfg{
+					{
+						sharedPolicyCfg: sharedPolicyCfg{
+							Name:             "half",
+							Type:             Probabilistic,
+							ProbabilisticCfg: ProbabilisticCfg{SamplingPercentage: 50},
+						},
+					},
+				},
+				Options: []Option{
+					withDecisionBatcher(idb),
+				},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), msp, cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+			td := simpleTracesWithID(traceID)
+			td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().FromRaw("vendor=value")
+			require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+			tsp := p.(*tailSamplingSpanProcessor)
+			tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+			tsp.policyTicker.OnTick()
+
+			// Late spans are sampled with the same threshold.
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+			require.Len(t, msp.AllTraces(), 2)
+			assert.Equal(t, "ot=th:8,vendor=value", msp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().AsRaw())
+			assert.Equal(t, "ot=th:8", msp.AllTraces()[1].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().AsRaw())
+		})
+	}
+}
+
 func collectSpanIDs(trace ptrace.Traces) []pcommon.SpanID {
 	var spanIDs []pcommon.SpanID
 
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+)
+
+// sampledIDCache adapts a cache of sampled trace IDs to the cache of sampling thresholds used by
+// the processor. The threshold of the traces isn't kept: they are reported as sampled with
+// AlwaysSampleThreshold, so their late spans are released without a threshold.
+type sampledIDCache struct {
+	ids cache.Cache[bool]
+}
+
+// newSampledIDCache returns the cache of sampling thresholds backed by the given cache of sampled
+// trace IDs. It is a cache.KeysCache if the given cache is one.
+func newSampledIDCache(ids cache.Cache[bool]) cache.Cache[pkgsampling.Threshold] {
+	c := &sampledIDCache{ids: ids}
+	if keys, ok := ids.(cache.KeysCache[bool]); ok {
+		return &sampledIDKeysCache{sampledIDCache: c, keys: keys}
+	}
+	return c
+}
+
+func (c *sampledIDCache) Get(id pcommon.TraceID) (pkgsampling.Threshold, bool) {
+	if _, ok := c.ids.Get(id); !ok {
+		return pkgsampling.Threshold{}, false
+	}
+	return pkgsampling.AlwaysSampleThreshold, true
+}
+
+func (c *sampledIDCache) Put(id pcommon.TraceID, _ pkgsampling.Threshold) {
+	c.ids.Put(id, true)
+}
+
+func (c *sampledIDCache) Delete(id pcommon.TraceID) {
+	c.ids.Delete(id)
+}
+
+type sampledIDKeysCache struct {
+	*sampledIDCache
+	keys cache.KeysCache[bool]
+}
+
+func (c *sampledIDKeysCache) Keys() []pcommon.TraceID {
+	return c.keys.Keys()
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+)
+
+func TestSampledIDCache(t *testing.T) {
+	ids, err := cache.NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+	c := newSampledIDCache(ids)
+
+	threshold, err := pkgsampling.ProbabilityToThreshold(0.25)
+	require.NoError(t, err)
+	c.Put(uInt64ToTraceID(1), threshold)
+
+	got, ok := c.Get(uInt64ToTraceID(1))
+	assert.True(t, ok)
+	assert.Equal(t, pkgsampling.AlwaysSampleThreshold, got)
+	_, ok = ids.Get(uInt64ToTraceID(1))
+	assert.True(t, ok)
+
+	keys, ok := c.(cache.KeysCache[pkgsampling.Threshold])
+	require.True(t, ok)
+	assert.Equal(t, ids.(cache.KeysCache[bool]).Keys(), keys.Keys())
+	assert.Len(t, keys.Keys(), 1)
+
+	_, ok = c.Get(uInt64ToTraceID(2))
+	assert.False(t, ok)
+
+	_, ok = newSampledIDCache(cache.NewNopDecisionCache[bool]()).(cache.KeysCache[pkgsampling.Threshold])
+	assert.False(t, ok)
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+const (
+	pendingTracesKey       = "pending_traces"
+	sampledDecisionsKey    = "sampled_decisions"
+	nonSampledDecisionsKey = "non_sampled_decisions"
+	spillSlotsKey          = "spill_slots"
+
+	// sampledDecisionLen is the length of a saved sampled decision: a trace ID and a threshold.
+	sampledDecisionLen = 16 + 8
+)
+
+// pendingTrace is the state of a trace waiting for a sampling decision, saved on shutdown. Its
+// spans are saved as spilled batches.
+type pendingTrace struct {
+	TraceID     pcommon.TraceID `json:"trace_id"`
+	ArrivalTime time.Time       `json:"arrival_time"`
+	SpanCount   int64           `json:"span_count"`
+	Batches     int             `json:"batches"`
+}
+
+// sampledDecision is an entry of the sampled decision cache, saved on shutdown.
+type sampledDecision struct {
+	TraceID   pcommon.TraceID
+	Threshold pkgsampling.Threshold
+}
+
+// traceStorage keeps the span batches spilled by the processor, as well as the state saved on
+// shutdown, in a storage extension.
+//
+// As the storage extension can't list its keys, each trace with spilled batches holds a spill
+// slot: a key, written along with its first batch, recording its ID. The number of slots ever
+// used is saved as well, so that the batches left behind by a crash are found on start.
+type traceStorage struct {
+	client      storage.Client
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	slotsMux  sync.Mutex
+	slots     map[pcommon.TraceID]int
+	freeSlots []int
+	numSlots  int
+}
+
+func newTraceStorage(client storage.Client) *traceStorage {
+	return &traceStorage{
+		client: client,
+		slots:  make(map[pcommon.TraceID]int),
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+func batchKey(id pcommon.TraceID, index int) string {
+	return fmt.Sprintf("batch.%s.%d", id, index)
+}
+
+func spillSlotKey(slot int) string {
+	return fmt.Sprintf("spill_slot.%d", slot)
+}
+
+// spill writes a batch of the given trace at the given index. The first batch of a trace is
+// written along with the spill slot of the trace.
+func (s *traceStorage) spill(ctx context.Context, id pcommon.TraceID, index int, td ptrace.Traces) error {
+	value, err := s.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	if index > 0 {
+		return s.client.Set(ctx, batchKey(id, index), value)
+	}
+
+	s.slotsMux.Lock()
+	slot, ok := s.slots[id]
+	if !ok && len(s.freeSlots) == 0 {
+		// A new slot is written along with the number of slots under the lock, so that the saved
+		// number never goes back.
+		defer s.slotsMux.Unlock()
+		slot = s.numSlots
+		err = s.client.Batch(ctx,
+			storage.SetOperation(batchKey(id, index), value),
+			storage.SetOperation(spillSlotKey(slot), id[:]),
+			storage.SetOperation(spillSlotsKey, binary.BigEndian.AppendUint64(nil, uint64(slot+1))),
+		)
+		if err == nil {
+			s.numSlots++
+			s.slots[id] = slot
+		}
+		return err
+	}
+	if !ok {
+		slot = s.freeSlots[len(s.freeSlots)-1]
+		s.freeSlots = s.freeSlots[:len(s.freeSlots)-1]
+		s.slots[id] = slot
+	}
+	s.slotsMux.Unlock()
+
+	err = s.client.Batch(ctx,
+		storage.SetOperation(batchKey(id, index), value),
+		storage.SetOperation(spillSlotKey(slot), id[:]),
+	)
+	if err != nil && !ok {
+		s.releaseSlot(id, slot)
+	}
+	return err
+}
+
+// releaseSlot frees the spill slot held by the given trace.
+func (s *traceStorage) releaseSlot(id pcommon.TraceID, slot int) {
+	s.slotsMux.Lock()
+	defer s.slotsMux.Unlock()
+	delete(s.slots, id)
+	s.freeSlots = append(s.freeSlots, slot)
+}
+
+// load reads and deletes the given number of batches spilled for the given trace, appending them
+// to dest.
+func (s *traceStorage) load(ctx context.Context, id pcommon.TraceID, batches int, dest ptrace.Traces) error {
+	ops := make([]*storage.Operation, batches)
+	for i := range ops {
+		ops[i] = storage.GetOperation(batchKey(id, i))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op.Value == nil {
+			return fmt.Errorf("batch %q not found", op.Key)
+		}
+		td, err := s.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal batch %q: %w", op.Key, err)
+		}
+		td.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+	}
+	return s.remove(ctx, id, batches)
+}
+
+// remove deletes the given number of batches spilled for the given trace, along with its spill
+// slot.
+func (s *traceStorage) remove(ctx context.Context, id pcommon.TraceID, batches int) error {
+	ops := make([]*storage.Operation, batches, batches+1)
+	for i := range ops {
+		ops[i] = storage.DeleteOperation(batchKey(id, i))
+	}
+	s.slotsMux.Lock()
+	slot, ok := s.slots[id]
+	s.slotsMux.Unlock()
+	if ok {
+		ops = append(ops, storage.DeleteOperation(spillSlotKey(slot)))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	if ok {
+		s.releaseSlot(id, slot)
+	}
+	return nil
+}
+
+// sweep reads the spill slots saved in the storage extension. The slots of the given traces,
+// restored with their spilled batches, are kept. The batches of the other traces were left
+// behind by a crash, they are removed along with their slots. It returns the number of traces
+// whose batches were removed.
+func (s *traceStorage) sweep(ctx context.Context, keep map[pcommon.TraceID]bool) (int, error) {
+	value, err := s.client.Get(ctx, spillSlotsKey)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid length %d of the number of spill slots", len(value))
+	}
+	s.numSlots = int(binary.BigEndian.Uint64(value))
+	removed := 0
+	for slot := 0; slot < s.numSlots; slot++ {
+		value, err = s.client.Get(ctx, spillSlotKey(slot))
+		if err != nil {
+			return removed, err
+		}
+		if len(value) == len(pcommon.TraceID{}) {
+			id := pcommon.TraceID(value)
+			if keep[id] {
+				s.slots[id] = slot
+				continue
+			}
+			if err = s.removeOrphan(ctx, id); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+		if err = s.client.Delete(ctx, spillSlotKey(slot)); err != nil {
+			return removed, err
+		}
+		s.freeSlots = append(s.freeSlots, slot)
+	}
+	return removed, nil
+}
+
+// removeOrphan deletes the batches spilled for the given trace, whose number isn't known.
+func (s *traceStorage) removeOrphan(ctx context.Context, id pcommon.TraceID) error {
+	var ops []*storage.Operation
+	for i := 0; ; i++ {
+		value, err := s.client.Get(ctx, batchKey(id, i))
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			break
+		}
+		ops = append(ops, storage.DeleteOperation(batchKey(id, i)))
+	}
+	return s.client.Batch(ctx, ops...)
+}
+
+func (s *traceStorage) savePendingTraces(ctx context.Context, traces []pendingTrace) error {
+	value, err := json.Marshal(traces)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, pendingTracesKey, value)
+}
+
+// loadPendingTraces reads and deletes the pending traces saved on shutdown.
+func (s *traceStorage) loadPendingTraces(ctx context.Context) ([]pendingTrace, error) {
+	value, err := s.client.Get(ctx, pendingTracesKey)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	var traces []pendingTrace
+	if err = json.Unmarshal(value, &traces); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pending traces: %w", err)
+	}
+	return traces, s.client.Delete(ctx, pendingTracesKey)
+}
+
+// saveDecisions writes the trace IDs of a decision cache under the given key, as a sequence of
+// 16 bytes IDs.
+func (s *traceStorage) saveDecisions(ctx context.Context, key string, ids []pcommon.TraceID) error {
+	value := make([]byte, 0, len(ids)*len(pcommon.TraceID{}))
+	for _, id := range ids {
+		value = append(value, id[:]...)
+	}
+	return s.client.Set(ctx, key, value)
+}
+
+// loadDecisions reads and deletes the trace IDs of a decision cache saved under the given key.
+func (s *traceStorage) loadDecisions(ctx context.Context, key string) ([]pcommon.TraceID, error) {
+	value, err := s.client.Get(ctx, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	idLen := len(pcommon.TraceID{})
+	if len(value)%idLen != 0 {
+		return nil, fmt.Errorf("invalid length %d of the decisions saved under %q", len(value), key)
+	}
+	ids := make([]pcommon.TraceID, 0, len(value)/idLen)
+	for i := 0; i < len(value); i += idLen {
+		ids = append(ids, pcommon.TraceID(value[i:i+idLen]))
+	}
+	return ids, s.client.Delete(ctx, key)
+}
+
+// saveSampledDecisions writes the entries of the sampled decision cache, as a sequence of 16 bytes
+// IDs each followed by the 8 bytes of its threshold.
+func (s *traceStorage) saveSampledDecisions(ctx context.Context, decisions []sampledDecision) error {
+	value := make([]byte, 0, len(decisions)*sampledDecisionLen)
+	for _, d := range decisions {
+		value = append(value, d.TraceID[:]...)
+		value = binary.BigEndian.AppendUint64(value, d.Threshold.Unsigned())
+	}
+	return s.client.Set(ctx, sampledDecisionsKey, value)
+}
+
+// loadSampledDecisions reads and deletes the entries of the sampled decision cache.
+func (s *traceStorage) loadSampledDecisions(ctx context.Context) ([]sampledDecision, error) {
+	value, err := s.client.Get(ctx, sampledDecisionsKey)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	if len(value)%sampledDecisionLen != 0 {
+		return nil, fmt.Errorf("invalid length %d of the decisions saved under %q", len(value), sampledDecisionsKey)
+	}
+	idLen := len(pcommon.TraceID{})
+	decisions := make([]sampledDecision, 0, len(value)/sampledDecisionLen)
+	for i := 0; i < len(value); i += sampledDecisionLen {
+		threshold, err := pkgsampling.UnsignedToThreshold(binary.BigEndian.Uint64(value[i+idLen : i+sampledDecisionLen]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold of the decisions saved under %q: %w", sampledDecisionsKey, err)
+		}
+		decisions = append(decisions, sampledDecision{
+			TraceID:   pcommon.TraceID(value[i : i+idLen]),
+			Threshold: threshold,
+		})
+	}
+	return decisions, s.client.Delete(ctx, sampledDecisionsKey)
+}
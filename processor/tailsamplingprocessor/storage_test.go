@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newStorageTestProcessor(t *testing.T, storageID component.ID, maxSpansInMemory uint64, sink *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait:     defaultTestDecisionWait,
+		NumTraces:        defaultNumTraces,
+		PolicyCfgs:       testPolicy,
+		DecisionCache:    DecisionCacheConfig{SampledCacheSize: 100},
+		Storage:          &storageID,
+		MaxSpansInMemory: maxSpansInMemory,
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestSpillBatchesToStorage(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, storagetest.NewStorageID("spill"), 2, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceIDs, batches := generateIDsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	// The first two spans are kept in memory, the others are spilled.
+	assert.EqualValues(t, 2, tsp.numSpansInMemory.Load())
+	for i, spilled := range []int{0, 1, 3} {
+		d, ok := tsp.idToTrace.Load(traceIDs[i])
+		require.True(t, ok)
+		assert.Equal(t, spilled, d.(*sampling.TraceData).SpilledBatches)
+	}
+
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	require.Len(t, sink.AllTraces(), 3)
+	for i, traceID := range traceIDs {
+		assert.Equal(t, i+1, findTrace(t, sink.AllTraces(), traceID).SpanCount())
+	}
+	assert.EqualValues(t, 0, tsp.numSpansInMemory.Load())
+}
+
+// consumingPolicyEvaluator samples all traces, consuming the given spans while evaluating them.
+type consumingPolicyEvaluator struct {
+	tsp   *tailSamplingSpanProcessor
+	spans ptrace.Traces
+}
+
+func (e *consumingPolicyEvaluator) Evaluate(ctx context.Context, _ pcommon.TraceID, _ *sampling.TraceData) (sampling.Decision, error) {
+	return sampling.Sampled, e.tsp.ConsumeTraces(ctx, e.spans)
+}
+
+func TestSpillBatchesDuringDecision(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, storagetest.NewStorageID("spill"), 0, sink)
+	traceIDs, batches := generateIDsAndBatches(1)
+	tsp.policies = []*policy{{
+		name:      "consuming-policy",
+		evaluator: &consumingPolicyEvaluator{tsp: tsp, spans: simpleTracesWithID(traceIDs[0])},
+		attribute: metric.WithAttributes(attribute.String("policy", "consuming-policy")),
+	}}
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	// The span received while the policy was evaluated is released along with the trace.
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 2, sink.AllTraces()[0].SpanCount())
+	assert.EqualValues(t, 0, tsp.numSpansInMemory.Load())
+}
+
+func TestRestoreStateFromStorage(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("restart", t.TempDir())
+	storageID := storagetest.NewStorageID("restart")
+
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, storageID, 100, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+
+	traceIDs, batches := generateIDsAndBatches(3)
+	// The first trace is sampled before the restart, the others are still pending.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Len(t, sink.AllTraces(), 1)
+	for _, batch := range batches[1:] {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	sink = new(consumertest.TracesSink)
+	tsp = newStorageTestProcessor(t, storageID, 100, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	// Late spans of the sampled trace are released using the restored decision cache.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceIDs[0])))
+	require.Len(t, sink.AllTraces(), 1)
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	require.Len(t, sink.AllTraces(), 3)
+	for i, traceID := range traceIDs[1:] {
+		assert.Equal(t, i+2, findTrace(t, sink.AllTraces()[1:], traceID).SpanCount())
+	}
+	assertNoSpilledBatches(t, tsp, traceIDs, 2)
+}
+
+func TestRemoveSpilledBatchesFailingToLoad(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, storagetest.NewStorageID("spill"), 0, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceIDs, batches := generateIDsAndBatches(1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	require.NoError(t, tsp.storage.client.Set(context.Background(), batchKey(traceIDs[0], 0), []byte("invalid")))
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	assert.EqualValues(t, 0, tsp.numSpansInMemory.Load())
+	assertNoSpilledBatches(t, tsp, traceIDs, 1)
+}
+
+func TestRemoveOrphanedSpilledBatches(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("crash", t.TempDir())
+	storageID := storagetest.NewStorageID("crash")
+
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, storageID, 0, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	traceIDs, batches := generateIDsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	// The processor crashes: its state isn't saved.
+	tsp.policyTicker.Stop()
+	require.NoError(t, tsp.storage.client.Close(context.Background()))
+
+	tsp = newStorageTestProcessor(t, storageID, 0, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	assertNoSpilledBatches(t, tsp, traceIDs, 3)
+	assert.Empty(t, sink.AllTraces())
+}
+
+// assertNoSpilledBatches asserts that no batches, nor spill slots, are left in the storage of the
+// processor for the given traces.
+func assertNoSpilledBatches(t *testing.T, tsp *tailSamplingSpanProcessor, traceIDs []pcommon.TraceID, slots int) {
+	for _, id := range traceIDs {
+		value, err := tsp.storage.client.Get(context.Background(), batchKey(id, 0))
+		require.NoError(t, err)
+		assert.Nil(t, value, "batch of trace %s", id)
+	}
+	for slot := range slots {
+		value, err := tsp.storage.client.Get(context.Background(), spillSlotKey(slot))
+		require.NoError(t, err)
+		assert.Nil(t, value, "spill slot %d", slot)
+	}
+}
+
+func TestStartWithMissingStorage(t *testing.T) {
+	host := storagetest.NewStorageHost().WithNonStorageExtension("other")
+	sink := new(consumertest.TracesSink)
+
+	tsp := newStorageTestProcessor(t, storagetest.NewStorageID("missing"), 0, sink)
+	require.ErrorContains(t, tsp.Start(context.Background(), host), "storage extension 'test_storage/missing' not found")
+
+	tsp = newStorageTestProcessor(t, storagetest.NewNonStorageID("other"), 0, sink)
+	require.ErrorContains(t, tsp.Start(context.Background(), host), "non-storage extension 'non_storage/other' found")
+}
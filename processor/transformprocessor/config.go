@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/profiles"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 )
 
@@ -39,9 +40,20 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// ErrorAttribute is the name of the attribute set on the telemetry a statement failed to execute for,
+	// with the error as value, so it can be routed elsewhere. No attribute is set when it is empty.
+	ErrorAttribute string `mapstructure:"error_attribute"`
+
 	TraceStatements  []common.ContextStatements `mapstructure:"trace_statements"`
 	MetricStatements []common.ContextStatements `mapstructure:"metric_statements"`
 	LogStatements    []common.ContextStatements `mapstructure:"log_statements"`
+	// ProfileStatements are the statements applied to the profiles signal, which is in development.
+	ProfileStatements []common.ContextStatements `mapstructure:"profile_statements"`
+
+	// UserFunctions are OTTL functions defined in the configuration, which can be used in the
+	// statements and conditions of all signals. Their invocations are expanded before the
+	// statements are parsed.
+	UserFunctions []ottl.UserFunction `mapstructure:"ottl_functions"`
 
 	FlattenData bool `mapstructure:"flatten_data"`
 	logger      *zap.Logger
@@ -73,9 +85,10 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 	}
 
 	contextStatementsFields := map[string]*[]common.ContextStatements{
-		"trace_statements":  &c.TraceStatements,
-		"metric_statements": &c.MetricStatements,
-		"log_statements":    &c.LogStatements,
+		"trace_statements":   &c.TraceStatements,
+		"metric_statements":  &c.MetricStatements,
+		"log_statements":     &c.LogStatements,
+		"profile_statements": &c.ProfileStatements,
 	}
 
 	flatContextStatements := map[string][]int{}
@@ -129,15 +142,71 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 
 var _ component.Config = (*Config)(nil)
 
+// newUserFunctions returns the UserFunctions expanding the configured user functions, or nil if
+// there are none.
+func (c *Config) newUserFunctions() (*ottl.UserFunctions, error) {
+	if len(c.UserFunctions) == 0 {
+		return nil, nil
+	}
+	return ottl.NewUserFunctions(c.UserFunctions)
+}
+
+// expandUserFunctions returns a copy of the context statements with the invocations of the user
+// functions expanded. The expanded statements keep track of the index of the configured statement
+// they were expanded from.
+func expandUserFunctions(userFunctions *ottl.UserFunctions, contextStatements []common.ContextStatements) ([]common.ContextStatements, error) {
+	if userFunctions == nil {
+		return contextStatements, nil
+	}
+	var err error
+	expanded := make([]common.ContextStatements, len(contextStatements))
+	for i, cs := range contextStatements {
+		expanded[i] = cs
+		expanded[i].Statements = make([]string, 0, len(cs.Statements))
+		expanded[i].StatementIndexes = make([]int, 0, len(cs.Statements))
+		var errs error
+		for j, statement := range cs.Statements {
+			statements, err := userFunctions.ExpandStatements([]string{statement})
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			expanded[i].Statements = append(expanded[i].Statements, statements...)
+			for range statements {
+				expanded[i].StatementIndexes = append(expanded[i].StatementIndexes, j)
+			}
+		}
+		if errs != nil {
+			return nil, errs
+		}
+		if expanded[i].Conditions, err = userFunctions.ExpandConditions(cs.Conditions); err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
+
 func (c *Config) Validate() error {
 	var errors error
 
+	userFunctions, err := c.newUserFunctions()
+	if err != nil {
+		return err
+	}
+
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()), common.WithSpanLinkParser(traces.SpanLinkFunctions()))
 		if err != nil {
 			return err
 		}
-		for _, cs := range c.TraceStatements {
+		if err = pc.ValidateUserFunctions(userFunctions); err != nil {
+			return err
+		}
+		traceStatements, err := expandUserFunctions(userFunctions, c.TraceStatements)
+		if err != nil {
+			return err
+		}
+		for _, cs := range traceStatements {
 			_, err = pc.ParseContextStatements(cs)
 			if err != nil {
 				errors = multierr.Append(errors, err)
@@ -150,7 +219,14 @@ func (c *Config) Validate() error {
 		if err != nil {
 			return err
 		}
-		for _, cs := range c.MetricStatements {
+		if err = pc.ValidateUserFunctions(userFunctions); err != nil {
+			return err
+		}
+		metricStatements, err := expandUserFunctions(userFunctions, c.MetricStatements)
+		if err != nil {
+			return err
+		}
+		for _, cs := range metricStatements {
 			_, err := pc.ParseContextStatements(cs)
 			if err != nil {
 				errors = multierr.Append(errors, err)
@@ -163,7 +239,34 @@ func (c *Config) Validate() error {
 		if err != nil {
 			return err
 		}
-		for _, cs := range c.LogStatements {
+		if err = pc.ValidateUserFunctions(userFunctions); err != nil {
+			return err
+		}
+		logStatements, err := expandUserFunctions(userFunctions, c.LogStatements)
+		if err != nil {
+			return err
+		}
+		for _, cs := range logStatements {
+			_, err = pc.ParseContextStatements(cs)
+			if err != nil {
+				errors = multierr.Append(errors, err)
+			}
+		}
+	}
+
+	if len(c.ProfileStatements) > 0 {
+		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithProfileParser(profiles.ProfileFunctions()))
+		if err != nil {
+			return err
+		}
+		if err = pc.ValidateUserFunctions(userFunctions); err != nil {
+			return err
+		}
+		profileStatements, err := expandUserFunctions(userFunctions, c.ProfileStatements)
+		if err != nil {
+			return err
+		}
+		for _, cs := range profileStatements {
 			_, err = pc.ParseContextStatements(cs)
 			if err != nil {
 				errors = multierr.Append(errors, err)
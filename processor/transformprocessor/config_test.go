@@ -75,6 +75,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -108,6 +109,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -122,8 +124,25 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				MetricStatements:  []common.ContextStatements{},
+				LogStatements:     []common.ContextStatements{},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "error_attribute"),
+			expected: &Config{
+				ErrorMode:        ottl.IgnoreError,
+				ErrorAttribute:   "ottl.error",
+				TraceStatements:  []common.ContextStatements{},
 				MetricStatements: []common.ContextStatements{},
-				LogStatements:    []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements:  []string{`set(log.attributes["prefix"], Substring(log.body, 0, 5))`},
+						SharedCache: true,
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -174,6 +193,7 @@ func TestLoadConfig(t *testing.T) {
 						Statements: []string{`set(log.body, "bear") where log.attributes["http.path"] == "/animal"`},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -204,6 +224,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -240,6 +261,7 @@ func TestLoadConfig(t *testing.T) {
 						Statements:  []string{`set(resource.attributes["name"], "bear")`},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -306,6 +328,7 @@ func TestLoadConfig(t *testing.T) {
 						Statements:  []string{`set(log.body, "lion") where log.attributes["http.path"] == "/animal"`},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -342,6 +365,82 @@ func TestLoadConfig(t *testing.T) {
 						ErrorMode:  "",
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl_functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				UserFunctions: []ottl.UserFunction{
+					{
+						Name:       "IsHealthCheck",
+						Params:     []string{"route"},
+						Expression: `IsMatch(route, "^/(health|ready)z?$")`,
+					},
+					{
+						Name:   "rename_attribute",
+						Params: []string{"map", "from", "to"},
+						Statements: []string{
+							`set(map[to], map[from]) where map[from] != nil`,
+							`delete_key(map, from)`,
+						},
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Conditions: []string{`not IsHealthCheck(span.attributes["http.route"])`},
+						Statements: []string{`rename_attribute(span.attributes, "http.method", "http.request.method")`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`rename_attribute(log.attributes, "level", "severity")`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "profile_statements"),
+			expected: &Config{
+				ErrorMode:        ottl.PropagateError,
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements:    []common.ContextStatements{},
+				ProfileStatements: []common.ContextStatements{
+					{
+						Context:    "profile",
+						Conditions: []string{`original_payload_format == "pprof"`},
+						Statements: []string{`set(attributes["name"], "bear")`},
+					},
+					{
+						Statements:  []string{`set(resource.attributes["name"], "bear")`},
+						SharedCache: true,
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_profile"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl_functions_invalid_definition"),
+			errors: []error{
+				errors.New(`invalid user function "IsHealthCheck": converters must define an expression and no statements`),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl_functions_param_path"),
+			errors: []error{
+				errors.New(`invalid user function "rename_attribute": parameter "attributes" has the name of a path, which it would hide in the body of the function`),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl_functions_wrong_arguments"),
+			errors: []error{
+				errors.New(`user function "IsHealthCheck" expects 1 arguments but got 0`),
 			},
 		},
 	}
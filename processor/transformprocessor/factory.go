@@ -9,35 +9,42 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processorhelper/xprocessorhelper"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/profiles"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 )
 
 var processorCapabilities = consumer.Capabilities{MutatesData: true}
 
 func NewFactory() processor.Factory {
-	return processor.NewFactory(
+	return xprocessor.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		processor.WithLogs(createLogsProcessor, metadata.LogsStability),
-		processor.WithTraces(createTracesProcessor, metadata.TracesStability),
-		processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability),
+		xprocessor.WithLogs(createLogsProcessor, metadata.LogsStability),
+		xprocessor.WithTraces(createTracesProcessor, metadata.TracesStability),
+		xprocessor.WithMetrics(createMetricsProcessor, metadata.MetricsStability),
+		xprocessor.WithProfiles(createProfilesProcessor, metadata.ProfilesStability),
 	)
 }
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		ErrorMode:        ottl.PropagateError,
-		TraceStatements:  []common.ContextStatements{},
-		MetricStatements: []common.ContextStatements{},
-		LogStatements:    []common.ContextStatements{},
+		ErrorMode:         ottl.PropagateError,
+		TraceStatements:   []common.ContextStatements{},
+		MetricStatements:  []common.ContextStatements{},
+		LogStatements:     []common.ContextStatements{},
+		ProfileStatements: []common.ContextStatements{},
 	}
 }
 
@@ -49,17 +56,32 @@ func createLogsProcessor(
 ) (processor.Logs, error) {
 	oCfg := cfg.(*Config)
 
-	proc, err := logs.NewProcessor(oCfg.LogStatements, oCfg.ErrorMode, oCfg.FlattenData, set.TelemetrySettings)
+	userFunctions, err := oCfg.newUserFunctions()
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
+	logStatements, err := expandUserFunctions(userFunctions, oCfg.LogStatements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	logStatements = withStatementErrors(set, oCfg, telemetryBuilder, "log_statements", logStatements)
+	proc, err := logs.NewProcessor(logStatements, oCfg.ErrorMode, oCfg.FlattenData, set.TelemetrySettings)
+	if err != nil {
+		telemetryBuilder.Shutdown()
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
 	return processorhelper.NewLogs(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
 		proc.ProcessLogs,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithShutdown(shutdownTelemetry(telemetryBuilder)))
 }
 
 func createTracesProcessor(
@@ -70,8 +92,22 @@ func createTracesProcessor(
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
 
-	proc, err := traces.NewProcessor(oCfg.TraceStatements, oCfg.ErrorMode, set.TelemetrySettings)
+	userFunctions, err := oCfg.newUserFunctions()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	traceStatements, err := expandUserFunctions(userFunctions, oCfg.TraceStatements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	traceStatements = withStatementErrors(set, oCfg, telemetryBuilder, "trace_statements", traceStatements)
+	proc, err := traces.NewProcessor(traceStatements, oCfg.ErrorMode, set.TelemetrySettings)
 	if err != nil {
+		telemetryBuilder.Shutdown()
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
 	return processorhelper.NewTraces(
@@ -80,7 +116,8 @@ func createTracesProcessor(
 		cfg,
 		nextConsumer,
 		proc.ProcessTraces,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithShutdown(shutdownTelemetry(telemetryBuilder)))
 }
 
 func createMetricsProcessor(
@@ -92,15 +129,92 @@ func createMetricsProcessor(
 	oCfg := cfg.(*Config)
 	oCfg.logger = set.Logger
 
-	proc, err := metrics.NewProcessor(oCfg.MetricStatements, oCfg.ErrorMode, set.TelemetrySettings)
+	userFunctions, err := oCfg.newUserFunctions()
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
+	metricStatements, err := expandUserFunctions(userFunctions, oCfg.MetricStatements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	metricStatements = withStatementErrors(set, oCfg, telemetryBuilder, "metric_statements", metricStatements)
+	proc, err := metrics.NewProcessor(metricStatements, oCfg.ErrorMode, set.TelemetrySettings)
+	if err != nil {
+		telemetryBuilder.Shutdown()
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
 	return processorhelper.NewMetrics(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
 		proc.ProcessMetrics,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithShutdown(shutdownTelemetry(telemetryBuilder)))
+}
+
+func createProfilesProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer xconsumer.Profiles,
+) (xprocessor.Profiles, error) {
+	oCfg := cfg.(*Config)
+
+	userFunctions, err := oCfg.newUserFunctions()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	profileStatements, err := expandUserFunctions(userFunctions, oCfg.ProfileStatements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	profileStatements = withStatementErrors(set, oCfg, telemetryBuilder, "profile_statements", profileStatements)
+	proc, err := profiles.NewProcessor(profileStatements, oCfg.ErrorMode, set.TelemetrySettings)
+	if err != nil {
+		telemetryBuilder.Shutdown()
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
+	return xprocessorhelper.NewProfiles(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		proc.ProcessProfiles,
+		xprocessorhelper.WithCapabilities(processorCapabilities),
+		xprocessorhelper.WithShutdown(shutdownTelemetry(telemetryBuilder)))
+}
+
+// withStatementErrors returns a copy of the context statements reporting the statements failing
+// to execute with the instruments of the processor. The groups are identified by the key of the
+// signal statements in the configuration and their index.
+func withStatementErrors(set processor.Settings, cfg *Config, telemetryBuilder *metadata.TelemetryBuilder, key string, contextStatements []common.ContextStatements) []common.ContextStatements {
+	result := make([]common.ContextStatements, len(contextStatements))
+	for i, cs := range contextStatements {
+		cs.StatementErrors = common.NewStatementErrors(
+			telemetryBuilder.ProcessorTransformStatementsFailed,
+			cfg.ErrorAttribute,
+			cs.StatementIndexes,
+			attribute.String(metadata.Type.String(), set.ID.String()),
+			attribute.String("group", fmt.Sprintf("%s[%d]", key, i)),
+		)
+		result[i] = cs
+	}
+	return result
+}
+
+// shutdownTelemetry returns a function shutting down the telemetry of a processor.
+func shutdownTelemetry(telemetryBuilder *metadata.TelemetryBuilder) component.ShutdownFunc {
+	return func(context.Context) error {
+		telemetryBuilder.Shutdown()
+		return nil
+	}
 }
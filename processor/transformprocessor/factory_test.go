@@ -13,8 +13,10 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/xprocessor"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
@@ -30,10 +32,11 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
 	assert.Equal(t, &Config{
-		ErrorMode:        ottl.PropagateError,
-		TraceStatements:  []common.ContextStatements{},
-		MetricStatements: []common.ContextStatements{},
-		LogStatements:    []common.ContextStatements{},
+		ErrorMode:         ottl.PropagateError,
+		TraceStatements:   []common.ContextStatements{},
+		MetricStatements:  []common.ContextStatements{},
+		LogStatements:     []common.ContextStatements{},
+		ProfileStatements: []common.ContextStatements{},
 	}, cfg)
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
@@ -93,6 +96,74 @@ func TestFactoryCreateTraces(t *testing.T) {
 	assert.Equal(t, "pass", val.Str())
 }
 
+func TestFactoryCreateTraces_UserFunctions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.UserFunctions = []ottl.UserFunction{
+		{
+			Name:       "Greeting",
+			Params:     []string{"who"},
+			Expression: `Concat(["hello", who], " ")`,
+		},
+		{
+			Name:   "greet",
+			Params: []string{"target", "who"},
+			Statements: []string{
+				`set(target, Greeting(who))`,
+			},
+		},
+	}
+	oCfg.TraceStatements = []common.ContextStatements{
+		{
+			Context:    "span",
+			Conditions: []string{`Greeting(name) == "hello operationA"`},
+			Statements: []string{`greet(attributes["test"], name)`},
+		},
+	}
+	tp, err := factory.CreateTraces(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.NotNil(t, tp)
+	assert.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spanA := spans.AppendEmpty()
+	spanA.SetName("operationA")
+	spanB := spans.AppendEmpty()
+	spanB.SetName("operationB")
+
+	err = tp.ConsumeTraces(context.Background(), td)
+	assert.NoError(t, err)
+
+	val, ok := spanA.Attributes().Get("test")
+	assert.True(t, ok)
+	assert.Equal(t, "hello operationA", val.Str())
+	_, ok = spanB.Attributes().Get("test")
+	assert.False(t, ok)
+}
+
+func TestFactoryCreateTraces_InvalidUserFunctions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.UserFunctions = []ottl.UserFunction{
+		{
+			Name:       "Greeting",
+			Params:     []string{"who"},
+			Expression: `Concat(["hello", who], " ")`,
+		},
+	}
+	oCfg.TraceStatements = []common.ContextStatements{
+		{
+			Context:    "span",
+			Statements: []string{`set(attributes["test"], Greeting())`},
+		},
+	}
+	tp, err := factory.CreateTraces(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.ErrorContains(t, err, `user function "Greeting" expects 1 arguments but got 0`)
+	assert.Nil(t, tp)
+}
+
 func TestFactoryCreateMetrics_InvalidActions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -190,6 +261,51 @@ func TestFactoryCreateLogs_InvalidActions(t *testing.T) {
 	assert.Nil(t, ap)
 }
 
+func TestFactoryCreateProfiles(t *testing.T) {
+	factory := NewFactory().(xprocessor.Factory)
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ErrorMode = ottl.IgnoreError
+	oCfg.ProfileStatements = []common.ContextStatements{
+		{
+			Context: "profile",
+			Statements: []string{
+				`set(attributes["test"], "pass") where original_payload_format == "pprof"`,
+				`set(attributes["test error mode"], ParseJSON(1)) where original_payload_format == "pprof"`,
+			},
+		},
+	}
+	pp, err := factory.CreateProfiles(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.NotNil(t, pp)
+	assert.NoError(t, err)
+
+	pd := pprofile.NewProfiles()
+	profile := pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+	profile.SetOriginalPayloadFormat("pprof")
+
+	err = pp.ConsumeProfiles(context.Background(), pd)
+	assert.NoError(t, err)
+
+	val, ok := pprofile.FromAttributeIndices(profile.AttributeTable(), profile).Get("test")
+	assert.True(t, ok)
+	assert.Equal(t, "pass", val.Str())
+}
+
+func TestFactoryCreateProfiles_InvalidActions(t *testing.T) {
+	factory := NewFactory().(xprocessor.Factory)
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ProfileStatements = []common.ContextStatements{
+		{
+			Context:    "profile",
+			Statements: []string{`set(123`},
+		},
+	}
+	pp, err := factory.CreateProfiles(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, pp)
+}
+
 func TestFactoryCreateLogProcessor(t *testing.T) {
 	tests := []struct {
 		name       string
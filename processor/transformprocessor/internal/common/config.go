@@ -19,15 +19,17 @@ const (
 	Scope     ContextID = "scope"
 	Span      ContextID = "span"
 	SpanEvent ContextID = "spanevent"
+	SpanLink  ContextID = "spanlink"
 	Metric    ContextID = "metric"
 	DataPoint ContextID = "datapoint"
 	Log       ContextID = "log"
+	Profile   ContextID = "profile"
 )
 
 func (c *ContextID) UnmarshalText(text []byte) error {
 	str := ContextID(strings.ToLower(string(text)))
 	switch str {
-	case Resource, Scope, Span, SpanEvent, Metric, DataPoint, Log:
+	case Resource, Scope, Span, SpanEvent, SpanLink, Metric, DataPoint, Log, Profile:
 		*c = str
 		return nil
 	default:
@@ -46,6 +48,13 @@ type ContextStatements struct {
 	// When enabled, it allows the statements cache to be shared across all other groups that share the cache.
 	// This feature is not configurable via `mapstructure` and cannot be set in configuration files.
 	SharedCache bool `mapstructure:"-"`
+	// StatementErrors reports the statements of the group failing to execute. It is set by the
+	// processor and cannot be set in configuration files.
+	StatementErrors *StatementErrors `mapstructure:"-"`
+	// StatementIndexes holds, for each statement, the index of the configured statement it was
+	// expanded from when user functions are used. It is set by the processor and cannot be set
+	// in configuration files.
+	StatementIndexes []int `mapstructure:"-"`
 }
 
 func (c ContextStatements) GetStatements() []string {
@@ -35,15 +35,16 @@ func (l logStatements) ConsumeLogs(ctx context.Context, ld plog.Logs, cache *pco
 		rlogs := ld.ResourceLogs().At(i)
 		for j := 0; j < rlogs.ScopeLogs().Len(); j++ {
 			slogs := rlogs.ScopeLogs().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
 			logs := slogs.LogRecords()
 			for k := 0; k < logs.Len(); k++ {
 				tCtx := ottllog.NewTransformContext(logs.At(k), slogs.Scope(), rlogs.Resource(), slogs, rlogs, ottllog.WithCache(cache))
-				condition, err := l.BoolExpr.Eval(ctx, tCtx)
+				condition, err := l.BoolExpr.Eval(scopeCtx, tCtx)
 				if err != nil {
 					return err
 				}
 				if condition {
-					err := l.Execute(ctx, tCtx)
+					err := l.Execute(scopeCtx, tCtx)
 					if err != nil {
 						return err
 					}
@@ -108,7 +109,10 @@ func convertLogStatements(pc *ottl.ParserCollection[LogsConsumer], _ *ottl.Parse
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, ottllog.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, Log, func(tCtx ottllog.TransformContext, key string, value string) {
+		tCtx.GetLogRecord().Attributes().PutStr(key, value)
+	})
+	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, ottllog.WithStatementSequenceErrorMode(errorMode), ottllog.WithStatementSequenceErrorHandler(errorHandler))
 	return logStatements{lStatements, globalExpr}, nil
 }
 
@@ -119,3 +123,10 @@ func (lpc *LogParserCollection) ParseContextStatements(contextStatements Context
 	}
 	return pc.ParseStatements(contextStatements)
 }
+
+// ValidateUserFunctions returns an error if a user function has the name of a function, or one of
+// its parameters the name of a path, of one of the log contexts.
+func (lpc *LogParserCollection) ValidateUserFunctions(userFunctions *ottl.UserFunctions) error {
+	pc := ottl.ParserCollection[LogsConsumer](*lpc)
+	return pc.ValidateUserFunctions(userFunctions)
+}
@@ -36,15 +36,16 @@ func (m metricStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 		rmetrics := md.ResourceMetrics().At(i)
 		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
 			smetrics := rmetrics.ScopeMetrics().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
 			metrics := smetrics.Metrics()
 			for k := 0; k < metrics.Len(); k++ {
 				tCtx := ottlmetric.NewTransformContext(metrics.At(k), smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, ottlmetric.WithCache(cache))
-				condition, err := m.BoolExpr.Eval(ctx, tCtx)
+				condition, err := m.BoolExpr.Eval(scopeCtx, tCtx)
 				if err != nil {
 					return err
 				}
 				if condition {
-					err := m.Execute(ctx, tCtx)
+					err := m.Execute(scopeCtx, tCtx)
 					if err != nil {
 						return err
 					}
@@ -69,6 +70,7 @@ func (d dataPointStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metr
 		rmetrics := md.ResourceMetrics().At(i)
 		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
 			smetrics := rmetrics.ScopeMetrics().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
 			metrics := smetrics.Metrics()
 			for k := 0; k < metrics.Len(); k++ {
 				metric := metrics.At(k)
@@ -77,15 +79,15 @@ func (d dataPointStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metr
 				//exhaustive:enforce
 				switch metric.Type() {
 				case pmetric.MetricTypeSum:
-					err = d.handleNumberDataPoints(ctx, metric.Sum().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
+					err = d.handleNumberDataPoints(scopeCtx, metric.Sum().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
 				case pmetric.MetricTypeGauge:
-					err = d.handleNumberDataPoints(ctx, metric.Gauge().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
+					err = d.handleNumberDataPoints(scopeCtx, metric.Gauge().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
 				case pmetric.MetricTypeHistogram:
-					err = d.handleHistogramDataPoints(ctx, metric.Histogram().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
+					err = d.handleHistogramDataPoints(scopeCtx, metric.Histogram().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
 				case pmetric.MetricTypeExponentialHistogram:
-					err = d.handleExponentialHistogramDataPoints(ctx, metric.ExponentialHistogram().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
+					err = d.handleExponentialHistogramDataPoints(scopeCtx, metric.ExponentialHistogram().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
 				case pmetric.MetricTypeSummary:
-					err = d.handleSummaryDataPoints(ctx, metric.Summary().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
+					err = d.handleSummaryDataPoints(scopeCtx, metric.Summary().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics, transformContextOptions)
 				}
 				if err != nil {
 					return err
@@ -228,7 +230,10 @@ func convertMetricStatements(pc *ottl.ParserCollection[MetricsConsumer], _ *ottl
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, ottlmetric.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, Metric, func(tCtx ottlmetric.TransformContext, key string, value string) {
+		tCtx.GetMetric().Metadata().PutStr(key, value)
+	})
+	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, ottlmetric.WithStatementSequenceErrorMode(errorMode), ottlmetric.WithStatementSequenceErrorHandler(errorHandler))
 	return metricStatements{mStatements, globalExpr}, nil
 }
 
@@ -249,7 +254,10 @@ func convertDataPointStatements(pc *ottl.ParserCollection[MetricsConsumer], _ *o
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, ottldatapoint.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, DataPoint, func(tCtx ottldatapoint.TransformContext, key string, value string) {
+		setDataPointAttribute(tCtx.GetDataPoint(), key, value)
+	})
+	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, ottldatapoint.WithStatementSequenceErrorMode(errorMode), ottldatapoint.WithStatementSequenceErrorHandler(errorHandler))
 	return dataPointStatements{dpStatements, globalExpr}, nil
 }
 
@@ -260,3 +268,10 @@ func (mpc *MetricParserCollection) ParseContextStatements(contextStatements Cont
 	}
 	return pc.ParseStatements(contextStatements)
 }
+
+// ValidateUserFunctions returns an error if a user function has the name of a function, or one of
+// its parameters the name of a path, of one of the metric contexts.
+func (mpc *MetricParserCollection) ValidateUserFunctions(userFunctions *ottl.UserFunctions) error {
+	pc := ottl.ParserCollection[MetricsConsumer](*mpc)
+	return pc.ValidateUserFunctions(userFunctions)
+}
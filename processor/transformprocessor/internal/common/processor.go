@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
@@ -84,6 +85,24 @@ func (r resourceStatements) ConsumeLogs(ctx context.Context, ld plog.Logs, cache
 	return nil
 }
 
+func (r resourceStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		tCtx := ottlresource.NewTransformContext(rprofiles.Resource(), rprofiles, ottlresource.WithCache(cache))
+		condition, err := r.BoolExpr.Eval(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if condition {
+			err := r.Execute(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 var _ baseContext = &scopeStatements{}
 
 type scopeStatements struct {
@@ -158,10 +177,32 @@ func (s scopeStatements) ConsumeLogs(ctx context.Context, ld plog.Logs, cache *p
 	return nil
 }
 
+func (s scopeStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			tCtx := ottlscope.NewTransformContext(sprofiles.Scope(), rprofiles.Resource(), sprofiles, ottlscope.WithCache(cache))
+			condition, err := s.BoolExpr.Eval(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+			if condition {
+				err := s.Execute(ctx, tCtx)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 type baseContext interface {
 	TracesConsumer
 	MetricsConsumer
 	LogsConsumer
+	ProfilesConsumer
 }
 
 func withCommonContextParsers[R any]() ottl.ParserCollectionOption[R] {
@@ -212,7 +253,10 @@ func parseResourceContextStatements[R any](
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, ottlresource.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, Resource, func(tCtx ottlresource.TransformContext, key string, value string) {
+		tCtx.GetResource().Attributes().PutStr(key, value)
+	})
+	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, ottlresource.WithStatementSequenceErrorMode(errorMode), ottlresource.WithStatementSequenceErrorHandler(errorHandler))
 	result := (baseContext)(resourceStatements{rStatements, globalExpr})
 	return result.(R), nil
 }
@@ -240,7 +284,10 @@ func parseScopeContextStatements[R any](
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, ottlscope.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, Scope, func(tCtx ottlscope.TransformContext, key string, value string) {
+		tCtx.GetInstrumentationScope().Attributes().PutStr(key, value)
+	})
+	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, ottlscope.WithStatementSequenceErrorMode(errorMode), ottlscope.WithStatementSequenceErrorHandler(errorHandler))
 	result := (baseContext)(scopeStatements{sStatements, globalExpr})
 	return result.(R), nil
 }
@@ -259,3 +306,10 @@ func parseGlobalExpr[K any, O any](
 	// By default, set the global expression to always true unless conditions are specified.
 	return expr.AlwaysTrue[K](), nil
 }
+
+// withScopeConditionCache returns a context letting the statements executed for the records of
+// a single scope skip the where clauses that only read the resource and scope and were already
+// false for a previous record.
+func withScopeConditionCache(ctx context.Context) context.Context {
+	return ottl.WithConditionCache(ctx, ottlresource.ContextName, ottlscope.ContextName, ottlscope.LegacyContextName)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+)
+
+type ProfilesConsumer interface {
+	Context() ContextID
+	ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error
+}
+
+type profileStatements struct {
+	ottl.StatementSequence[ottlprofile.TransformContext]
+	expr.BoolExpr[ottlprofile.TransformContext]
+}
+
+func (p profileStatements) Context() ContextID {
+	return Profile
+}
+
+func (p profileStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
+			profiles := sprofiles.Profiles()
+			for k := 0; k < profiles.Len(); k++ {
+				tCtx := ottlprofile.NewTransformContext(profiles.At(k), sprofiles.Scope(), rprofiles.Resource(), sprofiles, rprofiles, ottlprofile.WithCache(cache))
+				condition, err := p.BoolExpr.Eval(scopeCtx, tCtx)
+				if err != nil {
+					return err
+				}
+				if condition {
+					err := p.Execute(scopeCtx, tCtx)
+					if err != nil {
+						return err
+					}
+					// The attributes of a profile are resolved from its attribute table,
+					// so the modifications made in place by editors are written back.
+					if err = tCtx.WriteAttributes(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type ProfileParserCollection ottl.ParserCollection[ProfilesConsumer]
+
+type ProfileParserCollectionOption ottl.ParserCollectionOption[ProfilesConsumer]
+
+func WithProfileParser(functions map[string]ottl.Factory[ottlprofile.TransformContext]) ProfileParserCollectionOption {
+	return func(pc *ottl.ParserCollection[ProfilesConsumer]) error {
+		profileParser, err := ottlprofile.NewParser(functions, pc.Settings, ottlprofile.EnablePathContextNames())
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext(ottlprofile.ContextName, &profileParser, convertProfileStatements)(pc)
+	}
+}
+
+func WithProfileErrorMode(errorMode ottl.ErrorMode) ProfileParserCollectionOption {
+	return ProfileParserCollectionOption(ottl.WithParserCollectionErrorMode[ProfilesConsumer](errorMode))
+}
+
+func NewProfileParserCollection(settings component.TelemetrySettings, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
+	pcOptions := []ottl.ParserCollectionOption[ProfilesConsumer]{
+		withCommonContextParsers[ProfilesConsumer](),
+		ottl.EnableParserCollectionModifiedStatementLogging[ProfilesConsumer](true),
+	}
+
+	for _, option := range options {
+		pcOptions = append(pcOptions, ottl.ParserCollectionOption[ProfilesConsumer](option))
+	}
+
+	pc, err := ottl.NewParserCollection(settings, pcOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	ppc := ProfileParserCollection(*pc)
+	return &ppc, nil
+}
+
+func convertProfileStatements(pc *ottl.ParserCollection[ProfilesConsumer], _ *ottl.Parser[ottlprofile.TransformContext], _ string, statements ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottlprofile.TransformContext]) (ProfilesConsumer, error) {
+	contextStatements, err := toContextStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	errorMode := pc.ErrorMode
+	if contextStatements.ErrorMode != "" {
+		errorMode = contextStatements.ErrorMode
+	}
+	var parserOptions []ottl.Option[ottlprofile.TransformContext]
+	if contextStatements.Context == "" {
+		parserOptions = append(parserOptions, ottlprofile.EnablePathContextNames())
+	}
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForProfileWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardProfileFuncs(), parserOptions)
+	if errGlobalBoolExpr != nil {
+		return nil, errGlobalBoolExpr
+	}
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, Profile, func(tCtx ottlprofile.TransformContext, key string, value string) {
+		// Written back to the profile with the other attributes once the statements were executed.
+		tCtx.GetProfileAttributes().PutStr(key, value)
+	})
+	pStatements := ottlprofile.NewStatementSequence(parsedStatements, pc.Settings, ottlprofile.WithStatementSequenceErrorMode(errorMode), ottlprofile.WithStatementSequenceErrorHandler(errorHandler))
+	return profileStatements{pStatements, globalExpr}, nil
+}
+
+func (ppc *ProfileParserCollection) ParseContextStatements(contextStatements ContextStatements) (ProfilesConsumer, error) {
+	pc := ottl.ParserCollection[ProfilesConsumer](*ppc)
+	if contextStatements.Context != "" {
+		return pc.ParseStatementsWithContext(string(contextStatements.Context), contextStatements, true)
+	}
+	return pc.ParseStatements(contextStatements)
+}
+
+// InferContext returns the context ParseContextStatements parses the statements with: the
+// configured context, or else the context inferred from the statements.
+func (ppc *ProfileParserCollection) InferContext(contextStatements ContextStatements) (string, error) {
+	if contextStatements.Context != "" {
+		return string(contextStatements.Context), nil
+	}
+	pc := ottl.ParserCollection[ProfilesConsumer](*ppc)
+	return pc.InferContext(contextStatements)
+}
+
+// ValidateUserFunctions returns an error if a user function has the name of a function, or one of
+// its parameters the name of a path, of one of the profile contexts.
+func (ppc *ProfileParserCollection) ValidateUserFunctions(userFunctions *ottl.UserFunctions) error {
+	pc := ottl.ParserCollection[ProfilesConsumer](*ppc)
+	return pc.ValidateUserFunctions(userFunctions)
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// StatementErrors reports the statements of a group failing to execute, whatever the error mode
+// is: it counts the errors per statement and, when an error attribute is configured, sets it on
+// the telemetry the statement failed for, so it can be routed elsewhere.
+type StatementErrors struct {
+	counter          metric.Int64Counter
+	attributes       []attribute.KeyValue
+	errorAttribute   string
+	statementIndexes []int
+}
+
+// NewStatementErrors creates a StatementErrors adding the errors to the given counter, with the
+// given attributes identifying the processor and the group. The errorAttribute is the name of the
+// attribute set on the failing telemetry, or empty to leave it untouched. The statementIndexes are
+// the indexes of the configured statements the statements of the group were expanded from, or nil
+// if they were not expanded.
+func NewStatementErrors(counter metric.Int64Counter, errorAttribute string, statementIndexes []int, attributes ...attribute.KeyValue) *StatementErrors {
+	return &StatementErrors{
+		counter:          counter,
+		attributes:       attributes,
+		errorAttribute:   errorAttribute,
+		statementIndexes: statementIndexes,
+	}
+}
+
+// record counts an error of the statement at the given index of the group. The statements are
+// identified by the index of the configured statement rather than by their text, to bound the
+// cardinality of the metric.
+func (s *StatementErrors) record(ctx context.Context, contextID ContextID, index int) {
+	if index < len(s.statementIndexes) {
+		index = s.statementIndexes[index]
+	}
+	attributes := make([]attribute.KeyValue, 0, len(s.attributes)+2)
+	attributes = append(attributes, s.attributes...)
+	attributes = append(attributes, attribute.String("context", string(contextID)), attribute.Int("statement", index))
+	s.counter.Add(ctx, 1, metric.WithAttributes(attributes...))
+}
+
+// newStatementErrorHandler returns the handler reporting the errors of the statements of a group
+// executed in the given context, using setAttribute to tag the telemetry of the context.
+func newStatementErrorHandler[K any](statementErrors *StatementErrors, contextID ContextID, setAttribute func(tCtx K, key string, value string)) ottl.StatementErrorHandler[K] {
+	if statementErrors == nil {
+		return nil
+	}
+	return func(ctx context.Context, tCtx K, index int, statement string, err error) {
+		statementErrors.record(ctx, contextID, index)
+		if statementErrors.errorAttribute != "" {
+			setAttribute(tCtx, statementErrors.errorAttribute, fmt.Sprintf("failed to execute statement: %v, %v", statement, err))
+		}
+	}
+}
+
+func setDataPointAttribute(dataPoint any, key string, value string) {
+	var attributes pcommon.Map
+	switch dp := dataPoint.(type) {
+	case pmetric.NumberDataPoint:
+		attributes = dp.Attributes()
+	case pmetric.HistogramDataPoint:
+		attributes = dp.Attributes()
+	case pmetric.ExponentialHistogramDataPoint:
+		attributes = dp.Attributes()
+	case pmetric.SummaryDataPoint:
+		attributes = dp.Attributes()
+	default:
+		return
+	}
+	attributes.PutStr(key, value)
+}
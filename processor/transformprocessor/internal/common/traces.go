@@ -15,6 +15,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 )
 
 type TracesConsumer interface {
@@ -36,15 +37,16 @@ func (t traceStatements) ConsumeTraces(ctx context.Context, td ptrace.Traces, ca
 		rspans := td.ResourceSpans().At(i)
 		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
 			sspans := rspans.ScopeSpans().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
 			spans := sspans.Spans()
 			for k := 0; k < spans.Len(); k++ {
 				tCtx := ottlspan.NewTransformContext(spans.At(k), sspans.Scope(), rspans.Resource(), sspans, rspans, ottlspan.WithCache(cache))
-				condition, err := t.BoolExpr.Eval(ctx, tCtx)
+				condition, err := t.BoolExpr.Eval(scopeCtx, tCtx)
 				if err != nil {
 					return err
 				}
 				if condition {
-					err := t.Execute(ctx, tCtx)
+					err := t.Execute(scopeCtx, tCtx)
 					if err != nil {
 						return err
 					}
@@ -69,18 +71,57 @@ func (s spanEventStatements) ConsumeTraces(ctx context.Context, td ptrace.Traces
 		rspans := td.ResourceSpans().At(i)
 		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
 			sspans := rspans.ScopeSpans().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
 			spans := sspans.Spans()
 			for k := 0; k < spans.Len(); k++ {
 				span := spans.At(k)
 				spanEvents := span.Events()
 				for n := 0; n < spanEvents.Len(); n++ {
 					tCtx := ottlspanevent.NewTransformContext(spanEvents.At(n), span, sspans.Scope(), rspans.Resource(), sspans, rspans, ottlspanevent.WithCache(cache))
-					condition, err := s.BoolExpr.Eval(ctx, tCtx)
+					condition, err := s.BoolExpr.Eval(scopeCtx, tCtx)
 					if err != nil {
 						return err
 					}
 					if condition {
-						err := s.Execute(ctx, tCtx)
+						err := s.Execute(scopeCtx, tCtx)
+						if err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type spanLinkStatements struct {
+	ottl.StatementSequence[ottlspanlink.TransformContext]
+	expr.BoolExpr[ottlspanlink.TransformContext]
+}
+
+func (s spanLinkStatements) Context() ContextID {
+	return SpanLink
+}
+
+func (s spanLinkStatements) ConsumeTraces(ctx context.Context, td ptrace.Traces, cache *pcommon.Map) error {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rspans := td.ResourceSpans().At(i)
+		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
+			sspans := rspans.ScopeSpans().At(j)
+			scopeCtx := withScopeConditionCache(ctx)
+			spans := sspans.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				spanLinks := span.Links()
+				for n := 0; n < spanLinks.Len(); n++ {
+					tCtx := ottlspanlink.NewTransformContext(spanLinks.At(n), span, sspans.Scope(), rspans.Resource(), sspans, rspans, ottlspanlink.WithCache(cache))
+					condition, err := s.BoolExpr.Eval(scopeCtx, tCtx)
+					if err != nil {
+						return err
+					}
+					if condition {
+						err := s.Execute(scopeCtx, tCtx)
 						if err != nil {
 							return err
 						}
@@ -116,6 +157,16 @@ func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.Transfo
 	}
 }
 
+func WithSpanLinkParser(functions map[string]ottl.Factory[ottlspanlink.TransformContext]) TraceParserCollectionOption {
+	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
+		parser, err := ottlspanlink.NewParser(functions, pc.Settings, ottlspanlink.EnablePathContextNames())
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext(ottlspanlink.ContextName, &parser, convertSpanLinkStatements)(pc)
+	}
+}
+
 func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	return TraceParserCollectionOption(ottl.WithParserCollectionErrorMode[TracesConsumer](errorMode))
 }
@@ -156,7 +207,10 @@ func convertSpanStatements(pc *ottl.ParserCollection[TracesConsumer], _ *ottl.Pa
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, ottlspan.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, Span, func(tCtx ottlspan.TransformContext, key string, value string) {
+		tCtx.GetSpan().Attributes().PutStr(key, value)
+	})
+	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, ottlspan.WithStatementSequenceErrorMode(errorMode), ottlspan.WithStatementSequenceErrorHandler(errorHandler))
 	return traceStatements{sStatements, globalExpr}, nil
 }
 
@@ -177,10 +231,37 @@ func convertSpanEventStatements(pc *ottl.ParserCollection[TracesConsumer], _ *ot
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, ottlspanevent.WithStatementSequenceErrorMode(errorMode))
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, SpanEvent, func(tCtx ottlspanevent.TransformContext, key string, value string) {
+		tCtx.GetSpanEvent().Attributes().PutStr(key, value)
+	})
+	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, ottlspanevent.WithStatementSequenceErrorMode(errorMode), ottlspanevent.WithStatementSequenceErrorHandler(errorHandler))
 	return spanEventStatements{seStatements, globalExpr}, nil
 }
 
+func convertSpanLinkStatements(pc *ottl.ParserCollection[TracesConsumer], _ *ottl.Parser[ottlspanlink.TransformContext], _ string, statements ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottlspanlink.TransformContext]) (TracesConsumer, error) {
+	contextStatements, err := toContextStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	errorMode := pc.ErrorMode
+	if contextStatements.ErrorMode != "" {
+		errorMode = contextStatements.ErrorMode
+	}
+	var parserOptions []ottl.Option[ottlspanlink.TransformContext]
+	if contextStatements.Context == "" {
+		parserOptions = append(parserOptions, ottlspanlink.EnablePathContextNames())
+	}
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSpanLinkWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardSpanLinkFuncs(), parserOptions)
+	if errGlobalBoolExpr != nil {
+		return nil, errGlobalBoolExpr
+	}
+	errorHandler := newStatementErrorHandler(contextStatements.StatementErrors, SpanLink, func(tCtx ottlspanlink.TransformContext, key string, value string) {
+		tCtx.GetSpanLink().Attributes().PutStr(key, value)
+	})
+	slStatements := ottlspanlink.NewStatementSequence(parsedStatements, pc.Settings, ottlspanlink.WithStatementSequenceErrorMode(errorMode), ottlspanlink.WithStatementSequenceErrorHandler(errorHandler))
+	return spanLinkStatements{slStatements, globalExpr}, nil
+}
+
 func (tpc *TraceParserCollection) ParseContextStatements(contextStatements ContextStatements) (TracesConsumer, error) {
 	pc := ottl.ParserCollection[TracesConsumer](*tpc)
 	if contextStatements.Context != "" {
@@ -188,3 +269,10 @@ func (tpc *TraceParserCollection) ParseContextStatements(contextStatements Conte
 	}
 	return pc.ParseStatements(contextStatements)
 }
+
+// ValidateUserFunctions returns an error if a user function has the name of a function, or one of
+// its parameters the name of a path, of one of the trace contexts.
+func (tpc *TraceParserCollection) ValidateUserFunctions(userFunctions *ottl.UserFunctions) error {
+	pc := ottl.ParserCollection[TracesConsumer](*tpc)
+	return pc.ValidateUserFunctions(userFunctions)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/profiles"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+type parsedContextStatements struct {
+	common.ProfilesConsumer
+	sharedCache bool
+}
+
+type Processor struct {
+	contexts []parsedContextStatements
+	logger   *zap.Logger
+}
+
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings) (*Processor, error) {
+	pc, err := common.NewProfileParserCollection(settings, common.WithProfileParser(ProfileFunctions()), common.WithProfileErrorMode(errorMode))
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make([]parsedContextStatements, len(contextStatements))
+	var errors error
+	for i, cs := range contextStatements {
+		context, err := pc.ParseContextStatements(cs)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+		}
+		contexts[i] = parsedContextStatements{context, cs.SharedCache}
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+
+	return &Processor{
+		contexts: contexts,
+		logger:   settings.Logger,
+	}, nil
+}
+
+func (p *Processor) ProcessProfiles(ctx context.Context, pd pprofile.Profiles) (pprofile.Profiles, error) {
+	sharedContextCache := make(map[common.ContextID]*pcommon.Map, len(p.contexts))
+	for _, c := range p.contexts {
+		var cache *pcommon.Map
+		if c.sharedCache {
+			cache = common.LoadContextCache(sharedContextCache, c.Context())
+		}
+		err := c.ConsumeProfiles(ctx, pd, cache)
+		if err != nil {
+			p.logger.Error("failed processing profiles", zap.Error(err))
+			return pd, err
+		}
+	}
+	return pd, nil
+}
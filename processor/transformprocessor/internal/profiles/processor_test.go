@@ -0,0 +1,332 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+var (
+	TestProfileTime      = time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC)
+	TestProfileTimestamp = pcommon.NewTimestampFromTime(TestProfileTime)
+
+	profileID = pprofile.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+)
+
+func Test_ProcessProfiles_ResourceContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(attributes["test"], "pass")`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).Resource().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where attributes["host.name"] == "wrong"`,
+			want: func(_ pprofile.Profiles) {
+			},
+		},
+		{
+			statement: `set(schema_url, "test_schema_url_2")`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).SetSchemaUrl("test_schema_url_2")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_ScopeContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where name == "scope"`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).ScopeProfiles().At(0).Scope().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where version == "2"`,
+			want: func(_ pprofile.Profiles) {
+			},
+		},
+		{
+			statement: `set(schema_url, "test_schema_url_2")`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).ScopeProfiles().At(0).SetSchemaUrl("test_schema_url_2")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_ProfileContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where original_payload_format == "pprof"`,
+			want: func(td pprofile.Profiles) {
+				putAttribute(t, td, 0, "test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where resource.attributes["host.name"] == "localhost"`,
+			want: func(td pprofile.Profiles) {
+				putAttribute(t, td, 0, "test", "pass")
+				putAttribute(t, td, 1, "test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where attributes["http.method"] == "post"`,
+			want: func(_ pprofile.Profiles) {
+			},
+		},
+		{
+			statement: `set(original_payload_format, "jfr") where duration > Duration("1m")`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(1).SetOriginalPayloadFormat("jfr")
+			},
+		},
+		{
+			statement: `delete_key(attributes, "http.method") where original_payload_format == "pprof"`,
+			want: func(td pprofile.Profiles) {
+				// The entries of the attribute table are kept.
+				td.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).AttributeIndices().FromRaw([]int32{1})
+			},
+		},
+		{
+			statement: `set(attributes["sample.type"], sample_type[0]["type"])`,
+			want: func(td pprofile.Profiles) {
+				putAttribute(t, td, 0, "sample.type", "cpu")
+				putAttribute(t, td, 1, "sample.type", "cpu")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "profile", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_InferredContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(profile.attributes["test"], "pass") where profile.original_payload_format == "pprof"`,
+			want: func(td pprofile.Profiles) {
+				putAttribute(t, td, 0, "test", "pass")
+			},
+		},
+		{
+			statement: `set(resource.attributes["test"], "pass")`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).Resource().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(profile.dropped_attributes_count, 1) where instrumentation_scope.name == "scope"`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).SetDroppedAttributesCount(1)
+				td.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(1).SetDroppedAttributesCount(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_MixContext(t *testing.T) {
+	td := constructProfiles()
+	processor, err := NewProcessor([]common.ContextStatements{
+		{
+			Context:    "resource",
+			Statements: []string{`set(attributes["test"], "pass")`},
+		},
+		{
+			Context:    "profile",
+			Statements: []string{`set(attributes["test"], "pass") where resource.attributes["test"] == "pass"`},
+		},
+	}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessProfiles(context.Background(), td)
+	assert.NoError(t, err)
+
+	exTd := constructProfiles()
+	exTd.ResourceProfiles().At(0).Resource().Attributes().PutStr("test", "pass")
+	putAttribute(t, exTd, 0, "test", "pass")
+	putAttribute(t, exTd, 1, "test", "pass")
+
+	assert.Equal(t, exTd, td)
+}
+
+func Test_ProcessProfiles_ErrorMode(t *testing.T) {
+	tests := []struct {
+		context common.ContextID
+	}{
+		{
+			context: "resource",
+		},
+		{
+			context: "scope",
+		},
+		{
+			context: "profile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.context), func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_NewProcessor_ConditionsParse(t *testing.T) {
+	for _, ctx := range []string{"profile", "resource", "scope"} {
+		t.Run(ctx, func(t *testing.T) {
+			_, err := NewProcessor([]common.ContextStatements{
+				{
+					Statements: []string{fmt.Sprintf(`set(%s.cache["test"], "pass")`, ctx)},
+					Conditions: []string{fmt.Sprintf(`%s.cache["test"] == ""`, ctx)},
+				},
+			}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			_, err = NewProcessor([]common.ContextStatements{
+				{
+					Statements: []string{fmt.Sprintf(`set(%s.cache["test"], "pass")`, ctx)},
+					Conditions: []string{`cache["test"] == ""`},
+				},
+			}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			assert.ErrorContains(t, err, `missing context name for path "cache[test]"`)
+		})
+	}
+}
+
+func putAttribute(t *testing.T, td pprofile.Profiles, index int, key, value string) {
+	profile := td.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(index)
+	require.NoError(t, pprofile.AddAttribute(profile.AttributeTable(), profile, key, pcommon.NewValueStr(value)))
+}
+
+func constructProfiles() pprofile.Profiles {
+	td := pprofile.NewProfiles()
+	rs0 := td.ResourceProfiles().AppendEmpty()
+	rs0.SetSchemaUrl("test_schema_url")
+	rs0.Resource().Attributes().PutStr("host.name", "localhost")
+	rs0ils0 := rs0.ScopeProfiles().AppendEmpty()
+	rs0ils0.SetSchemaUrl("test_schema_url")
+	rs0ils0.Scope().SetName("scope")
+	fillProfileOne(rs0ils0.Profiles().AppendEmpty())
+	fillProfileTwo(rs0ils0.Profiles().AppendEmpty())
+	return td
+}
+
+func fillProfileOne(profile pprofile.Profile) {
+	profile.SetProfileID(profileID)
+	profile.SetTime(TestProfileTimestamp)
+	profile.SetDuration(pcommon.Timestamp(time.Second))
+	profile.SetOriginalPayloadFormat("pprof")
+	fillSampleType(profile)
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "http.method", pcommon.NewValueStr("get"))
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "http.path", pcommon.NewValueStr("/health"))
+}
+
+func fillProfileTwo(profile pprofile.Profile) {
+	profile.SetTime(TestProfileTimestamp)
+	profile.SetDuration(pcommon.Timestamp(2 * time.Minute))
+	profile.SetOriginalPayloadFormat("pprofext")
+	fillSampleType(profile)
+	_ = pprofile.AddAttribute(profile.AttributeTable(), profile, "http.method", pcommon.NewValueStr("get"))
+}
+
+func fillSampleType(profile pprofile.Profile) {
+	profile.StringTable().Append("", "cpu", "nanoseconds")
+	sampleType := profile.SampleType().AppendEmpty()
+	sampleType.SetTypeStrindex(1)
+	sampleType.SetUnitStrindex(2)
+	sampleType.SetAggregationTemporality(pprofile.AggregationTemporalityDelta)
+}
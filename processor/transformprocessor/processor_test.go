@@ -10,13 +10,20 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadatatest"
 )
 
 func TestFlattenDataDisabledByDefault(t *testing.T) {
@@ -96,6 +103,115 @@ func TestProcessLogsWithFlatten(t *testing.T) {
 	assert.NoError(t, plogtest.CompareLogs(expected, actual[0]))
 }
 
+func TestProcessLogsStatementErrors(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ErrorMode = ottl.IgnoreError
+	oCfg.ErrorAttribute = "ottl.error"
+	oCfg.LogStatements = []common.ContextStatements{
+		{
+			Context: "log",
+			Statements: []string{
+				`set(log.attributes["prefix"], Substring(log.body, 0, 5))`,
+			},
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	p, err := factory.CreateLogs(context.Background(), metadatatest.NewSettings(tel), oCfg, sink)
+	require.NoError(t, err)
+
+	input := plog.NewLogs()
+	logs := input.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	logs.AppendEmpty().Body().SetStr("hello world")
+	logs.AppendEmpty().Body().SetStr("hi")
+	logs.AppendEmpty().Body().SetStr("hey")
+
+	assert.NoError(t, p.ConsumeLogs(context.Background(), input))
+
+	actual := sink.AllLogs()
+	require.Len(t, actual, 1)
+	records := actual[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, map[string]any{"prefix": "hello"}, records.At(0).Attributes().AsRaw())
+	for i := 1; i < records.Len(); i++ {
+		errorValue, ok := records.At(i).Attributes().Get("ottl.error")
+		require.True(t, ok)
+		assert.Contains(t, errorValue.Str(), `failed to execute statement: set(log.attributes["prefix"], Substring(log.body, 0, 5))`)
+	}
+
+	metadatatest.AssertEqualProcessorTransformStatementsFailed(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value: 2,
+			Attributes: attribute.NewSet(
+				attribute.String("transform", "transform"),
+				attribute.String("group", "log_statements[0]"),
+				attribute.String("context", "log"),
+				attribute.Int("statement", 0),
+			),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessLogsStatementErrorsUserFunctions(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ErrorMode = ottl.IgnoreError
+	oCfg.UserFunctions = []ottl.UserFunction{
+		{
+			Name:   "set_prefix",
+			Params: []string{"length"},
+			Statements: []string{
+				`set(log.attributes["body"], log.body)`,
+				`set(log.attributes["prefix"], Substring(log.body, 0, length))`,
+			},
+		},
+	}
+	oCfg.LogStatements = []common.ContextStatements{
+		{
+			Context: "log",
+			Statements: []string{
+				`set(log.attributes["first"], true)`,
+				`set_prefix(5)`,
+			},
+		},
+	}
+	// A processor created from the same configuration in another pipeline has its own instruments.
+	otherTel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, otherTel.Shutdown(context.Background())) })
+	other, err := factory.CreateLogs(context.Background(), metadatatest.NewSettings(otherTel), oCfg, new(consumertest.LogsSink))
+	require.NoError(t, err)
+	require.NoError(t, other.Shutdown(context.Background()))
+
+	sink := new(consumertest.LogsSink)
+	p, err := factory.CreateLogs(context.Background(), metadatatest.NewSettings(tel), oCfg, sink)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, p.Shutdown(context.Background())) })
+
+	input := plog.NewLogs()
+	input.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hi")
+	assert.NoError(t, p.ConsumeLogs(context.Background(), input))
+
+	// The failing statement is the one expanded from the second configured statement.
+	metadatatest.AssertEqualProcessorTransformStatementsFailed(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value: 1,
+			Attributes: attribute.NewSet(
+				attribute.String("transform", "transform"),
+				attribute.String("group", "log_statements[0]"),
+				attribute.String("context", "log"),
+				attribute.Int("statement", 1),
+			),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
 func BenchmarkLogsWithoutFlatten(b *testing.B) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()